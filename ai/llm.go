@@ -0,0 +1,62 @@
+package ai
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/agoodway/workie/config"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/ollama"
+	"github.com/tmc/langchaingo/llms/openai"
+)
+
+// defaultOpenAIKeyEnv is read for the API key when ai.openai.api_key_env is not set
+const defaultOpenAIKeyEnv = "OPENAI_API_KEY"
+
+// NewLLM creates a client for the model configured in ai.model, talking to
+// Ollama by default or to an OpenAI-compatible API when the provider is "openai"
+func NewLLM(cfg *config.Config) (llms.Model, error) {
+	switch provider := cfg.GetAIProvider(); provider {
+	case "ollama":
+		opts := []ollama.Option{
+			ollama.WithModel(cfg.AI.Model.Name),
+		}
+		if cfg.AI.Ollama.BaseURL != "" {
+			opts = append(opts, ollama.WithServerURL(cfg.AI.Ollama.BaseURL))
+		}
+
+		llm, err := ollama.New(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Ollama client: %w", err)
+		}
+		return llm, nil
+
+	case "openai":
+		keyEnv := cfg.AI.OpenAI.APIKeyEnv
+		if keyEnv == "" {
+			keyEnv = defaultOpenAIKeyEnv
+		}
+		key := os.Getenv(keyEnv)
+		if key == "" {
+			return nil, fmt.Errorf("OpenAI API key not found in $%s\n\nTo fix this:\n  • Export your key: export %s=...\n  • Or set ai.openai.api_key_env to the variable that holds it", keyEnv, keyEnv)
+		}
+
+		opts := []openai.Option{
+			openai.WithModel(cfg.AI.Model.Name),
+			openai.WithToken(key),
+		}
+		if cfg.AI.OpenAI.BaseURL != "" {
+			opts = append(opts, openai.WithBaseURL(cfg.AI.OpenAI.BaseURL))
+		}
+
+		llm, err := openai.New(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OpenAI client: %w", err)
+		}
+		return llm, nil
+
+	default:
+		return nil, fmt.Errorf("unknown AI provider '%s'\n\nTo fix this:\n  • Set ai.model.provider to one of: %s", cfg.AI.Model.Provider, strings.Join(config.AIProviders, ", "))
+	}
+}
@@ -0,0 +1,46 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agoodway/workie/config"
+	"github.com/tmc/langchaingo/llms/ollama"
+	"github.com/tmc/langchaingo/llms/openai"
+)
+
+func TestNewLLM(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.AI.Model.Name = "llama3.2"
+
+	llm, err := NewLLM(cfg)
+	if err != nil {
+		t.Fatalf("Expected an Ollama client by default, got: %v", err)
+	}
+	if _, ok := llm.(*ollama.LLM); !ok {
+		t.Errorf("Expected an Ollama client, got %T", llm)
+	}
+
+	cfg.AI.Model.Provider = "openai"
+	cfg.AI.Model.Name = "gpt-4o-mini"
+	cfg.AI.OpenAI = config.OpenAIConfig{BaseURL: "https://llm.internal.example.com/v1", APIKeyEnv: "WORKIE_TEST_OPENAI_KEY"}
+
+	t.Setenv("WORKIE_TEST_OPENAI_KEY", "")
+	if _, err := NewLLM(cfg); err == nil || !strings.Contains(err.Error(), "$WORKIE_TEST_OPENAI_KEY") {
+		t.Errorf("Expected a missing key to name the variable, got: %v", err)
+	}
+
+	t.Setenv("WORKIE_TEST_OPENAI_KEY", "sk-test")
+	llm, err = NewLLM(cfg)
+	if err != nil {
+		t.Fatalf("Expected an OpenAI client, got: %v", err)
+	}
+	if _, ok := llm.(*openai.LLM); !ok {
+		t.Errorf("Expected an OpenAI client, got %T", llm)
+	}
+
+	cfg.AI.Model.Provider = "anthropic"
+	if _, err := NewLLM(cfg); err == nil || !strings.Contains(err.Error(), "unknown AI provider 'anthropic'") {
+		t.Errorf("Expected an unknown provider to be refused, got: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/agoodway/workie/config"
+)
+
+// defaultOllamaURL is used when ai.ollama.base_url is not set
+const defaultOllamaURL = "http://localhost:11434"
+
+// AvailableModels lists the models installed in the configured Ollama server
+func AvailableModels(ctx context.Context, cfg *config.Config) ([]string, error) {
+	baseURL := strings.TrimSuffix(cfg.AI.Ollama.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultOllamaURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+"/api/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Ollama at %s: %w", baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Ollama returned status %d listing models", resp.StatusCode)
+	}
+
+	var tags struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("failed to parse Ollama model list: %w", err)
+	}
+
+	names := make([]string, 0, len(tags.Models))
+	for _, model := range tags.Models {
+		names = append(names, model.Name)
+	}
+	return names, nil
+}
+
+// CheckModel returns an error if the model is not installed in the configured
+// Ollama server. A name without a tag matches its ":latest" version.
+func CheckModel(ctx context.Context, cfg *config.Config, model string) error {
+	names, err := AvailableModels(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if name == model || name == model+":latest" {
+			return nil
+		}
+	}
+	return fmt.Errorf("model '%s' is not installed (available: %s)\n\nTo fix this:\n  • Pull it with: ollama pull %s\n  • Or choose an installed model with --model", model, strings.Join(names, ", "), model)
+}
@@ -0,0 +1,35 @@
+package ai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/agoodway/workie/config"
+)
+
+func TestCheckModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/tags" {
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"models": [{"name": "llama3.2:latest"}, {"name": "zephyr:7b"}]}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.AI.Ollama.BaseURL = server.URL
+
+	for _, model := range []string{"llama3.2", "llama3.2:latest", "zephyr:7b"} {
+		if err := CheckModel(context.Background(), cfg, model); err != nil {
+			t.Errorf("Expected %s to be available, got: %v", model, err)
+		}
+	}
+
+	err := CheckModel(context.Background(), cfg, "zephyr")
+	if err == nil || !strings.Contains(err.Error(), "ollama pull zephyr") {
+		t.Errorf("Expected a not installed error for zephyr, got: %v", err)
+	}
+}
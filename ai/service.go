@@ -9,13 +9,13 @@ import (
 	"github.com/agoodway/workie/config"
 	"github.com/agoodway/workie/hooks"
 	"github.com/tmc/langchaingo/llms"
-	"github.com/tmc/langchaingo/llms/ollama"
 )
 
 // Service provides AI-powered decision making for hooks
 type Service struct {
 	llm    llms.Model
 	config *config.Config
+	usage  *UsageReporter
 }
 
 // NewService creates a new AI service
@@ -24,18 +24,9 @@ func NewService(cfg *config.Config) (*Service, error) {
 		return nil, fmt.Errorf("AI is not enabled in configuration")
 	}
 
-	// Create Ollama client
-	opts := []ollama.Option{
-		ollama.WithModel(cfg.AI.Model.Name),
-	}
-
-	if cfg.AI.Ollama.BaseURL != "" {
-		opts = append(opts, ollama.WithServerURL(cfg.AI.Ollama.BaseURL))
-	}
-
-	llm, err := ollama.New(opts...)
+	llm, err := NewLLM(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Ollama client: %w", err)
+		return nil, err
 	}
 
 	return &Service{
@@ -50,7 +41,7 @@ func (s *Service) AnalyzeToolUse(ctx context.Context, input *hooks.PreToolUseInp
 	prompt := s.buildDecisionPrompt(input, hookResults)
 
 	// Call the LLM
-	response, err := s.llm.Call(ctx, prompt)
+	response, err := s.usage.Wrap(s.llm, "hook_decision").Call(ctx, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call LLM: %w", err)
 	}
@@ -61,9 +52,14 @@ func (s *Service) AnalyzeToolUse(ctx context.Context, input *hooks.PreToolUseInp
 	return decision, nil
 }
 
-// CallLLM directly calls the LLM with a prompt
-func (s *Service) CallLLM(ctx context.Context, prompt string) (string, error) {
-	return s.llm.Call(ctx, prompt)
+// SetUsageReporter reports the usage of every call the service makes
+func (s *Service) SetUsageReporter(reporter *UsageReporter) {
+	s.usage = reporter
+}
+
+// CallLLM directly calls the LLM with a prompt; operation labels the call in usage reports
+func (s *Service) CallLLM(ctx context.Context, operation, prompt string) (string, error) {
+	return s.usage.Wrap(s.llm, operation).Call(ctx, prompt)
 }
 
 // buildDecisionPrompt creates the prompt for the LLM to analyze the tool use
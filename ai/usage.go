@@ -0,0 +1,165 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agoodway/workie/config"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// Usage describes one AI call
+type Usage struct {
+	Time             time.Time `json:"time"`
+	Operation        string    `json:"operation"`                   // What the call was for, e.g. "branch_name"
+	Model            string    `json:"model,omitempty"`             // Configured model name
+	PromptChars      int       `json:"prompt_chars"`                // Size of the prompt
+	ResponseChars    int       `json:"response_chars"`              // Size of the response
+	PromptTokens     int       `json:"prompt_tokens,omitempty"`     // Tokens in the prompt, as reported by the backend
+	CompletionTokens int       `json:"completion_tokens,omitempty"` // Tokens generated, as reported by the backend
+	LatencyMS        int64     `json:"latency_ms"`                  // Wall-clock time of the call
+	Error            string    `json:"error,omitempty"`             // Set if the call failed
+}
+
+// UsageReporter reports the usage of AI calls. A nil reporter reports nothing.
+type UsageReporter struct {
+	Model   string    // Model name recorded with each call
+	Verbose bool      // Print each call's usage to Out
+	LogPath string    // JSON lines file each call's usage is appended to ("" disables)
+	Out     io.Writer // Where verbose reports go (default: stderr, so JSON output on stdout stays intact)
+}
+
+// NewUsageReporter creates a reporter for the configured model, logging to
+// ai.usage_log (relative to the repository root) when it is set
+func NewUsageReporter(cfg *config.Config, repoPath string, verbose bool) *UsageReporter {
+	reporter := &UsageReporter{Verbose: verbose}
+	if cfg == nil {
+		return reporter
+	}
+
+	reporter.Model = cfg.AI.Model.Name
+	if logPath := cfg.AI.UsageLog; logPath != "" {
+		if !filepath.IsAbs(logPath) {
+			logPath = filepath.Join(repoPath, logPath)
+		}
+		reporter.LogPath = logPath
+	}
+	return reporter
+}
+
+// Wrap returns a model that reports the usage of every call made through it
+func (r *UsageReporter) Wrap(llm llms.Model, operation string) llms.Model {
+	if r == nil || llm == nil {
+		return llm
+	}
+	return &usageModel{llm: llm, operation: operation, reporter: r}
+}
+
+// Report prints and logs the usage of one call
+func (r *UsageReporter) Report(usage Usage) {
+	if r == nil {
+		return
+	}
+
+	if r.Verbose {
+		out := r.Out
+		if out == nil {
+			out = os.Stderr
+		}
+		tokens := ""
+		if usage.PromptTokens > 0 || usage.CompletionTokens > 0 {
+			tokens = fmt.Sprintf(", %d prompt + %d completion tokens", usage.PromptTokens, usage.CompletionTokens)
+		}
+		fmt.Fprintf(out, "🔢 AI usage (%s): %d prompt chars, %d response chars%s, %s\n",
+			usage.Operation, usage.PromptChars, usage.ResponseChars, tokens, time.Duration(usage.LatencyMS)*time.Millisecond)
+	}
+
+	if r.LogPath != "" {
+		if err := appendUsage(r.LogPath, usage); err != nil && r.Verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Failed to write AI usage log: %v\n", err)
+		}
+	}
+}
+
+// appendUsage appends usage as a JSON line to the log file
+func appendUsage(path string, usage Usage) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(usage)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// usageModel measures the calls made to the model it wraps
+type usageModel struct {
+	llm       llms.Model
+	operation string
+	reporter  *UsageReporter
+}
+
+// GenerateContent calls the wrapped model and reports the call's usage
+func (m *usageModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	usage := Usage{
+		Time:      time.Now(),
+		Operation: m.operation,
+		Model:     m.reporter.Model,
+	}
+	for _, message := range messages {
+		for _, part := range message.Parts {
+			if text, ok := part.(llms.TextContent); ok {
+				usage.PromptChars += len(text.Text)
+			}
+		}
+	}
+
+	resp, err := m.llm.GenerateContent(ctx, messages, options...)
+	usage.LatencyMS = time.Since(usage.Time).Milliseconds()
+
+	if err != nil {
+		usage.Error = err.Error()
+	} else if resp != nil && len(resp.Choices) > 0 {
+		choice := resp.Choices[0]
+		usage.ResponseChars = len(choice.Content)
+		// Ollama and OpenAI-compatible backends report token counts under these keys
+		usage.PromptTokens = intInfo(choice.GenerationInfo, "PromptTokens")
+		usage.CompletionTokens = intInfo(choice.GenerationInfo, "CompletionTokens")
+	}
+
+	m.reporter.Report(usage)
+	return resp, err
+}
+
+// Call sends a single prompt through GenerateContent so its usage is reported
+func (m *usageModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+// intInfo reads a numeric generation info value
+func intInfo(info map[string]any, key string) int {
+	switch v := info[key].(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
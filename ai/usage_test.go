@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// fakeModel answers every prompt with a fixed response and token counts
+type fakeModel struct {
+	response string
+}
+
+func (m *fakeModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{
+		Content:        m.response,
+		GenerationInfo: map[string]any{"PromptTokens": 12, "CompletionTokens": 3},
+	}}}, nil
+}
+
+func (m *fakeModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func TestUsageReporter(t *testing.T) {
+	var out bytes.Buffer
+	logPath := filepath.Join(t.TempDir(), "logs", "ai-usage.jsonl")
+	reporter := &UsageReporter{Model: "llama3.2", Verbose: true, LogPath: logPath, Out: &out}
+
+	llm := reporter.Wrap(&fakeModel{response: "fix/123-login"}, "branch_name")
+	response, err := llm.Call(context.Background(), "suggest a branch name")
+	if err != nil || response != "fix/123-login" {
+		t.Fatalf("Expected the wrapped model's response, got %q, %v", response, err)
+	}
+
+	if !strings.Contains(out.String(), "AI usage (branch_name): 21 prompt chars, 13 response chars, 12 prompt + 3 completion tokens") {
+		t.Errorf("Unexpected verbose report: %q", out.String())
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var usage Usage
+	if err := json.Unmarshal(data, &usage); err != nil {
+		t.Fatalf("Expected a JSON line, got %q: %v", data, err)
+	}
+	if usage.Operation != "branch_name" || usage.Model != "llama3.2" || usage.PromptTokens != 12 || usage.CompletionTokens != 3 {
+		t.Errorf("Unexpected logged usage: %+v", usage)
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/agoodway/workie/manager"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	adoptMove   bool // Move the worktree under the worktrees directory
+	adoptNoCopy bool // Leave the worktree's files alone
+	adoptDryRun bool // Show what adopt would do without doing it
+)
+
+// adoptCmd represents the adopt command
+var adoptCmd = &cobra.Command{
+	Use:   "adopt <path>",
+	Short: "Bring a worktree created with git worktree add under workie's management",
+	Long: `Adopt takes over a worktree that was created outside workie, e.g. with
+git worktree add, and sets it up as if 'workie begin' had created it:
+
+1. With --move, the worktree is moved to its place in the worktrees directory
+   with git worktree move.
+2. The files in files_to_copy are copied into it, replacing any copies it
+   already has (skip this with --no-copy), and the copy hooks run.
+3. Its metadata is recorded in .workie/meta.json and the worktrees index, so
+   'workie info' and 'workie list' know about it.
+4. The post_create hooks run in it.
+
+The worktree must have a branch checked out.`,
+	Example: `  # Adopt a worktree where it is
+  workie adopt ../my-experiment
+
+  # Move it under the worktrees directory as well
+  workie adopt ../my-experiment --move
+
+  # Record and run hooks, but keep the worktree's own .env and friends
+  workie adopt ../my-experiment --no-copy
+
+  # Show what would happen
+  workie adopt ../my-experiment --move --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := manager.Options{
+			ConfigFile: configFile,
+			Verbose:    verbose,
+			Quiet:      quiet,
+			DryRun:     adoptDryRun,
+			NoCopy:     adoptNoCopy,
+		}
+		wm := manager.NewWithOptions(opts)
+
+		if err := wm.DetectGitRepository(); err != nil {
+			return err
+		}
+		if err := wm.UseMainWorktree(); err != nil {
+			return err
+		}
+		if err := wm.LoadConfig(); err != nil {
+			return err
+		}
+
+		worktreePath, err := wm.AdoptWorktree(args[0], adoptMove)
+		if err != nil {
+			return err
+		}
+		if adoptDryRun {
+			return nil
+		}
+
+		// In quiet mode the worktree path is the only output, so it can be captured
+		if quiet {
+			fmt.Println(worktreePath)
+			return nil
+		}
+		fmt.Printf("✅ Adopted worktree:\n")
+		fmt.Printf("   Branch: %s\n", wm.HookTarget.Branch)
+		fmt.Printf("   Path: %s\n", worktreePath)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(adoptCmd)
+
+	adoptCmd.Flags().BoolVar(&adoptMove, "move", false, "Move the worktree into the worktrees directory with git worktree move")
+	adoptCmd.Flags().BoolVar(&adoptNoCopy, "no-copy", false, "Don't copy files_to_copy into the worktree")
+	adoptCmd.Flags().BoolVar(&adoptDryRun, "dry-run", false, "Show what adopt would do without changing anything")
+}
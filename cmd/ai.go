@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agoodway/workie/ai"
+	"github.com/agoodway/workie/config"
+	"github.com/agoodway/workie/errcode"
+)
+
+var (
+	aiModel   string // Overrides ai.model.name for this invocation
+	requireAI bool   // Treat AI failures as errors instead of falling back
+	noAI      bool   // Turn AI off for this invocation, whatever the config says
+)
+
+// checkNoAIFlags rejects --no-ai combined with flags that ask for AI. useAI is
+// the command's --ai flag, if it has one.
+func checkNoAIFlags(useAI bool) error {
+	if !noAI {
+		return nil
+	}
+	if useAI {
+		return errcode.Errorf(errcode.Usage, "cannot use both --ai and --no-ai\n\nTo fix this:\n  • Pass --ai to generate the name with AI\n  • Or pass --no-ai to skip AI for this run")
+	}
+	if requireAI {
+		return errcode.Errorf(errcode.Usage, "cannot use both --require-ai and --no-ai\n\nTo fix this:\n  • Drop --require-ai; with --no-ai no AI call is made")
+	}
+	if aiModel != "" {
+		return errcode.Errorf(errcode.Usage, "cannot use both --model and --no-ai\n\nTo fix this:\n  • Drop --model; with --no-ai no model is used")
+	}
+	return nil
+}
+
+// warnIfModelUnavailable warns when the --model override is not installed in
+// Ollama, so a typo is noticed before the AI call fails
+func warnIfModelUnavailable(cfg *config.Config) {
+	if aiModel == "" || cfg == nil {
+		return
+	}
+	if provider := strings.ToLower(cfg.AI.Model.Provider); provider != "" && provider != "ollama" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ai.CheckModel(ctx, cfg, aiModel); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/agoodway/workie/ai"
+	"github.com/agoodway/workie/config"
+	"github.com/agoodway/workie/manager"
+	"github.com/agoodway/workie/tools"
+
+	"github.com/spf13/cobra"
+	"github.com/tmc/langchaingo/llms"
+)
+
+var (
+	sandboxDir  string // Directory the agent's file tools are confined to
+	allowWrites bool   // Let the replace and filesystem tools write files
+	systemFlag  string // Instructions prepended to the agent's prompt
+)
+
+// repoPromptFile is a file of repository context loaded into the agent's prompt
+const repoPromptFile = ".workie/prompt.md"
+
+// askCmd represents the ask command
+var askCmd = &cobra.Command{
+	Use:   "ask [question]",
+	Short: "Ask the AI assistant a question about your repository",
+	Long: `Ask answers questions about your repository using the configured AI model
+and a set of read-only tools (file system, grep and git).
+A replace tool can preview regex find-and-replace changes as a diff; it only
+writes them, and the filesystem tool can only create or append to files, when
+--allow-writes is given.
+
+File access by the tools is confined to a sandbox directory. By default this is
+the repository root, regardless of which subdirectory you run workie from. Use
+--sandbox-dir (or tools.sandbox_dir in .workie.yaml) to restrict the agent to a
+subproject so it cannot read sibling directories.
+
+The git tool only runs the subcommands in tools.git.allowed_commands, by
+default the read-only status, branch, diff and log; any other is refused,
+including for the questions answered without AI.
+
+The assistant can be tailored per project. Instructions from --system (or
+ai.system_prompt in .workie.yaml) are prepended to the agent's prompt, followed
+by the contents of .workie/prompt.md when that file exists in the repository.
+
+Common questions (list files, current branch, current directory, commit
+message suggestions) are answered directly by the tools and work without AI;
+--no-ai answers only those, without contacting the model. Other questions require AI to be configured in .workie.yaml (see 'workie init').`,
+	Example: `  # Ask about the repository
+  workie ask "where is the worktree directory created?"
+
+  # Ask for a commit message for the current changes
+  workie ask "suggest a commit message"
+
+  # Confine the agent to a subproject
+  workie ask --sandbox-dir services/api "what does the config loader do?"
+
+  # Steer the assistant for one question
+  workie ask --system "Answer in one sentence." "what does workie finish do?"
+
+  # Try a different model
+  workie ask --model llama3.1 "how are worktrees named?"
+
+  # Let the agent apply a refactor
+  workie ask --allow-writes "rename the LoadConfig function to ReadConfig"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		question := strings.Join(args, " ")
+		if err := checkNoAIFlags(false); err != nil {
+			return err
+		}
+
+		// Create manager with options
+		opts := manager.Options{
+			ConfigFile: configFile,
+			Verbose:    verbose,
+			Quiet:      quiet,
+			Model:      aiModel,
+			NoAI:       noAI,
+		}
+		wm := manager.NewWithOptions(opts)
+
+		if err := wm.DetectGitRepository(); err != nil {
+			return err
+		}
+		if err := wm.LoadConfig(); err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		sandbox, err := tools.NewSandbox(resolveSandboxDir(wm))
+		if err != nil {
+			return fmt.Errorf("invalid sandbox directory: %w", err)
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Tool sandbox: %s\n", sandbox.Root)
+		}
+
+		// Built-in questions are answered without a model, so AI is optional
+		var llm llms.Model
+		if wm.Config.IsAIEnabled() {
+			warnIfModelUnavailable(wm.Config)
+			llm, err = newAskLLM(wm.Config)
+			if err != nil {
+				return err
+			}
+			llm = ai.NewUsageReporter(wm.Config, wm.RepoPath, verbose).Wrap(llm, "ask")
+		} else if verbose && noAI {
+			fmt.Fprintf(os.Stderr, "AI is turned off by --no-ai; only built-in questions can be answered\n")
+		} else if verbose {
+			fmt.Fprintf(os.Stderr, "AI is not configured; only built-in questions can be answered\n")
+		}
+
+		agent := tools.NewSimpleAgent(llm, newAskToolRegistry(sandbox.Root, allowWrites, gitToolCommands(wm.Config)), verbose)
+		agent.SetLimits(agentLimitsFromConfig(wm.Config))
+		systemPrompt, err := askSystemPrompt(wm)
+		if err != nil {
+			return err
+		}
+		agent.SetSystemPrompt(systemPrompt)
+		answer, err := agent.Execute(context.Background(), question)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(answer)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(askCmd)
+
+	// Add flags
+	askCmd.Flags().StringVar(&sandboxDir, "sandbox-dir", "", "Directory the agent's file tools are confined to (default: repository root)")
+	askCmd.Flags().BoolVar(&allowWrites, "allow-writes", false, "Allow the replace and filesystem tools to write files instead of only previewing changes")
+	askCmd.Flags().StringVar(&aiModel, "model", "", "AI model to use instead of ai.model.name")
+	askCmd.Flags().BoolVar(&noAI, "no-ai", false, "Answer only built-in questions, without the configured AI model")
+	askCmd.Flags().StringVar(&systemFlag, "system", "", "Instructions prepended to the agent's prompt (default from ai.system_prompt)")
+}
+
+// askSystemPrompt combines the --system flag (or ai.system_prompt) with the
+// repository context in .workie/prompt.md
+func askSystemPrompt(wm *manager.WorktreeManager) (string, error) {
+	var parts []string
+
+	instructions := systemFlag
+	if instructions == "" {
+		instructions = wm.Config.AI.SystemPrompt
+	}
+	if instructions = strings.TrimSpace(instructions); instructions != "" {
+		parts = append(parts, instructions)
+	}
+
+	content, err := os.ReadFile(filepath.Join(wm.RepoPath, repoPromptFile))
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read %s: %w", repoPromptFile, err)
+	}
+	if repoContext := strings.TrimSpace(string(content)); repoContext != "" {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Loaded repository context from %s\n", repoPromptFile)
+		}
+		parts = append(parts, "Repository context:\n"+repoContext)
+	}
+
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// resolveSandboxDir returns the sandbox directory from the --sandbox-dir flag,
+// the tools.sandbox_dir config setting (relative to the repo root), or the repo root
+func resolveSandboxDir(wm *manager.WorktreeManager) string {
+	if sandboxDir != "" {
+		return sandboxDir
+	}
+	if wm.Config.Tools != nil && wm.Config.Tools.SandboxDir != "" {
+		dir := wm.Config.Tools.SandboxDir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(wm.RepoPath, dir)
+		}
+		return dir
+	}
+	return wm.RepoPath
+}
+
+// newAskToolRegistry returns the default tools for the ask agent. The replace
+// tool can always preview changes, but it only writes them, and the filesystem
+// tool only writes files, when allowWrites is set. The git tool runs only
+// gitCommands, or its default read-only commands when there are none.
+func newAskToolRegistry(root string, allowWrites bool, gitCommands []string) *tools.ToolRegistry {
+	registry := tools.DefaultRegistry(root)
+	if tool, ok := registry.Get("git"); ok {
+		tool.(*tools.GitTool).AllowedCommands = gitCommands
+	}
+	if tool, ok := registry.Get("replace"); ok {
+		tool.(*tools.ReplaceTool).AllowApply = allowWrites
+	}
+	if tool, ok := registry.Get("filesystem"); ok {
+		tool.(*tools.FileSystemTool).AllowWrite = allowWrites
+	}
+	return registry
+}
+
+// gitToolCommands returns tools.git.allowed_commands, or nil when it is not set
+func gitToolCommands(cfg *config.Config) []string {
+	if cfg.Tools == nil || cfg.Tools.Git == nil {
+		return nil
+	}
+	return cfg.Tools.Git.AllowedCommands
+}
+
+// agentLimitsFromConfig overlays tools.agent settings on the default agent limits
+func agentLimitsFromConfig(cfg *config.Config) tools.AgentLimits {
+	limits := tools.DefaultAgentLimits()
+	if cfg.Tools == nil || cfg.Tools.Agent == nil {
+		return limits
+	}
+
+	agentCfg := cfg.Tools.Agent
+	if agentCfg.MaxIterations > 0 {
+		limits.MaxIterations = agentCfg.MaxIterations
+	}
+	if agentCfg.MaxCallsPerTool > 0 {
+		limits.MaxCallsPerTool = agentCfg.MaxCallsPerTool
+	}
+	if len(agentCfg.ToolCallLimits) > 0 {
+		limits.ToolCallLimits = agentCfg.ToolCallLimits
+	}
+	if agentCfg.TimeoutSeconds > 0 {
+		limits.Timeout = time.Duration(agentCfg.TimeoutSeconds) * time.Second
+	}
+	if agentCfg.MaxConsecutiveErrors > 0 {
+		limits.MaxConsecutiveErrors = agentCfg.MaxConsecutiveErrors
+	}
+	return limits
+}
+
+// newAskLLM creates the language model used to answer questions
+func newAskLLM(cfg *config.Config) (llms.Model, error) {
+	llm, err := ai.NewLLM(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AI client: %w", err)
+	}
+	return llm, nil
+}
@@ -2,23 +2,35 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
-	"github.com/agoodway/workie/config"
+	"github.com/agoodway/workie/ai"
 	"github.com/agoodway/workie/manager"
 	"github.com/agoodway/workie/provider"
+	"github.com/agoodway/workie/provider/bitbucket"
 	"github.com/agoodway/workie/provider/github"
 	"github.com/agoodway/workie/provider/jira"
 	"github.com/agoodway/workie/provider/linear"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"github.com/tmc/langchaingo/llms/ollama"
 )
 
 var (
 	issueRef string // Issue reference for creating branch from issue
 	useAI    bool   // Use AI to generate branch names
+	dryRun   bool   // Show what begin would do without doing it
+	checkout bool   // Check out an existing branch instead of creating one
+	openEdit bool   // Open the new worktree in an editor
+
+	autoSuffix bool   // Append -2, -3, ... to a branch name that is taken
+	beginFrom  string // Ref the new branch starts at instead of HEAD
+
+	moveChanges bool // Move uncommitted changes into the new worktree
+
+	beginProvider string // Provider of a bare --issue ID, overriding default_provider
+
+	beginNotes []string // Notes recorded in the worktree's metadata
 )
 
 // beginCmd represents the begin command
@@ -28,7 +40,7 @@ var beginCmd = &cobra.Command{
 	Long: `Begin creates a new Git worktree for isolated development on a branch.
 
 This command will:
-1. Create a new branch (or checkout an existing one)
+1. Create a new branch (or check out an existing one with --checkout)
 2. Set up a worktree directory alongside your main repository
 3. Copy essential files and configurations from .workie.yaml
 4. Execute any post_create hooks to set up your environment
@@ -55,9 +67,42 @@ When using --ai with --issue:
 
 Configuration is read from .workie.yaml (or workie.yaml) and can specify:
 - Files and directories to copy to new worktrees
+- Pre-copy hooks that generate files to copy (e.g. decrypted secrets)
+- Post-copy hooks that fix up the copied files (e.g. chmod, dos2unix)
 - Post-creation hooks for environment setup
 - Pre-removal hooks for cleanup tasks
-- Issue provider settings (GitHub, Jira, Linear)
+- Issue provider settings (GitHub, Jira, Linear, Bitbucket)
+
+By default begin refuses to reuse a branch that already exists. Use --checkout
+(or --existing) to check out an existing local branch into the new worktree;
+a branch that only exists on origin is created locally and tracks it. Or use
+--auto-suffix to create the first free one of <name>-2, <name>-3, ... instead,
+e.g. when two issues with similar titles generate the same name. Suffixed
+names are kept within 63 characters by shortening the name.
+
+A new branch starts at the commit checked out in the current directory. Use
+--from to start it at another branch, tag or commit instead, e.g.
+--from origin/main to branch off the latest main without updating your checkout.
+
+Use --open to open the new worktree in your editor once it is set up. The
+editor is the editor setting in .workie.yaml (e.g. editor: code), or $VISUAL
+or $EDITOR. If it can't be started begin warns but still succeeds.
+
+Use --move-changes when work started in the current checkout belongs on its own
+branch: the uncommitted changes, including untracked files, are stashed, the
+worktree is created and the stash is applied there, leaving the current checkout
+clean. If the changes don't apply cleanly the stash is kept (see git stash list)
+so nothing is lost.
+
+Use --dry-run to see the branch, worktree path, files to copy and hooks
+that would run without creating anything.
+
+Hooks can read WORKIE_BRANCH, WORKIE_WORKTREE_PATH, WORKIE_REPO_PATH,
+WORKIE_REPO_NAME and WORKIE_HOOK_TYPE from their environment.
+
+With worktree_metadata: true in the configuration, the issue, creation time,
+config file and any --note are recorded in .workie/meta.json in the worktree.
+Show them with: workie info <branch>
 
 Use this to start working on a new feature, bugfix, or experiment without
 affecting your main working directory.`,
@@ -76,9 +121,33 @@ affecting your main working directory.`,
   # Begin work from issue (uses default/only configured provider)
   workie begin --issue 123
 
+  # Begin work from a bare issue ID of a specific provider
+  workie begin --issue PROJ-456 --provider jira
+
   # Begin work with AI-generated branch name
   workie begin --issue github:123 --ai
 
+  # Try a different model for one invocation
+  workie begin --issue github:123 --ai --model llama3.1
+
+  # Check out an existing local or remote branch into a new worktree
+  workie begin feature/started-elsewhere --checkout
+
+  # Branch off the latest main instead of the current checkout
+  git fetch origin && workie begin feature/x --from origin/main
+
+  # Begin work from an issue whose branch name is already taken
+  workie begin --issue github:124 --auto-suffix
+
+  # Open the new worktree in your editor
+  workie begin feature/user-auth --open
+
+  # Move the changes you started on main into a new worktree
+  workie begin fix/typo --move-changes
+
+  # Preview what would be created without changing anything
+  workie begin feature/user-auth --dry-run
+
   # Begin a hotfix with custom configuration
   workie begin hotfix/security-patch --config .workie-production.yaml
 
@@ -89,6 +158,7 @@ affecting your main working directory.`,
   workie begin feature/complex-setup --verbose`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		startCdMode()
 		var branchName string
 
 		// Check if both branch name and issue flag are provided
@@ -96,11 +166,28 @@ affecting your main working directory.`,
 			return fmt.Errorf("cannot specify both branch name and --issue flag")
 		}
 
+		if err := checkNoAIFlags(useAI); err != nil {
+			return err
+		}
+
 		// Check if --ai is used without --issue
 		if useAI && issueRef == "" {
 			return fmt.Errorf("--ai flag requires --issue flag")
 		}
 
+		// An existing branch has to be named
+		if checkout && len(args) == 0 && issueRef == "" {
+			return fmt.Errorf("--checkout requires a branch name or --issue")
+		}
+
+		if autoSuffix && checkout {
+			return fmt.Errorf("--auto-suffix cannot be used with --checkout, which reuses the existing branch")
+		}
+
+		if beginFrom != "" && checkout {
+			return fmt.Errorf("--from cannot be used with --checkout, which checks out the existing branch as it is")
+		}
+
 		// Get branch name from args if provided
 		if len(args) > 0 {
 			branchName = args[0]
@@ -112,6 +199,15 @@ affecting your main working directory.`,
 			Verbose:          verbose,
 			Quiet:            quiet,
 			ShowInitMessages: true,
+			Model:            aiModel,
+			RequireAI:        requireAI,
+			NoAI:             noAI,
+			DryRun:           dryRun,
+			Checkout:         checkout,
+			Open:             openEdit,
+			MoveChanges:      moveChanges,
+			AutoSuffix:       autoSuffix,
+			From:             beginFrom,
 		}
 		wm := manager.NewWithOptions(opts)
 
@@ -128,18 +224,43 @@ affecting your main working directory.`,
 			}
 
 			// Get branch name from issue
-			name, err := getBranchNameFromIssue(wm, issueRef)
+			name, issue, err := getBranchNameFromIssue(wm, issueRef)
 			if err != nil {
 				return fmt.Errorf("failed to create branch from issue: %w", err)
 			}
 			branchName = name
+
+			if err := seedIssueTemplate(wm, issue); err != nil {
+				return err
+			}
+			recordIssue(wm, issue)
 		}
+		wm.Metadata.Notes = beginNotes
 
 		// Run the main workflow with the branch name
 		if err := wm.Run(branchName); err != nil {
 			return err
 		}
 
+		if dryRun || (!cdMode && !openEdit) {
+			return nil
+		}
+		// The branch may have been generated or suffixed, so look up the one created
+		path, err := wm.FindWorktree(wm.HookTarget.Branch)
+		if err != nil {
+			return err
+		}
+
+		// The worktree exists at this point, so a failed editor launch is only a warning
+		if openEdit {
+			if err := wm.OpenInEditor(path); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Warning: Could not open the worktree in an editor: %v\n", err)
+			}
+		}
+
+		// Hand the new worktree to the shell-init function
+		printCdPath(path)
+
 		return nil
 	},
 }
@@ -149,74 +270,59 @@ func init() {
 
 	// Add flags
 	beginCmd.Flags().StringVarP(&issueRef, "issue", "i", "", "Create branch from issue reference (e.g., github:123, jira:PROJ-456, or just 123 if only one provider is configured)")
+	beginCmd.Flags().StringVar(&beginProvider, "provider", "", "Provider of a bare --issue ID, overriding default_provider (github, jira, linear, bitbucket)")
+	beginCmd.Flags().BoolVar(&issueNoCache, "no-cache", false, "Fetch the issue from the provider instead of the cache (with --issue)")
 	beginCmd.Flags().BoolVar(&useAI, "ai", false, "Use AI to generate more descriptive branch names (requires --issue)")
+	beginCmd.Flags().StringVar(&aiModel, "model", "", "AI model to use instead of ai.model.name (with --ai)")
+	beginCmd.Flags().BoolVar(&checkout, "checkout", false, "Check out an existing local or remote branch instead of creating a new one")
+	beginCmd.Flags().BoolVar(&checkout, "existing", false, "Alias for --checkout")
+	if err := beginCmd.Flags().MarkHidden("existing"); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to hide existing flag: %v\n", err)
+	}
+	beginCmd.Flags().BoolVar(&autoSuffix, "auto-suffix", false, "If the branch already exists, create the first free one of <name>-2, <name>-3, ... instead of failing")
+	beginCmd.Flags().StringVar(&beginFrom, "from", "", "Branch, tag or commit to start the new branch at (default: the current HEAD)")
+	beginCmd.Flags().BoolVar(&moveChanges, "move-changes", false, "Move the uncommitted changes of the current checkout into the new worktree")
+	beginCmd.Flags().BoolVar(&openEdit, "open", false, "Open the new worktree in the editor setting, $VISUAL or $EDITOR")
+	beginCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the branch, path, files and hooks without creating the worktree")
+	beginCmd.Flags().StringArrayVar(&beginNotes, "note", nil, "Note to record in the worktree's metadata (repeatable, requires worktree_metadata)")
+	beginCmd.Flags().BoolVar(&cdMode, "cd", false, "Print only the new worktree's path on stdout and everything else on stderr (used by shell-init)")
+	beginCmd.Flags().BoolVar(&noAI, "no-ai", false, "Don't use AI for this run, even if it is configured")
+	beginCmd.Flags().BoolVar(&requireAI, "require-ai", false, "Fail if AI branch name generation fails instead of falling back (default from ai.require)")
 }
 
-// getBranchNameFromIssue fetches an issue and generates a branch name from it
-func getBranchNameFromIssue(wm *manager.WorktreeManager, issueRef string) (string, error) {
+// getBranchNameFromIssue fetches an issue and generates a branch name from it,
+// returning the issue alongside the name
+func getBranchNameFromIssue(wm *manager.WorktreeManager, issueRef string) (string, *provider.Issue, error) {
 	// Initialize provider registry
 	registry := provider.NewRegistry()
 
 	// Initialize providers based on configuration
 	if err := initializeBeginProviders(wm, registry); err != nil {
-		return "", fmt.Errorf("failed to initialize providers: %w", err)
-	}
-
-	// Check if any providers are configured
-	configuredProviders := registry.ListConfigured()
-	if len(configuredProviders) == 0 {
-		return "", fmt.Errorf("no issue providers are configured. Please configure providers in your .workie.yaml file")
+		return "", nil, fmt.Errorf("failed to initialize providers: %w", err)
 	}
 
-	// Parse issue reference
-	providerName, issueID, err := provider.ParseIssueReference(issueRef)
+	p, issueID, err := registry.ResolveIssueReference(issueRef, beginProvider, wm.Config.GetDefaultProvider())
 	if err != nil {
-		// If parsing fails, check if it's just an issue ID (no provider specified)
-		if !strings.Contains(issueRef, ":") {
-			// Try to use default provider
-			if wm.Config.DefaultProvider != "" {
-				providerName = wm.Config.DefaultProvider
-				issueID = issueRef
-			} else if len(configuredProviders) == 1 {
-				// If only one provider is configured, use it as default
-				providerName = configuredProviders[0]
-				issueID = issueRef
-				if verbose {
-					fmt.Printf("Using %s as default provider (only configured provider)\n", providerName)
-				}
-			} else if len(configuredProviders) > 1 {
-				// Multiple providers configured but no default specified
-				return "", fmt.Errorf("multiple providers configured but no default specified. Use format 'provider:id' or set 'default_provider' in config")
-			} else {
-				return "", err
-			}
-		} else {
-			return "", err
-		}
-	}
-
-	// Get provider
-	p, err := registry.Get(providerName)
-	if err != nil {
-		return "", fmt.Errorf("provider '%s' not found or not configured", providerName)
+		return "", nil, err
 	}
+	providerName := p.Name()
 
 	// Fetch issue
-	fmt.Printf("🔍 Fetching issue %s:%s...\n", providerName, issueID)
-	issue, err := p.GetIssue(issueID)
+	infof("🔍 Fetching issue %s:%s...\n", providerName, issueID)
+	issue, err := newIssueCache(wm).GetIssue(p, issueID)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch issue: %w", err)
+		return "", nil, fmt.Errorf("failed to fetch issue: %w", err)
 	}
 
 	// Display issue details
-	fmt.Printf("\n📋 Creating branch from issue:\n")
-	fmt.Printf("   Provider: %s\n", issue.Provider)
-	fmt.Printf("   ID: %s\n", issue.ID)
-	fmt.Printf("   Title: %s\n", issue.Title)
-	fmt.Printf("   Type: %s\n", issue.Type)
-	fmt.Printf("   Status: %s\n", issue.Status)
+	infof("\n📋 Creating branch from issue:\n")
+	infof("   Provider: %s\n", issue.Provider)
+	infof("   ID: %s\n", issue.ID)
+	infof("   Title: %s\n", issue.Title)
+	infof("   Type: %s\n", issue.Type)
+	infof("   Status: %s\n", issue.Status)
 	if len(issue.Labels) > 0 {
-		fmt.Printf("   Labels: %s\n", strings.Join(issue.Labels, ", "))
+		infof("   Labels: %s\n", strings.Join(issue.Labels, ", "))
 	}
 
 	// Generate branch name
@@ -225,24 +331,56 @@ func getBranchNameFromIssue(wm *manager.WorktreeManager, issueRef string) (strin
 	if useAI {
 		// Use AI to generate branch name
 		aiName, err := generateAIBranchName(wm, p, issue)
+		if err != nil && wm.Config.RequiresAI() {
+			return "", nil, fmt.Errorf("AI branch name generation failed: %w\n\nTo fix this:\n  • Check that Ollama is running and the model is installed\n  • Verify the ai section of your .workie.yaml\n  • Or drop --require-ai (ai.require) to fall back to standard names", err)
+		}
 		if err != nil {
 			// Fall back to standard generation if AI fails
 			if verbose {
-				fmt.Printf("⚠️  AI branch name generation failed: %v\n", err)
-				fmt.Printf("   Falling back to standard generation...\n")
+				fmt.Fprintf(os.Stderr, "⚠️  AI branch name generation failed: %v\n", err)
+				fmt.Fprintf(os.Stderr, "   Falling back to standard generation...\n")
 			}
 			branchName = p.CreateBranchName(issue)
 		} else {
 			branchName = aiName
-			fmt.Printf("\n🤖 AI-generated branch name: %s\n", branchName)
+			infof("\n🤖 AI-generated branch name: %s\n", branchName)
 		}
 	} else {
 		// Use standard branch name generation
 		branchName = p.CreateBranchName(issue)
-		fmt.Printf("\n🌿 Generated branch name: %s\n", branchName)
+		infof("\n🌿 Generated branch name: %s\n", branchName)
+	}
+
+	return branchName, issue, nil
+}
+
+// seedIssueTemplate renders the configured issue_worktree_template with the issue
+// and queues it to be written into the new worktree
+func seedIssueTemplate(wm *manager.WorktreeManager, issue *provider.Issue) error {
+	if wm.Config == nil || wm.Config.IssueTemplate == "" {
+		return nil
+	}
+
+	content, err := provider.RenderIssueTemplate(wm.Config.IssueTemplate, issue)
+	if err != nil {
+		return fmt.Errorf("%w\n\nTo fix this:\n  • Check the issue_worktree_template syntax in your config\n  • Available fields: .ID, .Title, .Description, .Type, .Status, .Labels, .URL, .Provider, .Metadata", err)
+	}
+
+	if wm.SeedFiles == nil {
+		wm.SeedFiles = make(map[string][]byte)
 	}
+	wm.SeedFiles[wm.Config.GetIssueTemplatePath()] = content
+	return nil
+}
 
-	return branchName, nil
+// recordIssue links the issue in the new worktree's metadata
+func recordIssue(wm *manager.WorktreeManager, issue *provider.Issue) {
+	wm.Metadata.Issue = &manager.IssueLink{
+		Provider: issue.Provider,
+		ID:       issue.ID,
+		Title:    issue.Title,
+		URL:      issue.URL,
+	}
 }
 
 // initializeBeginProviders initializes issue providers based on configuration
@@ -276,9 +414,11 @@ func initializeBeginProviders(wm *manager.WorktreeManager, registry *provider.Re
 			p, err = jira.NewProvider(configMap)
 		case "linear":
 			p, err = linear.NewProvider(configMap)
+		case "bitbucket":
+			p, err = bitbucket.NewProvider(configMap)
 		default:
 			if verbose {
-				fmt.Printf("Unknown provider type: %s\n", name)
+				fmt.Fprintf(os.Stderr, "Unknown provider type: %s\n", name)
 			}
 			continue
 		}
@@ -293,7 +433,7 @@ func initializeBeginProviders(wm *manager.WorktreeManager, registry *provider.Re
 				return fmt.Errorf("failed to register %s provider: %w", name, err)
 			}
 		} else if verbose {
-			fmt.Printf("Provider %s is not fully configured\n", name)
+			fmt.Fprintf(os.Stderr, "Provider %s is not fully configured\n", name)
 		}
 	}
 
@@ -302,86 +442,78 @@ func initializeBeginProviders(wm *manager.WorktreeManager, registry *provider.Re
 
 // generateAIBranchName generates a branch name using AI
 func generateAIBranchName(wm *manager.WorktreeManager, p provider.Provider, issue *provider.Issue) (string, error) {
-	// Load AI configuration
-	configFile := wm.Options.ConfigFile
-	if configFile == "" {
-		configFile = ".workie.yaml"
-	}
-	viper.SetConfigFile(configFile)
-	if err := viper.ReadInConfig(); err != nil {
-		return "", fmt.Errorf("failed to read config: %w", err)
+	generator, err := newAIBranchNameGenerator(wm)
+	if err != nil {
+		return "", err
 	}
 
-	var cfg config.Config
-	if err := viper.Unmarshal(&cfg); err != nil {
-		return "", fmt.Errorf("failed to unmarshal config: %w", err)
-	}
+	generator.Options = configuredBranchNameOptions(wm, p.Name())
+	prefix := aiBranchPrefix(configuredBranchPrefixes(wm, p.Name()), issue)
+	return generator.GenerateBranchName(issue, prefix)
+}
 
-	if !cfg.AI.Enabled {
-		return "", fmt.Errorf("AI features are not enabled in configuration")
+// newAIBranchNameGenerator creates a branch name generator for the model in
+// the loaded configuration, including .workie.local.yaml overrides, --model and
+// ai.require
+func newAIBranchNameGenerator(wm *manager.WorktreeManager) (*provider.AIBranchNameGenerator, error) {
+	if !wm.Config.AI.Enabled || !wm.Config.IsAIEnabled() {
+		return nil, fmt.Errorf("AI features are not enabled in configuration")
 	}
+	warnIfModelUnavailable(wm.Config)
 
-	// Create Ollama client
-	ollamaOpts := []ollama.Option{
-		ollama.WithModel(cfg.AI.Model.Name),
+	llm, err := ai.NewLLM(wm.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AI client: %w", err)
 	}
 
-	if cfg.AI.Ollama.BaseURL != "" {
-		ollamaOpts = append(ollamaOpts, ollama.WithServerURL(cfg.AI.Ollama.BaseURL))
-	}
+	// Create AI branch name generator
+	usage := ai.NewUsageReporter(wm.Config, wm.RepoPath, wm.Options.Verbose)
+	generator := provider.NewAIBranchNameGenerator(usage.Wrap(llm, "branch_name"))
+	generator.Strict = wm.Config.RequiresAI()
+	return generator, nil
+}
 
-	llm, err := ollama.New(ollamaOpts...)
-	if err != nil {
-		return "", fmt.Errorf("failed to create AI client: %w", err)
+// configuredBranchPrefixes returns the branch_prefix map configured for a provider
+func configuredBranchPrefixes(wm *manager.WorktreeManager, providerName string) map[string]string {
+	configured := map[string]string{}
+	if wm.Config == nil {
+		return configured
 	}
-
-	// Get the branch prefix from provider
-	prefix := ""
-	switch strings.ToLower(issue.Type) {
-	case "bug":
-		if wm.Config.Providers != nil {
-			if provConfig, ok := wm.Config.Providers[p.Name()].(map[string]interface{}); ok {
-				if branchPrefix, ok := provConfig["branch_prefix"].(map[string]interface{}); ok {
-					if bugPrefix, ok := branchPrefix["bug"].(string); ok {
-						prefix = bugPrefix
-					}
-				}
-			}
-		}
-		if prefix == "" {
-			prefix = "fix/"
-		}
-	case "feature", "enhancement", "story":
-		if wm.Config.Providers != nil {
-			if provConfig, ok := wm.Config.Providers[p.Name()].(map[string]interface{}); ok {
-				if branchPrefix, ok := provConfig["branch_prefix"].(map[string]interface{}); ok {
-					if featPrefix, ok := branchPrefix["feature"].(string); ok {
-						prefix = featPrefix
-					}
-				}
-			}
-		}
-		if prefix == "" {
-			prefix = "feat/"
-		}
-	default:
-		if wm.Config.Providers != nil {
-			if provConfig, ok := wm.Config.Providers[p.Name()].(map[string]interface{}); ok {
-				if branchPrefix, ok := provConfig["branch_prefix"].(map[string]interface{}); ok {
-					if defaultPrefix, ok := branchPrefix["default"].(string); ok {
-						prefix = defaultPrefix
-					}
+	if provConfig, ok := wm.Config.Providers[providerName].(map[string]interface{}); ok {
+		if branchPrefix, ok := provConfig["branch_prefix"].(map[string]interface{}); ok {
+			for key, value := range branchPrefix {
+				if prefix, ok := value.(string); ok {
+					configured[key] = prefix
 				}
 			}
 		}
+	}
+	return configured
+}
+
+// configuredBranchNameOptions returns the branch_name settings configured for a
+// provider, so AI names are formatted like the provider's own
+func configuredBranchNameOptions(wm *manager.WorktreeManager, providerName string) provider.BranchNameOptions {
+	if wm.Config == nil {
+		return provider.BranchNameOptions{}
+	}
+	provConfig, _ := wm.Config.Providers[providerName].(map[string]interface{})
+	return provider.BranchNameOptionsFromConfig(provConfig)
+}
+
+// aiBranchPrefix returns the branch prefix for the inferred issue type,
+// preferring configured prefixes
+func aiBranchPrefix(configured map[string]string, issue *provider.Issue) string {
+	issueType := provider.InferIssueType(issue)
+	prefix, ok := provider.LookupBranchPrefix(configured, issueType)
+	if !ok {
+		prefix, ok = provider.LookupBranchPrefix(map[string]string{"bug": "fix/", "feature": "feat/"}, issueType)
+	}
+	if !ok {
+		prefix = configured["default"]
 		if prefix == "" {
 			prefix = "issue/"
 		}
 	}
-
-	// Create AI branch name generator
-	generator := provider.NewAIBranchNameGenerator(llm)
-
-	// Generate the branch name
-	return generator.GenerateBranchName(issue, prefix)
+	return prefix
 }
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agoodway/workie/manager"
+	"github.com/agoodway/workie/provider"
+	"github.com/agoodway/workie/provider/github"
+)
+
+func TestAIBranchNameUsesLocalConfig(t *testing.T) {
+	var models []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Model string `json:"model"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		models = append(models, request.Model)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"model":   request.Model,
+			"message": map[string]string{"role": "assistant", "content": "dark-mode"},
+			"done":    true,
+		})
+	}))
+	defer server.Close()
+
+	repo := t.TempDir()
+	shared := "ai:\n  enabled: true\n  model:\n    provider: ollama\n    name: shared-model\n  ollama:\n    base_url: " + server.URL + "\n"
+	if err := os.WriteFile(filepath.Join(repo, ".workie.yaml"), []byte(shared), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".workie.local.yaml"), []byte("ai:\n  model:\n    name: local-model\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wm := manager.New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	if err := wm.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	generator, err := newAIBranchNameGenerator(wm)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	name, err := generator.GenerateBranchName(&provider.Issue{ID: "12", Title: "Dark mode"}, "feat/")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if name != "feat/12-dark-mode" {
+		t.Errorf("Expected the model's name, got %s", name)
+	}
+	if len(models) != 1 || models[0] != "local-model" {
+		t.Errorf("Expected the model from .workie.local.yaml, got %v", models)
+	}
+}
+
+func TestBranchNameMatchesBegin(t *testing.T) {
+	repo := t.TempDir()
+	cfg := `default_provider: github
+providers:
+  github:
+    enabled: true
+    settings:
+      owner: agoodway
+      repo: workie
+    branch_prefix:
+      bug: bugfix/
+      feature: feat/
+      default: issue/
+    branch_name:
+      separator: "_"
+      preserve_id_case: true
+`
+	if err := os.WriteFile(filepath.Join(repo, ".workie.yaml"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wm := manager.New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	if err := wm.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	providerConfig, _ := wm.Config.Providers["github"].(map[string]interface{})
+	p, err := github.NewProvider(providerConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue := &provider.Issue{ID: "PROJ-456", Title: "Login fails on Safari", Type: "bug"}
+
+	// begin --issue names the branch with the provider, branch-name with the
+	// default provider's settings
+	prefixes, opts := defaultProviderBranchNaming(wm)
+	begin := p.CreateBranchName(issue)
+	branchName := provider.CreateBranchNameWithOptions(prefixes, issue, opts)
+	if begin != "bugfix/PROJ-456_login_fails_on_safari" || branchName != begin {
+		t.Errorf("Expected both commands to name the branch bugfix/PROJ-456_login_fails_on_safari, got %q and %q", begin, branchName)
+	}
+}
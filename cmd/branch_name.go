@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/agoodway/workie/manager"
+	"github.com/agoodway/workie/provider"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	branchNameTitle    string   // Issue title
+	branchNameType     string   // Issue type (bug, feature, task, ...)
+	branchNameID       string   // Optional issue ID included in the name
+	branchNameBody     string   // Issue description, or "-" to read it from stdin
+	branchNameFromFile string   // File to read the issue description from
+	branchNameLabels   []string // Issue labels, used to infer the type
+	branchNameAI       bool     // Use AI to generate the name
+)
+
+// branchNameCmd represents the branch-name command
+var branchNameCmd = &cobra.Command{
+	Use:   "branch-name",
+	Short: "Generate a branch name for an issue entered by hand",
+	Long: `Branch-name prints the branch name workie would create for an issue, without
+fetching it from a provider. Use it for issues in trackers workie doesn't
+integrate with.
+
+The name is built the same way as 'workie begin --issue': a prefix for the
+issue type (fix/, feat/, task/, issue/), the issue ID when one is given, and
+the title. With --ai the title and description are sent to the configured AI
+model for a more descriptive name, falling back to the standard name unless
+ai.require or --require-ai is set. --no-ai skips AI even when it is configured.
+
+Branch prefixes and formatting come from the default_provider's branch_prefix
+and branch_name settings when a configuration is found, otherwise the built-in
+defaults are used.
+
+Only the branch name is printed to stdout, so it can be captured.`,
+	Example: `  # Name a bug fix
+  workie branch-name --title "Login fails on Safari" --type bug
+
+  # Include a ticket ID from another tracker
+  workie branch-name --title "Dark mode" --type feature --id OPS-42
+
+  # Pipe the issue description in for AI naming
+  pbpaste | workie branch-name --title "Checkout is slow" --body - --ai
+
+  # Read the description from a file
+  workie branch-name --title "Upgrade Postgres" --from-file notes.md --ai
+
+  # Create a worktree with the generated name
+  workie begin "$(workie branch-name --title "Fix flaky upload" --type bug)"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if strings.TrimSpace(branchNameTitle) == "" {
+			return fmt.Errorf("--title is required\n\nTo fix this:\n  • Pass the issue title: workie branch-name --title \"Login fails on Safari\"")
+		}
+		if err := checkNoAIFlags(branchNameAI); err != nil {
+			return err
+		}
+		if branchNameBody != "" && branchNameFromFile != "" {
+			return fmt.Errorf("cannot use both --body and --from-file")
+		}
+
+		description, err := readBranchNameBody(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+
+		issue := &provider.Issue{
+			ID:          branchNameID,
+			Title:       branchNameTitle,
+			Description: description,
+			Type:        branchNameType,
+			Labels:      branchNameLabels,
+		}
+
+		// Configuration is optional: it supplies branch prefixes and AI settings
+		opts := manager.Options{
+			ConfigFile: configFile,
+			Verbose:    verbose,
+			Quiet:      quiet,
+			Model:      aiModel,
+			RequireAI:  requireAI,
+			NoAI:       noAI,
+		}
+		wm := manager.NewWithOptions(opts)
+		configErr := wm.DetectGitRepository()
+		if configErr == nil {
+			configErr = wm.LoadConfig()
+		}
+		if configErr != nil && branchNameAI {
+			return fmt.Errorf("--ai needs the AI settings from your configuration: %w", configErr)
+		}
+
+		prefixes, nameOpts := provider.DefaultBranchPrefixes, provider.BranchNameOptions{}
+		if configErr == nil {
+			prefixes, nameOpts = defaultProviderBranchNaming(wm)
+		}
+
+		name := provider.CreateBranchNameWithOptions(prefixes, issue, nameOpts)
+		if branchNameAI {
+			aiName, err := generateManualAIBranchName(wm, prefixes, nameOpts, issue)
+			if err != nil && wm.Config.RequiresAI() {
+				return fmt.Errorf("AI branch name generation failed: %w\n\nTo fix this:\n  • Check that Ollama is running and the model is installed\n  • Verify the ai section of your .workie.yaml\n  • Or drop --require-ai (ai.require) to fall back to the standard name", err)
+			}
+			if err != nil {
+				if verbose {
+					fmt.Fprintf(os.Stderr, "⚠️  AI branch name generation failed: %v\n", err)
+					fmt.Fprintf(os.Stderr, "   Falling back to standard generation...\n")
+				}
+			} else {
+				name = aiName
+			}
+		}
+
+		fmt.Println(name)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(branchNameCmd)
+
+	// Add flags
+	branchNameCmd.Flags().StringVar(&branchNameTitle, "title", "", "Issue title (required)")
+	branchNameCmd.Flags().StringVar(&branchNameType, "type", "", "Issue type, e.g. bug, feature or task (inferred from the title when omitted)")
+	branchNameCmd.Flags().StringVar(&branchNameID, "id", "", "Issue ID to include in the name")
+	branchNameCmd.Flags().StringVar(&branchNameBody, "body", "", "Issue description, or - to read it from stdin")
+	branchNameCmd.Flags().StringVar(&branchNameFromFile, "from-file", "", "Read the issue description from a file")
+	branchNameCmd.Flags().StringSliceVar(&branchNameLabels, "labels", nil, "Issue labels (comma-separated), used to infer the type")
+	branchNameCmd.Flags().BoolVar(&branchNameAI, "ai", false, "Use AI to generate a more descriptive name")
+	branchNameCmd.Flags().StringVar(&aiModel, "model", "", "AI model to use instead of ai.model.name (with --ai)")
+	branchNameCmd.Flags().BoolVar(&noAI, "no-ai", false, "Don't use AI, even if it is configured")
+	branchNameCmd.Flags().BoolVar(&requireAI, "require-ai", false, "Fail if AI generation fails instead of falling back (default from ai.require)")
+}
+
+// readBranchNameBody returns the issue description from --body, stdin or --from-file
+func readBranchNameBody(stdin io.Reader) (string, error) {
+	switch {
+	case branchNameBody == "-":
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read issue description from stdin: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	case branchNameFromFile != "":
+		data, err := os.ReadFile(branchNameFromFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read issue description: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	default:
+		return branchNameBody, nil
+	}
+}
+
+// defaultProviderBranchNaming returns the branch prefixes and branch_name
+// settings of the default provider, so names match those 'workie begin --issue'
+// creates. Without a default provider, or prefixes configured for it, the
+// built-in prefixes are used.
+func defaultProviderBranchNaming(wm *manager.WorktreeManager) (map[string]string, provider.BranchNameOptions) {
+	name := wm.Config.GetDefaultProvider()
+	if name == "" {
+		return provider.DefaultBranchPrefixes, provider.BranchNameOptions{}
+	}
+	prefixes := provider.DefaultBranchPrefixes
+	if configured := configuredBranchPrefixes(wm, name); len(configured) > 0 {
+		prefixes = configured
+	}
+	return prefixes, configuredBranchNameOptions(wm, name)
+}
+
+// generateManualAIBranchName generates an AI branch name for an issue entered by hand
+func generateManualAIBranchName(wm *manager.WorktreeManager, prefixes map[string]string, opts provider.BranchNameOptions, issue *provider.Issue) (string, error) {
+	generator, err := newAIBranchNameGenerator(wm)
+	if err != nil {
+		return "", err
+	}
+	generator.Options = opts
+	return generator.GenerateBranchName(issue, aiBranchPrefix(prefixes, issue))
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/agoodway/workie/config"
+	"github.com/agoodway/workie/errcode"
+	"github.com/agoodway/workie/manager"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateConfigPath string // Config file to validate instead of the repository's
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and check workie configuration",
+	Long: `Config groups commands that work with the workie configuration file
+(.workie.yaml or workie.yaml) without creating a worktree.`,
+}
+
+// configValidateCmd represents the config validate command
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration file for problems",
+	Long: `Validate loads the configuration the same way other commands do and runs
+every validation rule, reporting each problem with its line and column in the file.
+Values of the wrong type (e.g. a string where a list is expected) are errors;
+unknown settings are ignored by workie, so they are reported as warnings with a
+suggestion when a similar setting exists.
+
+Hook commands are rejected when they are empty, start with whitespace, have
+unbalanced quotes, are duplicated within an event, run through sudo, start
+network servers or look destructive (e.g. rm -rf /). An event may run at most
+20 commands. files_to_copy destinations must stay inside the worktree.
+Counts and timeouts may not be negative, temperature and top_p must be in
+range, and copy_backend, copy_mode and ai.model.provider must be supported.
+
+The command exits with a non-zero status when the configuration is invalid,
+so it can be used in CI or a pre-commit hook.`,
+	Example: `  # Validate the repository's configuration
+  workie config validate
+
+  # Validate another file
+  workie config validate --config examples/watch-demo.yaml
+
+  # Only print problems (for scripts)
+  workie config validate --quiet`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repoPath := ""
+		if validateConfigPath != "" {
+			if err := validateConfigFile(validateConfigPath); err != nil {
+				return err
+			}
+		} else {
+			wm := manager.NewWithOptions(manager.Options{Quiet: quiet})
+			if err := wm.DetectGitRepository(); err != nil {
+				return err
+			}
+			repoPath = wm.RepoPath
+		}
+
+		cfg, err := config.LoadConfig(repoPath, validateConfigPath)
+		if err != nil {
+			var validationErr *config.ValidationError
+			if errors.As(err, &validationErr) {
+				return errcode.Errorf(errcode.ConfigInvalid, "%w\n\nTo fix this:\n  • Edit the listed settings in your configuration file\n  • Run 'workie config validate' again to confirm", err)
+			}
+			return errcode.Wrap(errcode.ConfigInvalid, err)
+		}
+
+		if cfg.LoadedFrom == "" {
+			return errcode.Errorf(errcode.ConfigNotFound, "no configuration file found in %s\n\nTo fix this:\n  • Run 'workie init' to create .workie.yaml\n  • Or pass a file with --config", repoPath)
+		}
+
+		for _, warning := range cfg.Warnings {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", warning)
+		}
+		if !quiet {
+			fmt.Printf("✅ Configuration is valid: %s\n", strings.Join(cfg.LoadedFiles, " + "))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+
+	// Add flags
+	configValidateCmd.Flags().StringVarP(&validateConfigPath, "config", "c", "", "Path to the configuration file to validate (default: .workie.yaml or workie.yaml)")
+}
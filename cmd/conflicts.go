@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/agoodway/workie/errcode"
+	"github.com/agoodway/workie/manager"
+	"github.com/agoodway/workie/table"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	conflictsJSON        bool     // Output the conflicts as JSON
+	conflictsIncludeMain bool     // Also check the main branch against origin
+	conflictsBranches    []string // Glob patterns of branches to check
+)
+
+// conflictsCmd represents the conflicts command
+var conflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "Check once whether worktree branches would conflict rebasing on main",
+	Long: `Conflicts runs the same check as 'workie watch' a single time: it fetches
+origin and reports every worktree branch that would conflict rebasing on the
+main branch, with the conflicting files.
+
+It exits with status 1 when any branch would conflict, or could not be checked,
+so it can be used as a pre-push hook or CI step. Branches matching
+watch.branches_to_ignore are not reported, and --branches and --include-main
+default to watch.branches and watch.include_main.
+
+With --json the conflicts are printed as a list of objects with branch,
+worktree_path, conflict_files, last_checked and error.`,
+	Example: `  # Check every worktree branch
+  workie conflicts
+
+  # Only feature branches, from a script
+  workie conflicts --branches 'feature/*' --json
+
+  # Block a push that would conflict (.git/hooks/pre-push)
+  workie conflicts --quiet`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := manager.Options{
+			ConfigFile: configFile,
+			Verbose:    verbose,
+			Quiet:      quiet || conflictsJSON,
+		}
+		wm := manager.NewWithOptions(opts)
+
+		if err := wm.DetectGitRepository(); err != nil {
+			return err
+		}
+		if err := wm.LoadConfig(); err != nil {
+			return err
+		}
+
+		if cfg := wm.Config.Watch; cfg != nil {
+			if !cmd.Flags().Changed("include-main") {
+				conflictsIncludeMain = cfg.IncludeMain
+			}
+			if !cmd.Flags().Changed("branches") {
+				conflictsBranches = cfg.Branches
+			}
+		}
+
+		checked, err := wm.CheckRebaseConflictsWithOptions(manager.ConflictCheckOptions{
+			IncludeMain: conflictsIncludeMain,
+			Branches:    conflictsBranches,
+		})
+		if err != nil {
+			return err
+		}
+
+		conflicts := []manager.ConflictInfo{}
+		failed := 0
+		for _, conflict := range checked {
+			if wm.IgnoresBranch(conflict.Branch) {
+				continue
+			}
+			if conflict.Error != "" {
+				failed++
+			}
+			conflicts = append(conflicts, conflict)
+		}
+
+		if conflictsJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(conflicts); err != nil {
+				return err
+			}
+		} else if len(conflicts) == 0 {
+			infof("✅ No conflicts detected\n")
+		} else {
+			printConflicts(conflicts)
+		}
+
+		if conflicting := len(conflicts) - failed; conflicting > 0 {
+			return errcode.Errorf(errcode.RebaseConflicts, "%d branch(es) would conflict rebasing on the main branch\n\nTo fix this:\n  • Rebase each branch and resolve the conflicts: git rebase origin/<main branch>\n  • Or ignore branches with watch.branches_to_ignore", conflicting)
+		}
+		if failed > 0 {
+			return fmt.Errorf("could not check %d branch(es) for conflicts; see the errors above", failed)
+		}
+		return nil
+	},
+}
+
+// printConflicts prints a table of the branches that would conflict and their files
+func printConflicts(conflicts []manager.ConflictInfo) {
+	tbl := table.New("BRANCH", "FILES", "CONFLICTING FILES")
+	tbl.SetMaxWidth(2, 80)
+	for _, conflict := range conflicts {
+		if conflict.Error != "" {
+			tbl.AddRow(conflict.Branch, "-", conflict.Error)
+			continue
+		}
+		tbl.AddRow(conflict.Branch, fmt.Sprint(len(conflict.ConflictFiles)), strings.Join(conflict.ConflictFiles, ", "))
+	}
+	if err := tbl.Render(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(conflictsCmd)
+
+	conflictsCmd.Flags().BoolVar(&conflictsJSON, "json", false, "Output the conflicts as JSON")
+	conflictsCmd.Flags().BoolVar(&conflictsIncludeMain, "include-main", false, "Also check whether the main branch would conflict rebasing onto origin (default from watch.include_main)")
+	conflictsCmd.Flags().StringSliceVar(&conflictsBranches, "branches", nil, "Comma-separated glob patterns; only matching branches are checked (default from watch.branches)")
+}
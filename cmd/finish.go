@@ -1,43 +1,70 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 
+	"github.com/agoodway/workie/config"
+	"github.com/agoodway/workie/errcode"
 	"github.com/agoodway/workie/manager"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	forceFinish bool
-	pruneBranch bool
+	forceFinish  bool
+	pruneBranch  bool
+	finishPR     bool // Push and open a pull request before removing the worktree
+	finishMerge  bool // Merge into the main branch locally before removing the worktree
+	finishYes    bool // Skip the merge confirmation prompt
+	finishDryRun bool // Show what finish would do without doing it
+	skipChecks   bool // Skip pre_finish hooks
 )
 
 // finishCmd represents the finish command
 var finishCmd = &cobra.Command{
 	Use:   "finish [branch-name]",
 	Short: "Finish working on a branch by removing its worktree",
-	Long: `Finish removes a worktree when you're done working on a branch.
+	Long: `Finish wraps up work on a branch and removes its worktree.
 
 This command will:
-1. Execute any pre_remove hooks configured in .workie.yaml
-2. Remove the worktree directory and its contents
-3. Optionally delete the branch (if --prune-branch is used)
-4. Clean up any Git references
+1. Run any pre_finish hooks (e.g. tests) when using --pr or --merge; a failure aborts
+2. Optionally push and open a pull request (--pr) or merge into the main
+   branch locally after confirmation (--merge)
+3. Execute any pre_remove hooks configured in .workie.yaml
+4. Remove the worktree directory and its contents
+5. Execute any post_remove hooks, from the main repository
+6. Optionally delete the branch (--prune-branch, implied by --merge)
+
+When no branch is given, the worktree you are currently in is finished.
+Use --dry-run to see the steps without running them.
 
 Pre-remove hooks allow you to run cleanup tasks before the worktree
 is removed, such as stopping services, backing up data, or stashing
 changes. These hooks run in the worktree directory that will be removed.
+A .workie.local.yaml in the worktree can add hooks of its own: its hooks
+section is merged over the configured one for every hook finish runs.
+Like every hook, they can read WORKIE_BRANCH, WORKIE_WORKTREE_PATH,
+WORKIE_REPO_PATH, WORKIE_REPO_NAME and WORKIE_HOOK_TYPE from their
+environment.
 
 Use this when you've finished working on a feature branch and want to
 clean up your development environment.`,
 	Example: `  # Finish working on a specific branch (keeps the branch)
   workie finish feature/user-auth
 
+  # Run checks, push, open a pull request, then remove the worktree
+  workie finish feature/user-auth --pr
+
+  # Run checks, merge into main locally, then remove the worktree and branch
+  workie finish feature/user-auth --merge
+
+  # Preview the steps for the current worktree
+  workie finish --merge --dry-run
+
   # Finish and delete the branch
   workie finish feature/completed-feature --prune-branch
 
@@ -46,10 +73,8 @@ clean up your development environment.`,
 
   # Finish, delete branch, and force if needed
   workie finish hotfix/old-fix --prune-branch --force`,
-	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		branchName := args[0]
-
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Create manager with options
 		opts := manager.Options{
 			ConfigFile: configFile,
@@ -60,36 +85,168 @@ clean up your development environment.`,
 
 		// Detect git repository
 		if err := wm.DetectGitRepository(); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-			os.Exit(1)
+			return err
 		}
 
-		// Load configuration
-		if err := wm.LoadConfig(); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-			os.Exit(1)
+		if finishPR && finishMerge {
+			return fmt.Errorf("--pr and --merge cannot be used together")
 		}
 
-		// Remove the worktree
-		if err := finishWorktree(wm, branchName); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-			os.Exit(1)
+		branchName, worktreePath, err := resolveFinishTarget(wm, args)
+		if err != nil {
+			return err
 		}
+
+		// Use the hooks of the worktree's own .workie.local.yaml, unless it
+		// checks out a pull request from a fork
+		wm.Options.FromFork = manager.IsForkPullRequestBranch(branchName)
+		wm.UseWorktreeHooks(worktreePath)
+
+		applyPRDefaults(cmd, wm)
+
+		if finishDryRun {
+			printFinishPlan(wm, branchName, worktreePath)
+			return nil
+		}
+
+		// Remove the worktree
+		return finishWorktree(wm, branchName, worktreePath)
 	},
 }
 
-func finishWorktree(wm *manager.WorktreeManager, branchName string) error {
+// resolveFinishTarget determines the branch and worktree to finish. Without a branch
+// argument the current worktree is used, and commands run from the main worktree.
+// The configuration is loaded from the main worktree, so a configured worktree
+// naming applies when the worktree is not known to git.
+func resolveFinishTarget(wm *manager.WorktreeManager, args []string) (string, string, error) {
+	worktrees, err := wm.GetWorktrees()
+	if err != nil {
+		return "", "", err
+	}
+
+	var branchName string
+	if len(args) > 0 {
+		branchName = args[0]
+	} else {
+		if len(worktrees) == 0 || worktrees[0].Path == wm.RepoPath {
+			return "", "", fmt.Errorf("no branch specified and not inside a worktree\n\nTo fix this:\n  • Specify the branch: workie finish <branch>\n  • Or run workie finish from inside the worktree you want to finish\n  • Use 'workie --list' to see available worktrees")
+		}
+		if branchName, err = currentBranch(wm.RepoPath); err != nil {
+			return "", "", err
+		}
+	}
+
 	// Validate branch name
 	if strings.TrimSpace(branchName) == "" {
-		return fmt.Errorf("branch name cannot be empty")
+		return "", "", fmt.Errorf("branch name cannot be empty")
+	}
+
+	// The first worktree is always the main one; run git commands from there so the
+	// worktree being removed is never the working directory of those commands
+	if len(worktrees) > 0 && worktrees[0].Path != wm.RepoPath {
+		wm.RepoPath = worktrees[0].Path
+	}
+
+	if err := wm.LoadConfig(); err != nil {
+		return "", "", err
 	}
 
-	// Construct expected worktree path
-	worktreePath := filepath.Join(wm.WorktreesDir, branchName)
+	// Prefer the worktree git knows about, falling back to the expected path
+	worktreePath := wm.WorktreePath(branchName)
+	for _, wt := range worktrees[1:] {
+		if wt.Branch == branchName {
+			worktreePath = wt.Path
+			break
+		}
+	}
 
 	// Check if worktree path exists
 	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
-		return fmt.Errorf("worktree not found: %s\n\nTo fix this:\n  • Check the branch name is correct\n  • Use 'workie --list' to see available worktrees\n  • Verify the worktree hasn't already been removed", worktreePath)
+		return "", "", errcode.Errorf(errcode.WorktreeNotFound, "worktree not found: %s\n\nTo fix this:\n  • Check the branch name is correct\n  • Use 'workie --list' to see available worktrees\n  • Verify the worktree hasn't already been removed", worktreePath)
+	}
+
+	return branchName, worktreePath, nil
+}
+
+// printFinishPlan describes the steps finish would take without running them
+func printFinishPlan(wm *manager.WorktreeManager, branchName, worktreePath string) {
+	fmt.Printf("🔍 Dry run: finishing %s would:\n", branchName)
+	step := 1
+	next := func(format string, a ...interface{}) {
+		fmt.Printf("   %d. %s\n", step, fmt.Sprintf(format, a...))
+		step++
+	}
+
+	if (finishPR || finishMerge) && wm.HasPreFinishHooks() && !skipChecks {
+		next("Run pre_finish hooks: %s", joinHooks(wm.Config.Hooks.PreFinish))
+	}
+
+	base := prBase
+	if base == "" {
+		var err error
+		if base, err = wm.GetMainBranch(); err != nil {
+			base = "the main branch (not found; pass --base)"
+		}
+	}
+	if finishPR {
+		next("Push %s to origin and open a pull request against %s", branchName, base)
+	}
+	if finishMerge {
+		next("Ask for confirmation, then merge %s into %s", branchName, base)
+	}
+	if wm.HasPreRemoveHooks() {
+		next("Run pre_remove hooks: %s", joinHooks(wm.Config.Hooks.PreRemove))
+	}
+	next("Remove worktree %s", worktreePath)
+	if wm.HasPostRemoveHooks() {
+		next("Run post_remove hooks: %s", joinHooks(wm.Config.Hooks.PostRemove))
+	}
+	if pruneBranch || finishMerge {
+		next("Delete branch %s", branchName)
+	}
+}
+
+// joinHooks lists hook commands on one line for the dry-run plan
+func joinHooks(hooks []config.HookCommand) string {
+	commands := make([]string, len(hooks))
+	for i, hook := range hooks {
+		commands[i] = hook.String()
+	}
+	return strings.Join(commands, "; ")
+}
+
+func finishWorktree(wm *manager.WorktreeManager, branchName, worktreePath string) error {
+	wm.HookTarget = manager.HookTarget{Branch: branchName, WorktreePath: worktreePath}
+
+	if finishPR || finishMerge {
+		// Pushing or merging with uncommitted changes would leave work behind
+		if err := checkWorktreeStatus(wm, worktreePath); err != nil && !forceFinish {
+			return fmt.Errorf("cannot finish: %w\n\nTo fix this:\n  • Commit or stash your changes first\n  • Use --force to continue anyway (uncommitted changes will be lost)", err)
+		}
+
+		if wm.HasPreFinishHooks() && !skipChecks {
+			if err := wm.ExecuteRequiredHooks(wm.Config.Hooks.PreFinish, worktreePath, "pre_finish"); err != nil {
+				return errcode.Errorf(errcode.HookFailed, "%w\n\nTo fix this:\n  • Fix the failing check and run finish again\n  • Or use --skip-checks to skip pre_finish hooks", err)
+			}
+		}
+	}
+
+	if finishPR {
+		if err := createPullRequest(wm, worktreePath, branchName); err != nil {
+			return err
+		}
+	}
+
+	if finishMerge {
+		merged, err := mergeIntoMain(wm, branchName)
+		if err != nil {
+			return err
+		}
+		if !merged {
+			fmt.Printf("Merge cancelled; worktree left in place\n")
+			return nil
+		}
+		pruneBranch = true
 	}
 
 	// Execute pre_remove hooks if configured
@@ -99,9 +256,9 @@ func finishWorktree(wm *manager.WorktreeManager, branchName string) error {
 		}
 		if err := wm.ExecuteHooks(wm.Config.Hooks.PreRemove, worktreePath, "pre_remove"); err != nil {
 			// Don't fail the entire operation for hook errors, just warn
-			fmt.Printf("⚠️  Warning: Some pre_remove hooks failed, but worktree removal will continue\n")
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Some pre_remove hooks failed, but worktree removal will continue\n")
 			if wm.Options.Verbose {
-				fmt.Printf("Hook execution details: %v\n", err)
+				fmt.Fprintf(os.Stderr, "Hook execution details: %v\n", err)
 			}
 		}
 	} else {
@@ -112,7 +269,7 @@ func finishWorktree(wm *manager.WorktreeManager, branchName string) error {
 
 	// Check if worktree is currently active/checked out
 	if err := checkWorktreeStatus(wm, worktreePath); err != nil && !forceFinish {
-		return fmt.Errorf("worktree removal blocked: %w\n\nTo fix this:\n  • Commit or stash your changes\n  • Use --force to remove anyway (will lose uncommitted changes)", err)
+		return errcode.Errorf(errcode.UncommittedChanges, "worktree removal blocked: %w\n\nTo fix this:\n  • Commit or stash your changes\n  • Use --force to remove anyway (will lose uncommitted changes)", err)
 	}
 
 	if !wm.Options.Quiet {
@@ -131,11 +288,26 @@ func finishWorktree(wm *manager.WorktreeManager, branchName string) error {
 		fmt.Printf("✓ Worktree removed successfully\n")
 	}
 
+	// Forget the worktree's metadata; the branch may be begun again later
+	if err := wm.RemoveWorktreeMetadata(branchName); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: Failed to update the worktree index: %v\n", err)
+	}
+
+	// Execute post_remove hooks from the main repository, since the worktree is gone
+	if wm.HasPostRemoveHooks() {
+		if err := wm.ExecuteHooks(wm.Config.Hooks.PostRemove, wm.RepoPath, "post_remove"); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Some post_remove hooks failed\n")
+			if wm.Options.Verbose {
+				fmt.Fprintf(os.Stderr, "Hook execution details: %v\n", err)
+			}
+		}
+	}
+
 	// Optionally remove the branch
 	if pruneBranch {
 		if err := removeBranch(wm, branchName); err != nil {
-			fmt.Printf("⚠️  Warning: Failed to remove branch: %v\n", err)
-			fmt.Printf("You can manually remove it with: git branch -D %s\n", branchName)
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Failed to remove branch: %v\n", err)
+			fmt.Fprintf(os.Stderr, "You can manually remove it with: git branch -D %s\n", branchName)
 		} else {
 			if !wm.Options.Quiet {
 				fmt.Printf("✓ Branch '%s' removed successfully\n", branchName)
@@ -271,4 +443,72 @@ func init() {
 	// Add flags specific to finish command
 	finishCmd.Flags().BoolVarP(&forceFinish, "force", "f", false, "Force removal even with uncommitted changes")
 	finishCmd.Flags().BoolVarP(&pruneBranch, "prune-branch", "p", false, "Also delete the branch after removing worktree")
+	finishCmd.Flags().BoolVar(&finishPR, "pr", false, "Push the branch and open a pull request before removing the worktree")
+	finishCmd.Flags().BoolVar(&finishMerge, "merge", false, "Merge the branch into the main branch locally (after confirmation) before removing the worktree")
+	finishCmd.Flags().BoolVarP(&finishYes, "yes", "y", false, "Skip the merge confirmation prompt")
+	finishCmd.Flags().BoolVar(&finishDryRun, "dry-run", false, "Show what finish would do without doing it")
+	finishCmd.Flags().BoolVar(&skipChecks, "skip-checks", false, "Skip pre_finish hooks")
+	finishCmd.Flags().StringVar(&prBase, "base", "", "Branch to open the pull request against or merge into (default: the repository's main branch)")
+}
+
+// mergeIntoMain merges the branch into the base branch where it is checked out,
+// after asking for confirmation. It returns false if the user declined.
+func mergeIntoMain(wm *manager.WorktreeManager, branchName string) (bool, error) {
+	base := prBase
+	if base == "" {
+		var err error
+		if base, err = wm.GetMainBranch(); err != nil {
+			return false, fmt.Errorf("failed to determine main branch: %w", err)
+		}
+	}
+
+	// git merge must run where the base branch is checked out
+	worktrees, err := wm.GetWorktrees()
+	if err != nil {
+		return false, err
+	}
+	baseDir := ""
+	for _, wt := range worktrees {
+		if wt.Branch == base {
+			baseDir = wt.Path
+			break
+		}
+	}
+	if baseDir == "" {
+		return false, fmt.Errorf("branch '%s' is not checked out in any worktree\n\nTo fix this:\n  • Check it out in the main repository: git -C %s checkout %s\n  • Or open a pull request instead with --pr", base, wm.RepoPath, base)
+	}
+
+	if !finishYes && !confirm(fmt.Sprintf("Merge '%s' into '%s' in %s?", branchName, base, baseDir)) {
+		return false, nil
+	}
+
+	if wm.Options.Verbose {
+		fmt.Printf("Executing: git merge --no-edit %s (in %s)\n", branchName, baseDir)
+	}
+
+	cmd := exec.Command("git", "merge", "--no-edit", branchName)
+	cmd.Dir = baseDir
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return false, fmt.Errorf("git merge failed\n\nError details: %s\n\nTo fix this:\n  • Resolve the conflicts in %s and commit, then run finish again\n  • Or abort the merge: git -C %s merge --abort", strings.TrimSpace(stderr.String()), baseDir, baseDir)
+	}
+
+	if !wm.Options.Quiet {
+		fmt.Printf("✓ Merged '%s' into '%s'\n", branchName, base)
+	}
+	return true, nil
+}
+
+// confirm asks a yes/no question on stdin, defaulting to no
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
 }
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/agoodway/workie/manager"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	infoJSON bool   // Output the metadata as JSON
+	infoNote string // Note to add to the worktree's metadata
+)
+
+// infoCmd represents the info command
+var infoCmd = &cobra.Command{
+	Use:   "info <branch-name>",
+	Short: "Show what workie recorded about a worktree",
+	Long: `Info prints the metadata workie recorded when it created the worktree for a
+branch: the issue it was begun from, when it was created, the configuration
+file used and any notes.
+
+Metadata is only recorded when worktree_metadata is enabled in .workie.yaml,
+or for worktrees brought under workie's management with 'workie adopt':
+
+  worktree_metadata: true
+
+It is written to .workie/meta.json in each new worktree (kept out of git status
+through .git/info/exclude) and to an index, .workie-index.json, in the
+worktrees directory. Use --note to add a note to an existing worktree.`,
+	Example: `  # Show a worktree's issue, creation time and notes
+  workie info feature/user-auth
+
+  # Add a note
+  workie info feature/user-auth --note "Waiting on API review"
+
+  # Machine-readable output
+  workie info feature/user-auth --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := manager.Options{
+			ConfigFile: configFile,
+			Verbose:    verbose,
+			Quiet:      quiet,
+		}
+		wm := manager.NewWithOptions(opts)
+
+		if err := wm.DetectGitRepository(); err != nil {
+			return err
+		}
+
+		var meta *manager.WorktreeMetadata
+		var err error
+		if infoNote != "" {
+			meta, err = wm.AddWorktreeNote(args[0], infoNote)
+		} else {
+			meta, err = wm.GetWorktreeMetadata(args[0])
+		}
+		if err != nil {
+			return err
+		}
+
+		if infoJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(meta)
+		}
+
+		if infoNote != "" {
+			infof("📝 Note added to %s\n\n", meta.Branch)
+		}
+		printWorktreeMetadata(meta)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+
+	// Add flags
+	infoCmd.Flags().BoolVar(&infoJSON, "json", false, "Output the metadata as JSON")
+	infoCmd.Flags().StringVar(&infoNote, "note", "", "Add a note to the worktree's metadata")
+}
+
+// printWorktreeMetadata prints a worktree's metadata for people
+func printWorktreeMetadata(meta *manager.WorktreeMetadata) {
+	fmt.Printf("Branch:  %s\n", meta.Branch)
+	fmt.Printf("Path:    %s\n", meta.Path)
+	fmt.Printf("Created: %s\n", meta.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	if meta.AdoptedFrom != "" {
+		fmt.Printf("Adopted: from %s\n", meta.AdoptedFrom)
+	}
+	if meta.Config != "" {
+		fmt.Printf("Config:  %s\n", meta.Config)
+	}
+	if meta.Issue != nil {
+		fmt.Printf("Issue:   %s", meta.Issue)
+		if meta.Issue.Title != "" {
+			fmt.Printf(" %s", meta.Issue.Title)
+		}
+		fmt.Println()
+		if meta.Issue.URL != "" {
+			fmt.Printf("         %s\n", meta.Issue.URL)
+		}
+	}
+	if len(meta.Notes) > 0 {
+		fmt.Printf("Notes:\n")
+		for _, note := range meta.Notes {
+			fmt.Printf("   • %s\n", note)
+		}
+	}
+}
@@ -9,8 +9,10 @@ import (
 )
 
 var (
-	forceInit  bool
-	outputFile string
+	forceInit       bool
+	outputFile      string
+	initInteractive bool
+	initShowDiff    bool
 )
 
 // initCmd represents the init command
@@ -21,10 +23,28 @@ var initCmd = &cobra.Command{
 with commented examples and best practices.
 
 This command helps you get started with Workie by generating a comprehensive
-configuration template that you can customize for your project's needs.`,
+configuration template that you can customize for your project's needs.
+
+With --interactive, workie instead asks which files to copy, which issue
+providers to set up (with their settings and the environment variables holding
+credentials, warning about any that are not set), whether to enable AI and
+which hook to run, and writes a short configuration with just those settings.
+--force and --output work as without it. Without a terminal it falls back to
+the commented template.
+
+With --show-diff, workie leaves an existing configuration alone and reports
+how it compares with this version: settings changed from their default,
+settings that are available but not set, and keys it doesn't recognize (often
+misspelled or removed settings, which are otherwise silently ignored).`,
 	Example: `  # Create .workie.yaml in current directory
   workie init
 
+  # Answer a few questions instead of editing the template
+  workie init --interactive
+
+  # See which defaults you override and which settings are new
+  workie init --show-diff
+
   # Create config file with specific name
   workie init --output custom-workie.yaml
 
@@ -34,11 +54,11 @@ configuration template that you can customize for your project's needs.`,
   # Create config in a specific directory
   cd /path/to/project && workie init`,
 	Args: cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := createConfigFile(); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-			os.Exit(1)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if initShowDiff {
+			return showConfigDiff()
 		}
+		return createConfigFile()
 	},
 }
 
@@ -62,6 +82,12 @@ func createConfigFile() error {
 
 	// Generate configuration content
 	configContent := generateConfigContent()
+	interactive := initInteractive && canPromptInteractively()
+	if initInteractive {
+		if configContent, err = interactiveConfigContent(); err != nil {
+			return err
+		}
+	}
 
 	// Write configuration file
 	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
@@ -71,19 +97,25 @@ func createConfigFile() error {
 		return fmt.Errorf("failed to write config file %s: %w\n\nTo fix this:\n  • Check available disk space\n  • Verify directory permissions\n  • Ensure the path is valid", absPath, err)
 	}
 
+	// In quiet mode only the created file's path is printed
+	if quiet {
+		fmt.Println(absPath)
+		return nil
+	}
+
 	// Success message
-	if !quiet {
-		fmt.Printf("✅ Created Workie configuration file: %s\n", configPath)
-		fmt.Printf("\n💡 Next steps:\n")
-		fmt.Printf("  • Edit %s to customize for your project\n", configPath)
+	fmt.Printf("✅ Created Workie configuration file: %s\n", configPath)
+	fmt.Printf("\n💡 Next steps:\n")
+	fmt.Printf("  • Edit %s to customize for your project\n", configPath)
+	if !interactive {
 		fmt.Printf("  • Uncomment the files and directories you want to copy\n")
-		fmt.Printf("  • Add project-specific files to the files_to_copy section\n")
-		fmt.Printf("  • Run 'workie your-branch-name' to test the configuration\n")
+	}
+	fmt.Printf("  • Add project-specific files to the files_to_copy section\n")
+	fmt.Printf("  • Run 'workie your-branch-name' to test the configuration\n")
 
-		if verbose {
-			fmt.Printf("\n📄 Configuration file created at: %s\n", absPath)
-			fmt.Printf("📝 File size: %d bytes\n", len(configContent))
-		}
+	if verbose {
+		fmt.Printf("\n📄 Configuration file created at: %s\n", absPath)
+		fmt.Printf("📝 File size: %d bytes\n", len(configContent))
 	}
 
 	return nil
@@ -103,6 +135,9 @@ files_to_copy:
   # - .env.dev.example
   # - .env.test.example
   # - .env.local.example
+  # Copy to a different path in the worktree
+  # - src: .env.development
+  #   dst: .env
 
   # Configuration files
   # - config/development.yaml
@@ -169,15 +204,45 @@ files_to_copy:
   # - .idea/
   # - .sublime-project
 
+# Refuse to create a worktree when files_to_copy would copy more than this
+# max_copy_size: 500MB
+
 # Post-creation hooks (uncomment and customize as needed)
 # hooks:
+#   pre_create:                # runs in the repository first; a failure aborts creation
+#     - "git fetch origin"
+#   pre_copy:                  # runs in the repository after creation, before files are copied
+#     - "sops -d secrets.enc.env > .env"
+#   post_copy:                 # runs in the worktree after files are copied, before post_create
+#     - "chmod +x scripts/*.sh"
 #   post_create:
 #     - "echo 'Setting up new worktree...'"
 #     - "npm install"
-#     - "make setup"
+#     - command: "make setup"  # a command may override timeout_minutes
+#       timeout: 15m
 #   pre_remove:
 #     - "echo 'Cleaning up worktree...'"
 #     - "npm run cleanup"
+#   post_remove:               # runs in the repository after the worktree is removed
+#     - "docker compose down --remove-orphans"
+#   pre_finish:                # must pass before 'workie finish --pr/--merge'
+#     - "npm test"
+#   timeout_minutes: 5         # limit for each command (default: 5)
+#   shell: bash                # runs commands that need a shell (default: sh, cmd on Windows)
+#   # Hooks can read WORKIE_BRANCH, WORKIE_WORKTREE_PATH, WORKIE_REPO_PATH,
+#   # WORKIE_REPO_NAME and WORKIE_HOOK_TYPE from their environment
+
+# Editor that 'workie begin --open' opens new worktrees in (default: $VISUAL, then $EDITOR)
+# editor: code
+
+# Record each new worktree's issue, creation time and notes in .workie/meta.json
+# (show them with 'workie info <branch>')
+# worktree_metadata: true
+
+# How branch names become worktree directories: nested (default) keeps slashes,
+# so feature/login is created in feature/login; flat replaces them with dashes
+# (feature-login) to keep every worktree one level deep
+# worktree_dir_naming: flat
 
 
 # AI Configuration (Ollama-based Assistant)
@@ -186,13 +251,20 @@ files_to_copy:
 # ai:
 #   enabled: true
 #   model:
-#     provider: "ollama"
+#     provider: "ollama"          # ollama or openai
 #     name: "llama3.2"
 #     temperature: 0.7
 #     max_tokens: 2048
 #   ollama:
 #     base_url: "http://localhost:11434"
 #     keep_alive: "5m"
+#   # With provider: "openai", any OpenAI-compatible API is used instead
+#   openai:
+#     base_url: "https://api.openai.com/v1"
+#     api_key_env: "OPENAI_API_KEY"
+#   system_prompt: "Answer concisely and follow our coding standards."  # Also loads .workie/prompt.md
+#   usage_log: .workie/ai-usage.jsonl  # Record each AI call's size, tokens and latency
+#   require: false                     # Fail on AI errors instead of falling back to non-AI results
 #   features:
 #     code_analysis: true
 #     code_generation: true
@@ -229,11 +301,17 @@ files_to_copy:
 
 # Issue Provider Configuration (Optional)
 # ======================================
-# Connect to GitHub, Jira, or Linear to work with issues
+# Connect to GitHub, Jira, Linear, or Bitbucket to work with issues
 
 # Default provider to use when no provider is specified in issue commands
 # default_provider: github
 
+# Fetched issues are cached in ~/.cache/workie so viewing an issue and then
+# beginning work on it makes one API request (bypass with --no-cache)
+# issue_cache:
+#   ttl: 5m          # how long a fetched issue is reused (default: 5m)
+#   disabled: false
+
 # providers:
 #   github:
 #     enabled: true
@@ -258,6 +336,9 @@ files_to_copy:
 #       story: "feature/"
 #       task: "task/"
 #       default: "jira/"
+#     branch_name:
+#       separator: "-"             # -, _ or . between words
+#       preserve_id_case: true     # keep PROJ-456 uppercase in branch names
 #
 #   linear:
 #     enabled: false
@@ -268,6 +349,19 @@ files_to_copy:
 #       bug: "fix/"
 #       feature: "feat/"
 #       default: "linear/"
+#
+#   bitbucket:
+#     enabled: false
+#     settings:
+#       workspace: "your-workspace"
+#       repo_slug: "your-repo"
+#       username_env: "BITBUCKET_USERNAME"          # Environment variable for your Bitbucket username
+#       app_password_env: "BITBUCKET_APP_PASSWORD"  # App password with Issues: Read permission
+#     branch_prefix:
+#       bug: "fix/"
+#       enhancement: "feat/"
+#       task: "task/"
+#       default: "issue/"
 
 # Issue Provider Usage:
 # ===================
@@ -284,4 +378,6 @@ func init() {
 	// Add flags specific to init command
 	initCmd.Flags().BoolVarP(&forceInit, "force", "f", false, "Overwrite existing configuration file")
 	initCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file name (default: .workie.yaml)")
+	initCmd.Flags().BoolVarP(&initInteractive, "interactive", "i", false, "Build the configuration by answering a few questions")
+	initCmd.Flags().BoolVar(&initShowDiff, "show-diff", false, "Compare the existing configuration with the current defaults instead of creating one")
 }
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agoodway/workie/config"
+)
+
+// showConfigDiff compares an existing configuration file with the settings and
+// defaults of this version of workie, without changing the file
+func showConfigDiff() error {
+	configPath := ".workie.yaml"
+	if outputFile != "" {
+		configPath = outputFile
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("configuration file not found: %s\n\nTo fix this:\n  • Run 'workie init' to create one\n  • Use --output to compare a different file", configPath)
+		}
+		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	diff, err := config.DiffAgainstDefaults(data)
+	if err != nil {
+		return fmt.Errorf("cannot compare %s: %w\n\nTo fix this:\n  • Run 'workie config validate' to locate the problem", configPath, err)
+	}
+
+	fmt.Printf("🔍 Comparing %s with the defaults of this version of workie\n", configPath)
+
+	if len(diff.Changed) > 0 {
+		fmt.Printf("\nChanged from the default (%d):\n", len(diff.Changed))
+		for _, k := range diff.Changed {
+			fmt.Printf("  ~ %s: %s (default: %s)\n", k.Key, k.Value, k.Default)
+		}
+	}
+
+	if len(diff.Unset) > 0 {
+		fmt.Printf("\nAvailable but not set (%d):\n", len(diff.Unset))
+		for _, k := range diff.Unset {
+			if k.Default != "" {
+				fmt.Printf("  + %s (default: %s)\n", k.Key, k.Default)
+			} else {
+				fmt.Printf("  + %s\n", k.Key)
+			}
+		}
+	}
+
+	if len(diff.Unknown) > 0 {
+		fmt.Printf("\nNot recognized, and ignored (%d):\n", len(diff.Unknown))
+		for _, key := range diff.Unknown {
+			fmt.Printf("  - %s\n", key)
+		}
+	}
+
+	if len(diff.Changed)+len(diff.Unset)+len(diff.Unknown) == 0 {
+		fmt.Printf("\n✅ Every setting is configured and recognized\n")
+		return nil
+	}
+
+	fmt.Printf("\n💡 Run 'workie init --output workie.example.yaml' to see the commented template\n")
+	return nil
+}
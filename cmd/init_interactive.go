@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// initCandidateFiles are commonly copied files that 'workie init --interactive'
+// offers as the default files_to_copy when they exist
+var initCandidateFiles = []string{
+	".env", ".env.example", ".env.local", ".env.development",
+	".envrc", ".tool-versions", ".vscode/", ".idea/", "config/master.key",
+}
+
+// initAnswers holds the answers given to 'workie init --interactive'
+type initAnswers struct {
+	FilesToCopy     []string
+	Providers       []initProvider
+	DefaultProvider string
+	AIEnabled       bool
+	AIModel         string
+	PostCreate      string
+	HookTimeout     int
+}
+
+// initProvider is an issue provider chosen in 'workie init --interactive'
+type initProvider struct {
+	Name     string
+	Settings [][2]string // Settings in the order they were asked
+}
+
+// initProviderNames are the issue providers 'workie init --interactive' can set up
+var initProviderNames = []string{"github", "jira", "linear", "bitbucket"}
+
+// initProviderSettings are the settings asked for each issue provider, with
+// their defaults
+var initProviderSettings = map[string][][2]string{
+	"github":    {{"token_env", "GITHUB_TOKEN"}, {"owner", ""}, {"repo", ""}},
+	"jira":      {{"base_url", "https://your-company.atlassian.net"}, {"email_env", "JIRA_EMAIL"}, {"api_token_env", "JIRA_TOKEN"}, {"project", ""}},
+	"linear":    {{"api_key_env", "LINEAR_API_KEY"}, {"team_id", ""}},
+	"bitbucket": {{"workspace", ""}, {"repo_slug", ""}, {"username_env", "BITBUCKET_USERNAME"}, {"app_password_env", "BITBUCKET_APP_PASSWORD"}},
+}
+
+// initBranchPrefixes are the branch_prefix settings written for each issue
+// provider, the same as in the commented template
+var initBranchPrefixes = map[string][][2]string{
+	"github":    {{"bug", "fix/"}, {"feature", "feat/"}, {"default", "issue/"}},
+	"jira":      {{"bug", "bugfix/"}, {"story", "feature/"}, {"task", "task/"}, {"default", "jira/"}},
+	"linear":    {{"bug", "fix/"}, {"feature", "feat/"}, {"default", "linear/"}},
+	"bitbucket": {{"bug", "fix/"}, {"enhancement", "feat/"}, {"task", "task/"}, {"default", "issue/"}},
+}
+
+// canPromptInteractively reports whether both stdin and stdout are terminals
+func canPromptInteractively() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// prompter asks questions on out and reads the answers line by line from in
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask returns the trimmed answer to question, or def when it is left empty
+func (p *prompter) ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	answer, err := p.in.ReadString('\n')
+	if err == io.EOF && answer == "" {
+		return "", fmt.Errorf("input closed before all questions were answered")
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// askYesNo asks a yes/no question until it gets a valid answer
+func (p *prompter) askYesNo(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		answer, err := p.ask(question+" ("+hint+")", "")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintf(p.out, "   Please answer y or n\n")
+	}
+}
+
+// askChoice asks until the answer is one of choices
+func (p *prompter) askChoice(question string, choices []string, def string) (string, error) {
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s (%s)", question, strings.Join(choices, ", ")), def)
+		if err != nil {
+			return "", err
+		}
+		for _, choice := range choices {
+			if strings.EqualFold(answer, choice) {
+				return choice, nil
+			}
+		}
+		fmt.Fprintf(p.out, "   Please choose one of: %s\n", strings.Join(choices, ", "))
+	}
+}
+
+// askList asks until every comma-separated item of the answer is one of
+// choices, or the answer is "none"
+func (p *prompter) askList(question string, choices []string, def string) ([]string, error) {
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s (%s, or none)", question, strings.Join(choices, ", ")), def)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(answer, "none") {
+			return nil, nil
+		}
+
+		var items []string
+		valid := true
+		for _, item := range strings.Split(answer, ",") {
+			item = strings.ToLower(strings.TrimSpace(item))
+			if item == "" || slices.Contains(items, item) {
+				continue
+			}
+			if !slices.Contains(choices, item) {
+				valid = false
+				break
+			}
+			items = append(items, item)
+		}
+		if valid && len(items) > 0 {
+			return items, nil
+		}
+		fmt.Fprintf(p.out, "   Please list some of: %s\n", strings.Join(choices, ", "))
+	}
+}
+
+// askInitQuestions walks through the questions of 'workie init --interactive'.
+// existing reports whether a path exists, to suggest files worth copying, and
+// getenv looks up environment variables, to warn about credentials that are not set.
+func askInitQuestions(p *prompter, existing func(path string) bool, getenv func(key string) string) (*initAnswers, error) {
+	answers := &initAnswers{HookTimeout: 5}
+
+	var detected []string
+	for _, candidate := range initCandidateFiles {
+		if existing(candidate) {
+			detected = append(detected, candidate)
+		}
+	}
+	fmt.Fprintf(p.out, "📂 Files to copy into every new worktree\n")
+	files, err := p.ask("   Paths, comma-separated (blank for none)", strings.Join(detected, ", "))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range strings.Split(files, ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			answers.FilesToCopy = append(answers.FilesToCopy, file)
+		}
+	}
+
+	fmt.Fprintf(p.out, "\n🔗 Issue providers\n")
+	names, err := p.askList("   Providers, comma-separated", initProviderNames, "none")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		fmt.Fprintf(p.out, "   %s\n", name)
+		provider := initProvider{Name: name}
+		for _, setting := range initProviderSettings[name] {
+			value, err := p.ask("     "+setting[0], setting[1])
+			if err != nil {
+				return nil, err
+			}
+			if value == "" {
+				continue
+			}
+			provider.Settings = append(provider.Settings, [2]string{setting[0], value})
+
+			// Credentials are read from the environment when the provider is used
+			if strings.HasSuffix(setting[0], "_env") && getenv(value) == "" {
+				fmt.Fprintf(p.out, "     ⚠️  $%s is not set; export it before using %s\n", value, name)
+			}
+		}
+		answers.Providers = append(answers.Providers, provider)
+	}
+	if len(names) > 0 {
+		answers.DefaultProvider = names[0]
+	}
+	if len(names) > 1 {
+		if answers.DefaultProvider, err = p.askChoice("   Default provider for bare issue IDs", names, names[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Fprintf(p.out, "\n🤖 AI features (need a local Ollama server)\n")
+	answers.AIEnabled, err = p.askYesNo("   Enable AI", false)
+	if err != nil {
+		return nil, err
+	}
+	if answers.AIEnabled {
+		if answers.AIModel, err = p.ask("   Model", "llama3.2"); err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Fprintf(p.out, "\n🪝 Hooks\n")
+	if answers.PostCreate, err = p.ask("   Command to run in new worktrees, e.g. npm install (blank for none)", ""); err != nil {
+		return nil, err
+	}
+	if answers.PostCreate != "" {
+		for {
+			value, err := p.ask("   Timeout per hook command in minutes", strconv.Itoa(answers.HookTimeout))
+			if err != nil {
+				return nil, err
+			}
+			if minutes, err := strconv.Atoi(value); err == nil && minutes > 0 {
+				answers.HookTimeout = minutes
+				break
+			}
+			fmt.Fprintf(p.out, "   Please enter a whole number of minutes\n")
+		}
+	}
+
+	return answers, nil
+}
+
+// renderInitConfig writes a .workie.yaml containing just what was chosen
+func renderInitConfig(answers *initAnswers) string {
+	var b strings.Builder
+	b.WriteString("# Workie Configuration File\n")
+	b.WriteString("# Generated by 'workie init --interactive'. For a commented template of\n")
+	b.WriteString("# every setting, run 'workie init --output workie.example.yaml'\n\n")
+
+	if len(answers.FilesToCopy) == 0 {
+		b.WriteString("files_to_copy: []\n")
+	} else {
+		b.WriteString("files_to_copy:\n")
+		for _, file := range answers.FilesToCopy {
+			fmt.Fprintf(&b, "  - %q\n", file)
+		}
+	}
+
+	if answers.PostCreate != "" {
+		b.WriteString("\nhooks:\n  post_create:\n")
+		fmt.Fprintf(&b, "    - %q\n", answers.PostCreate)
+		fmt.Fprintf(&b, "  timeout_minutes: %d\n", answers.HookTimeout)
+	}
+
+	if answers.AIEnabled {
+		b.WriteString("\nai:\n  enabled: true\n  model:\n    provider: \"ollama\"\n")
+		fmt.Fprintf(&b, "    name: %q\n", answers.AIModel)
+	}
+
+	if len(answers.Providers) > 0 {
+		fmt.Fprintf(&b, "\ndefault_provider: %s\n\nproviders:\n", answers.DefaultProvider)
+		for _, provider := range answers.Providers {
+			fmt.Fprintf(&b, "  %s:\n    enabled: true\n", provider.Name)
+			if len(provider.Settings) > 0 {
+				b.WriteString("    settings:\n")
+				for _, setting := range provider.Settings {
+					fmt.Fprintf(&b, "      %s: %q\n", setting[0], setting[1])
+				}
+			}
+			b.WriteString("    branch_prefix:\n")
+			for _, prefix := range initBranchPrefixes[provider.Name] {
+				fmt.Fprintf(&b, "      %s: %q\n", prefix[0], prefix[1])
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// interactiveConfigContent asks the init questions on the terminal and renders
+// the answers, falling back to the commented template without a terminal
+func interactiveConfigContent() (string, error) {
+	if !canPromptInteractively() {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: --interactive needs a terminal, writing the standard template instead\n")
+		return generateConfigContent(), nil
+	}
+
+	fmt.Printf("📝 Answer a few questions to create your configuration (press Enter for the default)\n\n")
+	p := &prompter{in: bufio.NewReader(os.Stdin), out: os.Stdout}
+	answers, err := askInitQuestions(p, func(path string) bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, os.Getenv)
+	if err != nil {
+		return "", fmt.Errorf("failed to read answers: %w", err)
+	}
+	fmt.Println()
+	return renderInitConfig(answers), nil
+}
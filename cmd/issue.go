@@ -1,33 +1,41 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
-	"text/tabwriter"
 
+	"github.com/agoodway/workie/errcode"
 	"github.com/agoodway/workie/manager"
 	"github.com/agoodway/workie/provider"
+	"github.com/agoodway/workie/provider/bitbucket"
 	"github.com/agoodway/workie/provider/github"
 	"github.com/agoodway/workie/provider/jira"
 	"github.com/agoodway/workie/provider/linear"
+	"github.com/agoodway/workie/table"
 	"github.com/spf13/cobra"
 )
 
 var (
-	issueProvider string
-	issueStatus   string
-	issueAssignee string
-	issueLimit    int
-	issueLabels   []string
-	issueQuery    string
-	issueCreate   bool
+	issueProvider  string
+	issueStatus    string
+	issueAssignees []string
+	issueLimit     int
+	issueLabels    []string
+	issueQuery     string
+	issueCreate    bool
+	issueIncludePR bool // List GitHub pull requests too, and fetch IDs that are pull requests
+
+	issueNoCache    bool // Fetch issues from the provider even if they are cached
+	issueClearCache bool // Delete all cached issues
 )
 
 // issuesCmd represents the issues command
 var issuesCmd = &cobra.Command{
 	Use:   "issues [provider:id]",
-	Short: "Work with issues from GitHub, Jira, or Linear",
+	Short: "Work with issues from GitHub, Jira, Linear, or Bitbucket",
 	Long: `Fetch and work with issues from various issue tracking providers.
 
 You can list issues, view issue details, or create a worktree based on an issue.
@@ -42,6 +50,9 @@ Examples:
   # List issues assigned to you
   workie issues --assignee me
 
+  # List issues assigned to you or alice
+  workie issues --assignee me,alice
+
   # List issues with specific status
   workie issues --status in-progress
 
@@ -50,9 +61,25 @@ Examples:
   workie issues jira:PROJ-456
   workie issues linear:TEAM-789
 
+  # Bare IDs use --provider, then default_provider, then the only configured provider
+  workie issues 123 --provider github
+
   # Create a worktree from an issue
   workie issues github:123 --create
-  workie issues jira:PROJ-456 -c`,
+  workie issues jira:PROJ-456 -c
+
+  # List GitHub pull requests along with issues
+  workie issues --provider github --include-prs
+
+  # Review a pull request in a worktree checked out on its head branch
+  workie issues pr:42 --create
+
+Fetched issues are cached for 5 minutes (issue_cache.ttl) so that viewing an
+issue and then beginning work on it makes a single API request. Use --no-cache
+to fetch the issue again, or --clear-cache to empty the cache.
+
+Pull requests are never cached. A pull request from a fork is checked out on a
+pr/<number> branch fetched from refs/pull/<number>/head.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runIssue,
 }
@@ -61,16 +88,23 @@ func init() {
 	rootCmd.AddCommand(issuesCmd)
 
 	// Add flags
-	issuesCmd.Flags().StringVarP(&issueProvider, "provider", "p", "", "Filter by provider (github, jira, linear)")
+	issuesCmd.Flags().StringVarP(&issueProvider, "provider", "p", "", "Filter by provider, or the provider of a bare issue ID (github, jira, linear, bitbucket)")
 	issuesCmd.Flags().StringVarP(&issueStatus, "status", "s", "", "Filter by status (open, closed, in-progress)")
-	issuesCmd.Flags().StringVarP(&issueAssignee, "assignee", "a", "", "Filter by assignee (use 'me' for current user)")
+	issuesCmd.Flags().StringSliceVarP(&issueAssignees, "assignee", "a", nil, "Filter by assignees, comma-separated (use 'me' for current user)")
 	issuesCmd.Flags().IntVarP(&issueLimit, "limit", "n", 20, "Maximum number of issues to display")
 	issuesCmd.Flags().StringSliceVarP(&issueLabels, "labels", "l", nil, "Filter by labels (comma-separated)")
-	issuesCmd.Flags().StringVarP(&issueQuery, "query", "q", "", "Search query")
+	issuesCmd.Flags().StringVar(&issueQuery, "query", "", "Search query")
 	issuesCmd.Flags().BoolVarP(&issueCreate, "create", "c", false, "Create a worktree from the issue")
+	issuesCmd.Flags().BoolVar(&issueIncludePR, "include-prs", false, "Include GitHub pull requests, as issues of type pull_request")
+	issuesCmd.Flags().BoolVar(&issueNoCache, "no-cache", false, "Fetch the issue from the provider instead of the cache")
+	issuesCmd.Flags().BoolVar(&issueClearCache, "clear-cache", false, "Delete all cached issues and exit")
 }
 
 func runIssue(cmd *cobra.Command, args []string) error {
+	if issueClearCache {
+		return clearIssueCache()
+	}
+
 	// Create manager with options
 	opts := manager.Options{
 		ConfigFile: configFile,
@@ -152,9 +186,11 @@ func initializeProviders(wm *manager.WorktreeManager, registry *provider.Registr
 			p, err = jira.NewProvider(configMap)
 		case "linear":
 			p, err = linear.NewProvider(configMap)
+		case "bitbucket":
+			p, err = bitbucket.NewProvider(configMap)
 		default:
 			if verbose {
-				fmt.Printf("Unknown provider type: %s\n", name)
+				fmt.Fprintf(os.Stderr, "Unknown provider type: %s\n", name)
 			}
 			continue
 		}
@@ -169,7 +205,7 @@ func initializeProviders(wm *manager.WorktreeManager, registry *provider.Registr
 				return fmt.Errorf("failed to register %s provider: %w", name, err)
 			}
 		} else if verbose {
-			fmt.Printf("Provider %s is not fully configured\n", name)
+			fmt.Fprintf(os.Stderr, "Provider %s is not fully configured\n", name)
 		}
 	}
 
@@ -177,37 +213,26 @@ func initializeProviders(wm *manager.WorktreeManager, registry *provider.Registr
 }
 
 func handleSpecificIssue(wm *manager.WorktreeManager, registry *provider.Registry, issueRef string) error {
-	// Parse issue reference
-	providerName, issueID, err := provider.ParseIssueReference(issueRef)
-	if err != nil {
-		// If parsing fails, check if it's just an issue ID and we have a default provider
-		if wm.Config.DefaultProvider != "" && !strings.Contains(issueRef, ":") {
-			providerName = wm.Config.DefaultProvider
-			issueID = issueRef
-		} else {
-			return err
-		}
-	}
-
-	// Get provider
-	p, err := registry.Get(providerName)
+	issue, p, err := fetchIssue(wm, registry, issueRef)
 	if err != nil {
-		return fmt.Errorf("provider '%s' not found or not configured", providerName)
-	}
-
-	// Fetch issue
-	issue, err := p.GetIssue(issueID)
-	if err != nil {
-		return fmt.Errorf("failed to fetch issue: %w", err)
+		return err
 	}
 
 	// Display issue details
 	displayIssueDetails(issue)
 
 	// Create worktree if requested
+	if issueCreate && issue.Type == provider.IssueTypePullRequest {
+		return createPullRequestWorktree(wm, issue)
+	}
 	if issueCreate {
 		branchName := p.CreateBranchName(issue)
-		fmt.Printf("\n🌳 Creating worktree with branch: %s\n", branchName)
+		infof("\n🌳 Creating worktree with branch: %s\n", branchName)
+
+		if err := seedIssueTemplate(wm, issue); err != nil {
+			return err
+		}
+		recordIssue(wm, issue)
 
 		if err := wm.CreateWorktreeBranch(branchName); err != nil {
 			return fmt.Errorf("failed to create worktree: %w", err)
@@ -219,14 +244,131 @@ func handleSpecificIssue(wm *manager.WorktreeManager, registry *provider.Registr
 	return nil
 }
 
+// pullRequestRefPrefix introduces a reference to a GitHub pull request, e.g. "pr:42"
+const pullRequestRefPrefix = "pr:"
+
+// fetchIssue fetches the issue an issue reference refers to, and its provider.
+// A "pr:<number>" reference, or with --include-prs an issue ID that belongs to
+// a pull request, fetches the pull request instead.
+func fetchIssue(wm *manager.WorktreeManager, registry *provider.Registry, issueRef string) (*provider.Issue, provider.Provider, error) {
+	if number, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(issueRef)), pullRequestRefPrefix); ok {
+		p, err := pullRequestFetcher(wm, registry)
+		if err != nil {
+			return nil, nil, err
+		}
+		issue, err := p.(provider.PullRequestFetcher).GetPullRequest(strings.TrimSpace(number))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch pull request: %w", err)
+		}
+		return issue, p, nil
+	}
+
+	p, issueID, err := registry.ResolveIssueReference(issueRef, issueProvider, wm.Config.GetDefaultProvider())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issue, err := newIssueCache(wm).GetIssue(p, issueID)
+	if fetcher, ok := p.(provider.PullRequestFetcher); ok && issueIncludePR && errors.Is(err, provider.ErrPullRequest) {
+		issue, err = fetcher.GetPullRequest(issueID)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch issue: %w", err)
+	}
+	return issue, p, nil
+}
+
+// pullRequestFetcher selects the provider that fetches a "pr:<number>" reference:
+// the --provider, the default provider, then any configured provider that supports it
+func pullRequestFetcher(wm *manager.WorktreeManager, registry *provider.Registry) (provider.Provider, error) {
+	names := registry.ListConfigured()
+	sort.Strings(names)
+	if wm.Config.GetDefaultProvider() != "" {
+		names = append([]string{wm.Config.GetDefaultProvider()}, names...)
+	}
+	if issueProvider != "" {
+		names = []string{issueProvider}
+	}
+
+	for _, name := range names {
+		p, err := registry.Get(name)
+		if err != nil || !p.IsConfigured() {
+			continue
+		}
+		if _, ok := p.(provider.PullRequestFetcher); ok {
+			return p, nil
+		}
+	}
+
+	return nil, errcode.Errorf(errcode.ProviderNotConfigured, "no configured provider supports fetching pull requests\n\nTo fix this:\n  • Configure the github provider in your .workie.yaml file\n  • Ensure its token_env, owner and repo settings are set")
+}
+
+// createPullRequestWorktree creates a worktree checked out on a pull request's
+// head branch, for reviewing it
+func createPullRequestWorktree(wm *manager.WorktreeManager, issue *provider.Issue) error {
+	branchName, err := wm.FetchPullRequest(issue.ID, issue.Metadata["head_ref"], issue.Metadata["from_fork"] == "true")
+	if err != nil {
+		return err
+	}
+	infof("\n🌳 Creating worktree for pull request #%s with branch: %s\n", issue.ID, branchName)
+
+	if err := seedIssueTemplate(wm, issue); err != nil {
+		return err
+	}
+	recordIssue(wm, issue)
+
+	wm.Options.Checkout = true
+	wm.Options.FromFork = issue.Metadata["from_fork"] == "true"
+	if err := wm.CreateWorktreeBranch(branchName); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+	return nil
+}
+
+// newIssueCache returns the cache issue lookups go through, or nil when caching
+// is turned off with --no-cache or issue_cache.disabled
+func newIssueCache(wm *manager.WorktreeManager) *provider.IssueCache {
+	ttl := wm.Config.GetIssueCacheTTL()
+	if issueNoCache || ttl <= 0 {
+		return nil
+	}
+
+	dir, err := provider.DefaultIssueCacheDir()
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Issue cache unavailable: %v\n", err)
+		}
+		return nil
+	}
+	return &provider.IssueCache{Dir: dir, Scope: wm.RepoPath, TTL: ttl}
+}
+
+// clearIssueCache deletes every cached issue, for all repositories
+func clearIssueCache() error {
+	dir, err := provider.DefaultIssueCacheDir()
+	if err != nil {
+		return fmt.Errorf("cannot locate the issue cache: %w", err)
+	}
+
+	cache := &provider.IssueCache{Dir: dir}
+	cleared, err := cache.Clear()
+	if err != nil {
+		return err
+	}
+	infof("🗑️  Cleared %d cached issue(s) from %s\n", cleared, dir)
+	return nil
+}
+
 func listIssues(wm *manager.WorktreeManager, registry *provider.Registry) error {
 	// Build filter
 	filter := provider.ListFilter{
-		Status:   issueStatus,
-		Assignee: issueAssignee,
-		Labels:   issueLabels,
-		Limit:    issueLimit,
-		Query:    issueQuery,
+		Status:    issueStatus,
+		Assignees: issueAssignees,
+		Labels:    issueLabels,
+		Limit:     issueLimit,
+		Query:     issueQuery,
+
+		IncludePullRequests: issueIncludePR,
 	}
 
 	// Get list of providers to query
@@ -246,10 +388,10 @@ func listIssues(wm *manager.WorktreeManager, registry *provider.Registry) error
 		if !found {
 			return fmt.Errorf("provider '%s' not found or not configured", issueProvider)
 		}
-	} else if wm.Config.DefaultProvider != "" {
+	} else if defaultProvider := wm.Config.GetDefaultProvider(); defaultProvider != "" {
 		// Use default provider from config if no provider flag specified
-		if p, err := registry.Get(wm.Config.DefaultProvider); err == nil && p.IsConfigured() {
-			providersToQuery = []string{wm.Config.DefaultProvider}
+		if p, err := registry.Get(defaultProvider); err == nil && p.IsConfigured() {
+			providersToQuery = []string{defaultProvider}
 		} else {
 			// Fall back to all configured providers if default is not available
 			providersToQuery = registry.ListConfigured()
@@ -259,61 +401,90 @@ func listIssues(wm *manager.WorktreeManager, registry *provider.Registry) error
 		providersToQuery = registry.ListConfigured()
 	}
 
-	// Collect issues from all providers
-	allIssues := make([]provider.Issue, 0)
-	for _, providerName := range providersToQuery {
-		p, err := registry.Get(providerName)
-		if err != nil {
-			continue
-		}
-
-		issueList, err := p.ListIssues(filter)
-		if err != nil {
-			if verbose {
-				fmt.Printf("Warning: Failed to fetch issues from %s: %v\n", providerName, err)
-			}
-			continue
-		}
-
-		allIssues = append(allIssues, issueList.Issues...)
-	}
+	// Collect issues from all providers, noting any that fail
+	sort.Strings(providersToQuery)
+	allIssues, results := registry.ListIssuesFrom(providersToQuery, filter)
+	failed := failedProviders(results)
 
 	// Display issues
 	if len(allIssues) == 0 {
-		fmt.Println("No issues found matching the criteria.")
-		return nil
+		if len(failed) == 0 {
+			fmt.Println("No issues found matching the criteria.")
+		}
+	} else {
+		displayIssueList(allIssues)
 	}
 
-	displayIssueList(allIssues)
+	if len(failed) > 0 {
+		if verbose {
+			printProviderResults(results)
+		} else {
+			printProviderFailures(results, len(allIssues) > 0)
+		}
+		incomplete := ""
+		if len(allIssues) > 0 {
+			incomplete = "; the list above is incomplete"
+		}
+		return fmt.Errorf("failed to fetch issues from %s%s\n\nTo fix this:\n  • Check the provider's credentials and network access\n  • Query the working providers only with --provider", strings.Join(failed, ", "), incomplete)
+	}
+	if verbose && len(results) > 1 {
+		printProviderResults(results)
+	}
 	return nil
 }
 
-func displayIssueList(issues []provider.Issue) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "PROVIDER\tID\tTITLE\tSTATUS\tTYPE")
-	fmt.Fprintln(w, "--------\t--\t-----\t------\t----")
+// failedProviders returns the names of the providers that could not be listed
+func failedProviders(results []provider.ProviderResult) []string {
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Provider)
+		}
+	}
+	return failed
+}
 
-	for _, issue := range issues {
-		// Truncate title if too long
-		title := issue.Title
-		if len(title) > 50 {
-			title = title[:47] + "..."
+// printProviderResults reports to stderr which providers succeeded and which failed
+func printProviderResults(results []provider.ProviderResult) {
+	fmt.Fprintf(os.Stderr, "\n📡 Providers queried:\n")
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(os.Stderr, "   ❌ %s: %v\n", result.Provider, result.Err)
+		} else {
+			fmt.Fprintf(os.Stderr, "   ✅ %s: %d issue(s)\n", result.Provider, result.Count)
 		}
+	}
+}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-			issue.Provider,
-			issue.ID,
-			title,
-			issue.Status,
-			issue.Type,
-		)
+// printProviderFailures prints the first line of each failed provider's error to
+// stderr. partial reports whether issues from the other providers were shown.
+func printProviderFailures(results []provider.ProviderResult, partial bool) {
+	note := ""
+	if partial {
+		note = " (results may be incomplete)"
 	}
+	fmt.Fprintln(os.Stderr)
+	for _, result := range results {
+		if result.Err != nil {
+			message, _, _ := strings.Cut(result.Err.Error(), "\n")
+			fmt.Fprintf(os.Stderr, "⚠️  %s: %s%s\n", result.Provider, message, note)
+		}
+	}
+}
 
-	w.Flush()
+func displayIssueList(issues []provider.Issue) {
+	tbl := table.New("PROVIDER", "ID", "TITLE", "STATUS", "TYPE")
+	tbl.SetMaxWidth(2, 50)
+	for _, issue := range issues {
+		tbl.AddRow(issue.Provider, issue.ID, issue.Title, issue.Status, issue.Type)
+	}
+	if err := tbl.Render(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
+	}
 
-	fmt.Printf("\n📋 Total issues: %d\n", len(issues))
-	fmt.Println("\nTo view issue details: workie issues <provider>:<id>")
-	fmt.Println("To create worktree:   workie issues <provider>:<id> --create")
+	infof("\n📋 Total issues: %d\n", len(issues))
+	infof("\nTo view issue details: workie issues <provider>:<id>\n")
+	infof("To create worktree:   workie issues <provider>:<id> --create\n")
 }
 
 func displayIssueDetails(issue *provider.Issue) {
@@ -334,6 +505,13 @@ func displayIssueDetails(issue *provider.Issue) {
 		fmt.Printf("Assignee:    %s\n", issue.Metadata["assignee"])
 	}
 
+	if issue.Metadata["head_ref"] != "" {
+		fmt.Printf("Branch:      %s → %s\n", issue.Metadata["head_ref"], issue.Metadata["base_ref"])
+	}
+	if issue.Metadata["from_fork"] == "true" && issue.Metadata["head_repo"] != "" {
+		fmt.Printf("Fork:        %s\n", issue.Metadata["head_repo"])
+	}
+
 	if issue.Metadata["created_at"] != "" {
 		fmt.Printf("Created:     %s\n", issue.Metadata["created_at"])
 	}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agoodway/workie/manager"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	listJSON   bool   // Output worktrees as JSON
+	listSizes  bool   // Show the disk usage of each worktree instead
+	listFormat string // Output format: table, json or tsv
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List existing worktrees",
+	Long: `List shows all worktrees of the current repository.
+
+With --json the worktrees are printed as a JSON array with the path, branch,
+commit, whether the entry is the main repository (is_main) and whether it has
+uncommitted changes (dirty), for use in scripts, CI and editor plugins.
+Worktrees begun from an issue with worktree_metadata enabled also show the
+issue, in an ISSUE column or as an issue object in the JSON.
+
+With --sizes the disk usage of each linked worktree is shown instead, largest
+first, with the total. Use it to decide which worktrees to finish or prune to
+reclaim space. Combined with --json each entry has path, branch and bytes.
+
+With --format tsv each worktree is printed as branch, path and commit separated
+by tabs, without headers or colors, for fuzzy finders such as fzf:
+
+  cd "$(workie list --format tsv | fzf | cut -f2)"`,
+	Example: `  # List worktrees
+  workie list
+
+  # Machine-readable output
+  workie list --json
+
+  # Branches with uncommitted changes
+  workie list --json | jq -r '.[] | select(.dirty) | .branch'
+
+  # Find the worktrees taking the most space
+  workie list --sizes
+
+  # Pick a worktree with fzf and change into it
+  cd "$(workie list --format tsv | fzf | cut -f2)"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := manager.Options{
+			ConfigFile: configFile,
+			Verbose:    verbose,
+			Quiet:      quiet,
+		}
+		wm := manager.NewWithOptions(opts)
+
+		if err := wm.DetectGitRepository(); err != nil {
+			return err
+		}
+
+		return listWorktrees(wm, listFormat, listJSON, listSizes)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+
+	// Add flags
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "Output worktrees as JSON (same as --format json)")
+	listCmd.Flags().StringVar(&listFormat, "format", "", "Output format: table, json or tsv (branch, path and commit separated by tabs)")
+	listCmd.Flags().BoolVar(&listSizes, "sizes", false, "Show the disk usage of each worktree, largest first")
+}
+
+// listWorktrees prints worktrees, or their disk usage, in the given format.
+// --json is shorthand for --format json.
+func listWorktrees(wm *manager.WorktreeManager, format string, asJSON, sizes bool) error {
+	if asJSON {
+		if format != "" && format != "json" {
+			return fmt.Errorf("--json conflicts with --format %s", format)
+		}
+		format = "json"
+	}
+
+	switch format {
+	case "", "table", "json", "tsv":
+	default:
+		return fmt.Errorf("unknown list format '%s'\n\nTo fix this:\n  • Use one of: table, json, tsv", format)
+	}
+
+	if sizes {
+		switch format {
+		case "json":
+			return wm.WriteWorktreeSizesJSON(os.Stdout)
+		case "tsv":
+			return fmt.Errorf("--format tsv cannot be combined with --sizes\n\nTo fix this:\n  • Use --sizes --json for machine-readable disk usage")
+		}
+		return wm.ListWorktreeSizes()
+	}
+
+	switch format {
+	case "json":
+		return wm.WriteWorktreesJSON(os.Stdout)
+	case "tsv":
+		return wm.WriteWorktreesTSV(os.Stdout)
+	}
+	return wm.ListWorktrees()
+}
@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/agoodway/workie/manager"
+	"github.com/agoodway/workie/provider"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	prBase      string   // Branch the pull request targets
+	prTitle     string   // Pull request title override
+	prProvider  string   // Provider used to open the pull request
+	prDraft     bool     // Open the pull request as a draft
+	prReviewers []string // Reviewers to request
+	prOpen      bool     // Open the created pull request in a browser
+)
+
+// prCmd represents the pr command
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Push the current branch and open a pull request",
+	Long: `PR pushes the current branch and opens a pull request against the main branch.
+
+This command will:
+1. Detect the current branch of the worktree you're in
+2. Push it to origin with upstream tracking (git push -u origin <branch>)
+3. Open a pull request using the configured provider (currently GitHub)
+
+When the branch was created from an issue (e.g. fix/123-login-error), the
+issue is fetched and used to prefill the pull request title and body. If an
+issue_worktree_template was rendered into the worktree, its contents are used
+as the body.
+
+Defaults for --draft, --reviewers and --open can be set in .workie.yaml:
+
+  pr:
+    draft: true
+    reviewers: [alice, my-org/backend-team]
+    open: true`,
+	Example: `  # Open a pull request for the current branch
+  workie pr
+
+  # Target a different base branch
+  workie pr --base develop
+
+  # Override the title
+  workie pr --title "Add dark mode toggle"
+
+  # Open a draft and request reviews from a user and a team
+  workie pr --draft --reviewers alice,my-org/backend-team
+
+  # Open the created pull request in your browser
+  workie pr --open`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Create manager with options
+		opts := manager.Options{
+			ConfigFile: configFile,
+			Verbose:    verbose,
+			Quiet:      quiet,
+		}
+		wm := manager.NewWithOptions(opts)
+
+		if err := wm.DetectGitRepository(); err != nil {
+			return err
+		}
+		if err := wm.LoadConfig(); err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		applyPRDefaults(cmd, wm)
+
+		return openPullRequest(wm)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(prCmd)
+
+	// Add flags
+	prCmd.Flags().StringVar(&prBase, "base", "", "Branch to merge into (default: the repository's main branch)")
+	prCmd.Flags().StringVar(&prTitle, "title", "", "Pull request title (default: linked issue title or last commit subject)")
+	prCmd.Flags().StringVar(&prProvider, "provider", "", "Provider used to open the pull request (default: first configured provider that supports pull requests)")
+	prCmd.Flags().BoolVar(&prDraft, "draft", false, "Open the pull request as a draft (default from pr.draft)")
+	prCmd.Flags().StringSliceVar(&prReviewers, "reviewers", nil, "Comma-separated reviewers to request; use org/team for teams (default from pr.reviewers)")
+	prCmd.Flags().BoolVar(&prOpen, "open", false, "Open the created pull request in a browser (default from pr.open)")
+}
+
+// applyPRDefaults fills in pr options from config for flags not set on the command line
+func applyPRDefaults(cmd *cobra.Command, wm *manager.WorktreeManager) {
+	defaults := wm.Config.PR
+	if defaults == nil {
+		return
+	}
+	if !cmd.Flags().Changed("draft") {
+		prDraft = defaults.Draft
+	}
+	if !cmd.Flags().Changed("reviewers") {
+		prReviewers = defaults.Reviewers
+	}
+	if !cmd.Flags().Changed("open") {
+		prOpen = defaults.Open
+	}
+}
+
+// openPullRequest pushes the current branch and opens a pull request for it
+func openPullRequest(wm *manager.WorktreeManager) error {
+	branchName, err := currentBranch(wm.RepoPath)
+	if err != nil {
+		return err
+	}
+	return createPullRequest(wm, wm.RepoPath, branchName)
+}
+
+// createPullRequest pushes a branch checked out in worktreeDir and opens a pull request for it
+func createPullRequest(wm *manager.WorktreeManager, worktreeDir, branchName string) error {
+	var err error
+	base := prBase
+	if base == "" {
+		if base, err = wm.GetMainBranch(); err != nil {
+			return fmt.Errorf("failed to determine main branch: %w", err)
+		}
+	}
+	if branchName == base {
+		return fmt.Errorf("current branch '%s' is the base branch\n\nTo fix this:\n  • Run workie pr from a worktree created with 'workie begin'\n  • Or target a different branch with --base", branchName)
+	}
+
+	registry := provider.NewRegistry()
+	if err := initializeBeginProviders(wm, registry); err != nil {
+		return fmt.Errorf("failed to initialize providers: %w", err)
+	}
+
+	creator, creatorName, err := pullRequestProvider(wm, registry)
+	if err != nil {
+		return err
+	}
+
+	if err := pushBranch(wm, worktreeDir, branchName); err != nil {
+		return err
+	}
+
+	pr := provider.PullRequest{
+		Title:     prTitle,
+		Head:      branchName,
+		Base:      base,
+		Draft:     prDraft,
+		Reviewers: prReviewers,
+	}
+
+	// Prefill from the linked issue when the branch name contains an issue ID
+	issue := findLinkedIssue(wm, registry, creatorName, provider.IssueIDFromBranch(branchName))
+	if issue != nil {
+		if !wm.Options.Quiet {
+			fmt.Printf("📋 Linked issue: %s:%s %s\n", issue.Provider, issue.ID, issue.Title)
+		}
+		if pr.Title == "" {
+			pr.Title = issue.Title
+		}
+		pr.Body = pullRequestBody(issue, creatorName)
+	}
+
+	// A rendered issue template in the worktree makes a better body than the default
+	if wm.Config.IssueTemplate != "" {
+		if content, err := os.ReadFile(filepath.Join(worktreeDir, wm.Config.GetIssueTemplatePath())); err == nil {
+			pr.Body = string(content)
+		}
+	}
+
+	if pr.Title == "" {
+		pr.Title = lastCommitSubject(worktreeDir, branchName)
+	}
+
+	if !wm.Options.Quiet {
+		fmt.Printf("🔀 Opening pull request %s → %s on %s...\n", branchName, base, creatorName)
+	}
+
+	info, err := creator.CreatePullRequest(pr)
+	if err != nil {
+		if info == nil {
+			return fmt.Errorf("failed to create pull request: %w", err)
+		}
+		// The pull request was created, but a follow-up step (e.g. reviewers) failed
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
+	}
+
+	// Always show the URL, even in quiet mode (essential info)
+	if wm.Options.Quiet {
+		fmt.Println(info.URL)
+	} else {
+		kind := "pull request"
+		if pr.Draft {
+			kind = "draft pull request"
+		}
+		fmt.Printf("✅ Created %s #%s: %s\n", kind, info.ID, info.URL)
+	}
+
+	if prOpen {
+		if err := openBrowser(info.URL); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Could not open browser: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// openBrowser opens a URL in the user's default browser
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// currentBranch returns the branch checked out in the given worktree
+func currentBranch(dir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = dir
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine current branch: %w", err)
+	}
+
+	branch := strings.TrimSpace(string(output))
+	if branch == "HEAD" {
+		return "", fmt.Errorf("HEAD is detached\n\nTo fix this:\n  • Check out the branch you want to open a pull request for")
+	}
+	return branch, nil
+}
+
+// pullRequestProvider selects the provider used to open the pull request
+func pullRequestProvider(wm *manager.WorktreeManager, registry *provider.Registry) (provider.PullRequestCreator, string, error) {
+	if prProvider != "" {
+		p, err := registry.Get(prProvider)
+		if err != nil {
+			return nil, "", fmt.Errorf("provider '%s' not found or not configured", prProvider)
+		}
+		creator, ok := p.(provider.PullRequestCreator)
+		if !ok {
+			return nil, "", fmt.Errorf("provider '%s' does not support creating pull requests", prProvider)
+		}
+		return creator, prProvider, nil
+	}
+
+	// Prefer the default provider, then any other configured provider
+	names := registry.ListConfigured()
+	sort.Strings(names)
+	if wm.Config.GetDefaultProvider() != "" {
+		names = append([]string{wm.Config.GetDefaultProvider()}, names...)
+	}
+
+	for _, name := range names {
+		p, err := registry.Get(name)
+		if err != nil {
+			continue
+		}
+		if creator, ok := p.(provider.PullRequestCreator); ok {
+			return creator, name, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("no configured provider supports creating pull requests\n\nTo fix this:\n  • Configure the github provider in your .workie.yaml file\n  • Ensure its token_env, owner and repo settings are set")
+}
+
+// pushBranch pushes the branch to origin and sets up upstream tracking
+func pushBranch(wm *manager.WorktreeManager, dir, branchName string) error {
+	if !wm.Options.Quiet {
+		fmt.Printf("⬆️  Pushing %s to origin...\n", branchName)
+	}
+	if wm.Options.Verbose {
+		fmt.Printf("Executing: git push -u origin %s\n", branchName)
+	}
+
+	cmd := exec.Command("git", "push", "-u", "origin", branchName)
+	cmd.Dir = dir
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git push failed\n\nError details: %s\n\nTo fix this:\n  • Check that the origin remote exists: git remote -v\n  • Verify you have push access to the repository\n  • Pull and rebase if the remote branch has diverged", strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// findLinkedIssue fetches the issue referenced by the branch name, trying the pull
+// request provider first and then the other configured providers
+func findLinkedIssue(wm *manager.WorktreeManager, registry *provider.Registry, preferred, issueID string) *provider.Issue {
+	if issueID == "" {
+		return nil
+	}
+
+	names := registry.ListConfigured()
+	sort.Strings(names)
+	names = append([]string{preferred, wm.Config.GetDefaultProvider()}, names...)
+
+	tried := make(map[string]bool)
+	for _, name := range names {
+		if name == "" || tried[name] {
+			continue
+		}
+		tried[name] = true
+
+		p, err := registry.Get(name)
+		if err != nil {
+			continue
+		}
+		issue, err := p.GetIssue(issueID)
+		if err != nil {
+			if wm.Options.Verbose {
+				fmt.Fprintf(os.Stderr, "Issue %s not found in %s: %v\n", issueID, name, err)
+			}
+			continue
+		}
+		return issue
+	}
+
+	return nil
+}
+
+// pullRequestBody builds a default pull request body that links the issue
+func pullRequestBody(issue *provider.Issue, prProviderName string) string {
+	if issue.Provider == prProviderName {
+		// Same tracker, so the reference auto-closes the issue on merge
+		return fmt.Sprintf("Closes #%s", issue.ID)
+	}
+	return fmt.Sprintf("Resolves [%s](%s): %s", issue.ID, issue.URL, issue.Title)
+}
+
+// lastCommitSubject returns the subject of the latest commit on the branch,
+// falling back to the branch name
+func lastCommitSubject(dir, branchName string) string {
+	cmd := exec.Command("git", "log", "-1", "--format=%s", branchName)
+	cmd.Dir = dir
+
+	output, err := cmd.Output()
+	if subject := strings.TrimSpace(string(output)); err == nil && subject != "" {
+		return subject
+	}
+	return branchName
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/agoodway/workie/manager"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneDryRun bool // List what would be pruned without changing anything
+	pruneYes    bool // Remove orphaned directories without asking
+)
+
+// pruneCmd represents the prune command
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Clean up stale and orphaned worktrees",
+	Long: `Prune cleans up worktrees that are no longer usable:
+
+1. Worktrees whose directory was deleted by hand are still registered with
+   git (git worktree list marks them prunable). Their records are removed
+   with git worktree prune.
+2. Directories in the worktrees directory that don't belong to any registered
+   worktree are offered for deletion. This asks for confirmation unless --yes
+   is given.
+
+Use --dry-run to list what would be pruned without changing anything.`,
+	Example: `  # Review and clean up stale worktrees
+  workie prune
+
+  # Show what would be pruned
+  workie prune --dry-run
+
+  # Prune without asking, e.g. from a script
+  workie prune --yes`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := manager.Options{
+			ConfigFile: configFile,
+			Verbose:    verbose,
+			Quiet:      quiet,
+		}
+		wm := manager.NewWithOptions(opts)
+
+		if err := wm.DetectGitRepository(); err != nil {
+			return err
+		}
+
+		stale, err := wm.FindStaleWorktrees()
+		if err != nil {
+			return fmt.Errorf("cannot find stale worktrees: %w\n\nTo fix this:\n  • Ensure you're in a valid git repository\n  • Verify repository status: git status", err)
+		}
+
+		if stale.Count() == 0 {
+			infof("✅ Nothing to prune\n")
+			return nil
+		}
+
+		printStaleWorktrees(stale)
+
+		if pruneDryRun {
+			infof("\n🔍 Dry run: would prune %d worktree record(s) and delete %d orphaned directories\n", len(stale.Prunable), len(stale.Orphaned))
+			return nil
+		}
+
+		removeOrphans := len(stale.Orphaned) > 0
+		if removeOrphans && !pruneYes {
+			removeOrphans = confirm(fmt.Sprintf("Delete the %d unregistered directories?", len(stale.Orphaned)))
+		}
+
+		summary, err := wm.PruneWorktrees(stale, removeOrphans)
+		if err != nil {
+			return err
+		}
+
+		printPruneSummary(summary, len(stale.Orphaned))
+		if summary.Failed > 0 {
+			return fmt.Errorf("failed to delete %d orphaned directories; see the warnings above", summary.Failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+
+	// Add flags
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "List what would be pruned without changing anything")
+	pruneCmd.Flags().BoolVarP(&pruneYes, "yes", "y", false, "Delete orphaned directories without asking")
+}
+
+// printStaleWorktrees lists stale worktrees. In quiet mode only their paths are printed.
+func printStaleWorktrees(stale *manager.StaleWorktrees) {
+	if quiet {
+		for _, wt := range stale.Prunable {
+			fmt.Println(wt.Path)
+		}
+		for _, dir := range stale.Orphaned {
+			fmt.Println(dir)
+		}
+		return
+	}
+
+	if len(stale.Prunable) > 0 {
+		fmt.Printf("🔍 Worktrees whose directory is missing:\n")
+		for _, wt := range stale.Prunable {
+			if wt.Branch != "" {
+				fmt.Printf("   • %s (%s)\n", wt.Path, wt.Branch)
+			} else {
+				fmt.Printf("   • %s\n", wt.Path)
+			}
+		}
+	}
+	if len(stale.Orphaned) > 0 {
+		fmt.Printf("📂 Directories not registered as worktrees:\n")
+		for _, dir := range stale.Orphaned {
+			fmt.Printf("   • %s\n", dir)
+		}
+	}
+}
+
+// printPruneSummary reports how many worktrees were pruned
+func printPruneSummary(summary manager.PruneSummary, orphaned int) {
+	infof("\n📊 Prune Summary:\n")
+	infof("   • Stale worktree records pruned: %d\n", summary.Pruned)
+	infof("   • Orphaned directories deleted: %d\n", summary.Removed)
+	if skipped := orphaned - summary.Removed - summary.Failed; skipped > 0 {
+		infof("   • Orphaned directories kept: %d\n", skipped)
+	}
+	if summary.Failed > 0 {
+		infof("   • Failed: %d (❌)\n", summary.Failed)
+	}
+}
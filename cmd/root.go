@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/agoodway/workie/errcode"
 	"github.com/agoodway/workie/manager"
 
 	"github.com/spf13/cobra"
@@ -24,6 +27,7 @@ var (
 	verbose     bool
 	quiet       bool
 	versionFlag bool
+	jsonErrors  bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -60,6 +64,7 @@ Configuration example:
 
   # List all active development environments
   workie --list
+  workie list --json
 
   # Finish working on a branch
   workie finish feature/completed-feature
@@ -74,28 +79,24 @@ Configuration example:
   # Debug environment setup with detailed output
   workie begin feature/complex-setup --verbose`,
 	Args: cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Validate conflicting flags
+		if verbose && quiet {
+			return errcode.Errorf(errcode.Usage, "cannot use both --verbose and --quiet flags together\n\nUsage tips:\n  • Use --verbose for detailed output\n  • Use --quiet for minimal output\n  • Use neither for normal output")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Handle version flag
 		if versionFlag {
 			printVersion()
-			return
-		}
-
-		// Validate conflicting flags
-		if verbose && quiet {
-			fmt.Fprintf(os.Stderr, "❌ Error: cannot use both --verbose and --quiet flags together\n")
-			fmt.Fprintf(os.Stderr, "\nUsage tips:\n")
-			fmt.Fprintf(os.Stderr, "  • Use --verbose for detailed output\n")
-			fmt.Fprintf(os.Stderr, "  • Use --quiet for minimal output\n")
-			fmt.Fprintf(os.Stderr, "  • Use neither for normal output\n")
-			os.Exit(1)
+			return nil
 		}
 
 		// Validate custom config file exists if specified
 		if configFile != "" {
 			if err := validateConfigFile(configFile); err != nil {
-				fmt.Fprintf(os.Stderr, "❌ Configuration file error: %v\n", err)
-				os.Exit(1)
+				return fmt.Errorf("configuration file error: %w", err)
 			}
 		}
 
@@ -110,32 +111,54 @@ Configuration example:
 		// Handle list flag
 		if listFlag {
 			if err := wm.DetectGitRepository(); err != nil {
-				fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			if err := wm.ListWorktrees(); err != nil {
-				fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-				os.Exit(1)
-			}
-			return
+			return listWorktrees(wm, listFormat, listJSON, listSizes)
 		}
 
 		// If no arguments and no flags, show help
-		if err := cmd.Help(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error displaying help: %v\n", err)
-		}
+		return cmd.Help()
 	},
+	// Errors are printed once by Execute, on stderr, without the usage text
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// Errors from any command are printed to stderr and exit with status 1, so stdout
+// only ever carries a command's results. With --json-errors the error is printed
+// as a JSON object for tools instead.
 func Execute() {
-	err := rootCmd.Execute()
-	if err != nil {
+	if err := rootCmd.Execute(); err != nil {
+		// A flag error can stop parsing before --json-errors is reached
+		if jsonErrors || slices.Contains(os.Args[1:], "--json-errors") {
+			printJSONError(err)
+		} else {
+			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+		}
 		os.Exit(1)
 	}
 }
 
+// printJSONError prints err to stderr as {"code", "message", "hint"}
+func printJSONError(err error) {
+	data, marshalErr := json.Marshal(errcode.NewReport(err))
+	if marshalErr != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, string(data))
+}
+
+// infof prints progress output that --quiet suppresses. Results and errors
+// must not go through it.
+func infof(format string, a ...interface{}) {
+	if !quiet {
+		fmt.Printf(format, a...)
+	}
+}
+
 // validateConfigFile performs early validation of the config file path
 // to provide better error messages before attempting to create worktrees
 func validateConfigFile(configPath string) error {
@@ -149,33 +172,33 @@ func validateConfigFile(configPath string) error {
 		if os.IsNotExist(err) {
 			// Try to provide helpful suggestions
 			absPath, _ := filepath.Abs(configPath)
-			return fmt.Errorf("config file not found: %s\n\nResolved to: %s\n\nTo fix this:\n  • Check that the file path is correct\n  • Ensure the file exists\n  • Use a relative path from the current directory\n  • Use an absolute path if needed", configPath, absPath)
+			return errcode.Errorf(errcode.ConfigNotFound, "config file not found: %s\n\nResolved to: %s\n\nTo fix this:\n  • Check that the file path is correct\n  • Ensure the file exists\n  • Use a relative path from the current directory\n  • Use an absolute path if needed", configPath, absPath)
 		}
 		if os.IsPermission(err) {
-			return fmt.Errorf("permission denied accessing config file: %s\n\nTo fix this:\n  • Check file permissions\n  • Ensure you have read access to the file\n  • Consider using a different config file location", configPath)
+			return errcode.Errorf(errcode.ConfigInvalid, "permission denied accessing config file: %s\n\nTo fix this:\n  • Check file permissions\n  • Ensure you have read access to the file\n  • Consider using a different config file location", configPath)
 		}
-		return fmt.Errorf("cannot access config file %s: %w\n\nTo fix this:\n  • Verify the file path is correct\n  • Check file permissions\n  • Ensure the file system is accessible", configPath, err)
+		return errcode.Errorf(errcode.ConfigInvalid, "cannot access config file %s: %w\n\nTo fix this:\n  • Verify the file path is correct\n  • Check file permissions\n  • Ensure the file system is accessible", configPath, err)
 	}
 
 	// Check if it's a file, not a directory
 	if info.IsDir() {
-		return fmt.Errorf("config path is a directory, not a file: %s\n\nTo fix this:\n  • Specify a file path, not a directory\n  • Use a .yaml or .yml file\n  • Example: --config path/to/config.yaml", configPath)
+		return errcode.Errorf(errcode.ConfigInvalid, "config path is a directory, not a file: %s\n\nTo fix this:\n  • Specify a file path, not a directory\n  • Use a .yaml or .yml file\n  • Example: --config path/to/config.yaml", configPath)
 	}
 
 	// Check file extension
 	ext := strings.ToLower(filepath.Ext(configPath))
 	if ext != ".yaml" && ext != ".yml" {
-		return fmt.Errorf("config file should have .yaml or .yml extension: %s\n\nCurrent extension: %s\n\nTo fix this:\n  • Rename the file to have .yaml or .yml extension\n  • Ensure the file contains valid YAML content", configPath, ext)
+		return errcode.Errorf(errcode.ConfigInvalid, "config file should have .yaml or .yml extension: %s\n\nCurrent extension: %s\n\nTo fix this:\n  • Rename the file to have .yaml or .yml extension\n  • Ensure the file contains valid YAML content", configPath, ext)
 	}
 
 	// Check file size - warn if suspiciously large
 	if info.Size() > 1024*1024 {
-		return fmt.Errorf("config file is unusually large (%d bytes): %s\n\nTo fix this:\n  • Verify this is the correct config file\n  • Config files should typically be small\n  • Check for accidental binary content", info.Size(), configPath)
+		return errcode.Errorf(errcode.ConfigInvalid, "config file is unusually large (%d bytes): %s\n\nTo fix this:\n  • Verify this is the correct config file\n  • Config files should typically be small\n  • Check for accidental binary content", info.Size(), configPath)
 	}
 
 	// Check if file is empty
 	if info.Size() == 0 {
-		return fmt.Errorf("config file is empty: %s\n\nTo fix this:\n  • Add configuration content to the file\n  • Example content:\n    files_to_copy:\n      - .env.example\n      - config/\n  • Or remove the --config flag to use defaults", configPath)
+		return errcode.Errorf(errcode.ConfigInvalid, "config file is empty: %s\n\nTo fix this:\n  • Add configuration content to the file\n  • Example content:\n    files_to_copy:\n      - .env.example\n      - config/\n  • Or remove the --config flag to use defaults", configPath)
 	}
 
 	return nil
@@ -197,12 +220,21 @@ func printVersion() {
 }
 
 func init() {
+	// Usage is silenced for every command, so point flag mistakes at --help instead
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return errcode.Errorf(errcode.Usage, "%w\n\nRun '%s --help' for usage", err, cmd.CommandPath())
+	})
+
 	// Add flags
 	rootCmd.Flags().BoolVar(&versionFlag, "version", false, "Show version information and exit")
 	rootCmd.Flags().BoolVarP(&listFlag, "list", "l", false, "List existing worktrees and exit")
+	rootCmd.Flags().BoolVar(&listJSON, "json", false, "With --list, output worktrees as JSON")
+	rootCmd.Flags().BoolVar(&listSizes, "sizes", false, "With --list, show the disk usage of each worktree")
+	rootCmd.Flags().StringVar(&listFormat, "format", "", "With --list, output format: table, json or tsv")
 	rootCmd.Flags().StringVarP(&configFile, "config", "c", "", "Path to custom configuration file (default: .workie.yaml or workie.yaml)")
-	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output with detailed information")
-	rootCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Enable quiet mode with minimal output")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output with detailed information")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only print results (paths, JSON, tables) and errors")
+	rootCmd.PersistentFlags().BoolVar(&jsonErrors, "json-errors", false, "Print errors to stderr as JSON objects with code, message and hint, for tools")
 
 	// Mark config flag as accepting a filename
 	if err := rootCmd.MarkFlagFilename("config", "yaml", "yml"); err != nil {
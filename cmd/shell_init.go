@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cdMode bool     // Print only the worktree path on stdout, for the shell-init function
+	cdOut  *os.File // Where the path goes in --cd mode: the original stdout
+	shells = []string{"bash", "zsh", "fish"}
+)
+
+// shellInitCmd represents the shell-init command
+var shellInitCmd = &cobra.Command{
+	Use:   "shell-init [bash|zsh|fish]",
+	Short: "Print a shell function that cds into worktrees after begin and switch",
+	Long: `Shell-init prints a shell function named workie that wraps the workie binary.
+A program can't change the directory of the shell that started it, so the
+function runs 'workie begin' and 'workie switch' with --cd and changes into the
+worktree they print. Every other command runs unchanged.
+
+Load it from your shell's startup file:
+
+  # ~/.bashrc or ~/.zshrc
+  eval "$(workie shell-init bash)"   # or zsh
+
+  # ~/.config/fish/config.fish
+  workie shell-init fish | source
+
+Without an argument the shell is taken from $SHELL.
+
+With --cd, begin and switch print their usual output to stderr and only the
+worktree path to stdout, which is what the function reads.`,
+	Example: `  # Set up bash, then begin work and land in the new worktree
+  eval "$(workie shell-init bash)"
+  workie begin feature/user-auth
+
+  # Jump to another worktree
+  workie switch feature/other
+
+  # See the function before installing it
+  workie shell-init zsh`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: shells,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shell := filepath.Base(os.Getenv("SHELL"))
+		if len(args) > 0 {
+			shell = args[0]
+		}
+
+		script, err := shellFunction(shell)
+		if err != nil {
+			return err
+		}
+		fmt.Print(script)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(shellInitCmd)
+}
+
+// shellFunction returns the workie wrapper function for a shell
+func shellFunction(shell string) (string, error) {
+	switch shell {
+	case "bash", "zsh":
+		return `workie() {
+  case "$1" in
+    begin|switch)
+      local dir
+      dir="$(command workie "$@" --cd)" || return $?
+      if [ -d "$dir" ]; then
+        cd "$dir"
+      elif [ -n "$dir" ]; then
+        printf '%s\n' "$dir"
+      fi
+      ;;
+    *)
+      command workie "$@"
+      ;;
+  esac
+}
+`, nil
+	case "fish":
+		return `function workie
+    switch "$argv[1]"
+        case begin switch
+            set -l dir (command workie $argv --cd)
+            or return $status
+            if test -d "$dir"
+                cd $dir
+            else if test -n "$dir"
+                printf '%s\n' $dir
+            end
+        case '*'
+            command workie $argv
+    end
+end
+`, nil
+	case "", ".":
+		return "", fmt.Errorf("cannot detect your shell\n\nTo fix this:\n  • Name it: workie shell-init bash (or zsh, fish)")
+	}
+	return "", fmt.Errorf("unsupported shell '%s'\n\nTo fix this:\n  • Use one of: bash, zsh, fish", shell)
+}
+
+// startCdMode sends everything a command prints to stderr when --cd is set,
+// keeping stdout for the worktree path passed to printCdPath
+func startCdMode() {
+	if !cdMode {
+		return
+	}
+	cdOut = os.Stdout
+	os.Stdout = os.Stderr
+}
+
+// printCdPath prints the worktree path on the original stdout in --cd mode
+func printCdPath(path string) {
+	if cdMode {
+		fmt.Fprintln(cdOut, path)
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/agoodway/workie/manager"
+
+	"github.com/spf13/cobra"
+)
+
+var statusJSON bool // Output the status as JSON
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show uncommitted changes and ahead/behind counts of every worktree",
+	Long: `Status gives a one-shot overview of all worktrees: their uncommitted changes
+(staged, modified, untracked and conflicted files) and how many commits they
+are ahead of and behind their upstream branch.
+
+The main repository is listed first and marked "(main repo)". Branches without an
+upstream show "-", and worktrees whose directory is gone show "missing".
+
+With --json each worktree is printed with its path, branch, is_main, dirty,
+the change counts, upstream, ahead and behind, for use in scripts.`,
+	Example: `  # Overview of all worktrees
+  workie status
+
+  # Branches with unpushed commits
+  workie status --json | jq -r '.[] | select(.ahead > 0) | .branch'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := manager.Options{
+			ConfigFile: configFile,
+			Verbose:    verbose,
+			Quiet:      quiet,
+		}
+		wm := manager.NewWithOptions(opts)
+
+		if err := wm.DetectGitRepository(); err != nil {
+			return err
+		}
+
+		if statusJSON {
+			return wm.WriteWorktreeGitStatusesJSON(os.Stdout)
+		}
+		return wm.ShowStatus()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	// Add flags
+	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "Output the status of each worktree as JSON")
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agoodway/workie/manager"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	switchPrintCd bool // Print a cd command instead of the bare path
+)
+
+// switchCmd represents the switch command
+var switchCmd = &cobra.Command{
+	Use:   "switch <branch-name>",
+	Short: "Print the path of a branch's worktree for cd",
+	Long: `Switch prints the path of the worktree that has the given branch checked out.
+
+A program can't change the directory of the shell that started it, so use the
+output with cd:
+
+  cd "$(workie switch feature/x)"
+
+With --print-cd a complete, shell-quoted cd command is printed instead, for use
+with eval or in a shell function:
+
+  ws() { eval "$(workie switch --print-cd "$1")"; }
+
+To have 'workie switch' and 'workie begin' change directory by themselves,
+install the shell function from 'workie shell-init'.`,
+	Example: `  # Change into the worktree for a branch
+  cd "$(workie switch feature/new-ui)"
+
+  # Go back to the main repository
+  cd "$(workie switch main)"
+
+  # Emit a cd command for eval
+  eval "$(workie switch --print-cd feature/new-ui)"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		startCdMode()
+
+		// Create manager with options
+		opts := manager.Options{
+			ConfigFile: configFile,
+			Verbose:    verbose,
+			Quiet:      quiet,
+		}
+		wm := manager.NewWithOptions(opts)
+
+		if err := wm.DetectGitRepository(); err != nil {
+			return err
+		}
+
+		path, err := wm.FindWorktree(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Only the path goes to stdout, even without --quiet, so it can be captured
+		if cdMode {
+			printCdPath(path)
+		} else if switchPrintCd {
+			fmt.Printf("cd %s\n", shellQuote(path))
+		} else {
+			fmt.Println(path)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(switchCmd)
+
+	// Add flags
+	switchCmd.Flags().BoolVar(&switchPrintCd, "print-cd", false, "Print a shell-quoted cd command for eval instead of the bare path")
+	switchCmd.Flags().BoolVar(&cdMode, "cd", false, "Print only the path on stdout and everything else on stderr (used by shell-init)")
+}
+
+// shellQuote quotes s for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/agoodway/workie/tools"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	toolsJSON bool // Output tools as JSON
+)
+
+// toolsCmd represents the tools command
+var toolsCmd = &cobra.Command{
+	Use:   "tools",
+	Short: "Inspect the tools available to the AI agent",
+	Long: `Tools shows the tools the AI agent used by 'workie ask' can call, with their
+parameters, so you can phrase questions the agent can answer and configure
+per-tool limits (tools.agent.tool_call_limits).`,
+}
+
+// toolsListCmd represents the tools list command
+var toolsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the agent's tools and their parameters",
+	Long: `List prints every tool in the agent's default registry with its description
+and parameters. Required parameters are marked, and parameters with a fixed set
+of values list them.
+
+With --json the tools are printed as a JSON array of objects with the name,
+description and JSON schema of the parameters.`,
+	Example: `  # Show the agent's tools
+  workie tools list
+
+  # Parameter schemas for scripts
+  workie tools list --json | jq '.[].name'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		registry := tools.DefaultRegistry("")
+		if toolsJSON {
+			return writeToolsJSON(registry.List())
+		}
+		printTools(registry.List())
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(toolsCmd)
+	toolsCmd.AddCommand(toolsListCmd)
+
+	// Add flags
+	toolsListCmd.Flags().BoolVar(&toolsJSON, "json", false, "Output tools as JSON")
+}
+
+// toolInfo describes a tool for JSON output
+type toolInfo struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+// writeToolsJSON prints the tools as a JSON array
+func writeToolsJSON(list []tools.Tool) error {
+	infos := make([]toolInfo, 0, len(list))
+	for _, tool := range list {
+		infos = append(infos, toolInfo{
+			Name:        tool.Name(),
+			Description: tool.Description(),
+			Parameters:  tool.Parameters(),
+		})
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(infos)
+}
+
+// printTools prints each tool with a summary of its parameters
+func printTools(list []tools.Tool) {
+	fmt.Printf("🧰 Agent tools (%d):\n", len(list))
+	for _, tool := range list {
+		fmt.Printf("\n%s\n", tool.Name())
+		fmt.Printf("  %s\n", tool.Description())
+
+		schema := tool.Parameters()
+		properties, _ := schema["properties"].(map[string]interface{})
+		if len(properties) == 0 {
+			fmt.Printf("  Parameters: none\n")
+			continue
+		}
+
+		required := make(map[string]bool)
+		if names, ok := schema["required"].([]string); ok {
+			for _, name := range names {
+				required[name] = true
+			}
+		}
+
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Printf("  Parameters:\n")
+		for _, name := range names {
+			prop, _ := properties[name].(map[string]interface{})
+			fmt.Printf("    %s\n", formatToolParameter(name, prop, required[name]))
+		}
+	}
+}
+
+// formatToolParameter renders one parameter as "name (type, required): description [values]"
+func formatToolParameter(name string, prop map[string]interface{}, required bool) string {
+	attrs := []string{}
+	if typ, ok := prop["type"].(string); ok {
+		attrs = append(attrs, typ)
+	}
+	if required {
+		attrs = append(attrs, "required")
+	}
+
+	line := name
+	if len(attrs) > 0 {
+		line += " (" + strings.Join(attrs, ", ") + ")"
+	}
+	if description, ok := prop["description"].(string); ok && description != "" {
+		line += ": " + description
+	}
+	if values, ok := prop["enum"].([]string); ok && len(values) > 0 {
+		line += " [" + strings.Join(values, ", ") + "]"
+	}
+	return line
+}
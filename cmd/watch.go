@@ -3,22 +3,31 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
-	"github.com/agoodway/workie/config"
+	"github.com/agoodway/workie/errcode"
 	"github.com/agoodway/workie/manager"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
 	watchInterval     string
 	watchPort         int
 	watchNotifyMethod string
-	watchQuiet        bool
+	watchDryRun       bool
+	watchStateFile    string
+	watchBind         string
+	watchIncludeMain  bool
+	watchBranches     []string
 )
 
 var watchCmd = &cobra.Command{
@@ -34,9 +43,18 @@ conflicts when rebasing on the main branch. Sends notifications when conflicts a
   
   # Use a custom port
   workie watch --port 8081
+
+  # Accept connections from other machines (configure watch.auth_token_env first)
+  workie watch --bind 0.0.0.0
   
   # Run in quiet mode
-  workie watch --quiet`,
+  workie watch --quiet
+
+  # Print the notifications that would be sent without sending them
+  workie watch --dry-run
+
+  # Only check feature branches, and include main itself
+  workie watch --branches 'feature/*' --include-main`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Parse interval duration
 		interval, err := time.ParseDuration(watchInterval)
@@ -49,16 +67,10 @@ conflicts when rebasing on the main branch. Sends notifications when conflicts a
 			return fmt.Errorf("interval must be at least 1 minute")
 		}
 
-		// Get the repository root
-		repoRoot, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
-		}
-
 		// Create manager with options
 		opts := manager.Options{
-			Quiet:            watchQuiet,
-			ShowInitMessages: !watchQuiet,
+			Quiet:            quiet,
+			ShowInitMessages: !quiet,
 		}
 		wm := manager.NewWithOptions(opts)
 
@@ -67,31 +79,84 @@ conflicts when rebasing on the main branch. Sends notifications when conflicts a
 			return fmt.Errorf("failed to detect git repository: %w", err)
 		}
 
-		// Load configuration
-		cfg, err := config.LoadConfig(repoRoot, "")
-		if err != nil {
-			// Config is optional for watch, so just log if verbose
-			if !watchQuiet {
-				fmt.Printf("⚠️  No configuration file found, using defaults\n")
+		// Load configuration from the repository root; without one the defaults apply
+		if err := wm.LoadConfig(); err != nil {
+			return err
+		}
+
+		// Override with config values if not specified via flags
+		if cfg := wm.Config.Watch; cfg != nil {
+			if !cmd.Flags().Changed("interval") && cfg.IntervalMinutes > 0 {
+				interval = time.Duration(cfg.IntervalMinutes) * time.Minute
+			}
+			if !cmd.Flags().Changed("port") && cfg.Port > 0 {
+				watchPort = cfg.Port
+			}
+			if !cmd.Flags().Changed("bind") && cfg.BindAddress != "" {
+				watchBind = cfg.BindAddress
+			}
+			if !cmd.Flags().Changed("include-main") {
+				watchIncludeMain = cfg.IncludeMain
 			}
-		} else {
-			wm.Config = cfg
+			if !cmd.Flags().Changed("branches") {
+				watchBranches = cfg.Branches
+			}
+		}
+
+		if !slices.Contains(manager.NotifyMethods, watchNotifyMethod) {
+			return errcode.Errorf(errcode.Usage, "invalid notification method '%s'\n\nTo fix this:\n  • Use one of: %s", watchNotifyMethod, strings.Join(manager.NotifyMethods, ", "))
+		}
+		// Webhook delivery is not implemented, so nothing would ever be sent
+		if watchNotifyMethod != "system" {
+			return errcode.Errorf(errcode.Usage, "notification method '%s' is not supported yet: webhook notifications are not implemented\n\nTo fix this:\n  • Use --notify system", watchNotifyMethod)
+		}
 
-			// Override with config values if not specified via flags
-			if cmd.Flags().Lookup("interval").Changed == false && cfg.Watch != nil && cfg.Watch.IntervalMinutes > 0 {
-				interval = time.Duration(cfg.Watch.IntervalMinutes) * time.Minute
+		// Persist state so restarts don't re-notify known conflicts
+		stateFile := watchStateFile
+		cooldown := 60 * time.Minute
+		if wm.Config.Watch != nil {
+			if stateFile == "" {
+				stateFile = wm.Config.Watch.StateFile
 			}
-			if cmd.Flags().Lookup("port").Changed == false && cfg.Watch != nil && cfg.Watch.Port > 0 {
-				watchPort = cfg.Watch.Port
+			if wm.Config.Watch.NotifyCooldownMinutes > 0 {
+				cooldown = time.Duration(wm.Config.Watch.NotifyCooldownMinutes) * time.Minute
 			}
 		}
+		if stateFile == "" {
+			stateFile = wm.DefaultWatchStatePath()
+		} else if !filepath.IsAbs(stateFile) {
+			stateFile = filepath.Join(wm.RepoPath, stateFile)
+		}
+
+		if err := manager.ValidateBindAddress(watchBind); err != nil {
+			return err
+		}
+
+		checks := manager.ConflictCheckOptions{
+			IncludeMain: watchIncludeMain,
+			Branches:    watchBranches,
+		}
+		if err := checks.Validate(); err != nil {
+			return err
+		}
+
+		authToken := wm.Config.Watch.GetAuthToken()
+		if !isLoopback(watchBind) && authToken == "" {
+			fmt.Fprintf(os.Stderr, "%s Listening on %s without authentication; set watch.auth_token_env to require a token\n", color.YellowString("⚠️"), watchBind)
+		}
 
 		// Create watch server
 		server := manager.NewWatchServer(wm, manager.WatchServerOptions{
-			Port:         watchPort,
-			Interval:     interval,
-			NotifyMethod: watchNotifyMethod,
-			Quiet:        watchQuiet,
+			Port:           watchPort,
+			BindAddress:    watchBind,
+			AuthToken:      authToken,
+			Interval:       interval,
+			NotifyMethod:   watchNotifyMethod,
+			Quiet:          quiet,
+			DryRun:         watchDryRun,
+			StatePath:      stateFile,
+			NotifyCooldown: cooldown,
+			Checks:         checks,
 		})
 
 		// Set up graceful shutdown
@@ -103,17 +168,29 @@ conflicts when rebasing on the main branch. Sends notifications when conflicts a
 
 		go func() {
 			<-sigChan
-			if !watchQuiet {
+			if !quiet {
 				fmt.Println("\n📊 Shutting down watch server...")
 			}
 			cancel()
 		}()
 
 		// Start the server
-		if !watchQuiet {
+		if !quiet {
 			fmt.Printf("%s Starting workie watch server...\n", color.GreenString("✓"))
 			fmt.Printf("📊 Monitoring worktrees every %s\n", interval)
-			fmt.Printf("🌐 Server running on http://localhost:%d\n", watchPort)
+			fmt.Printf("🌐 Server running on http://%s\n", server.Addr())
+			for _, endpoint := range manager.WatchEndpoints {
+				fmt.Printf("   %-4s http://%s%-11s %s\n", endpoint.Method, server.Addr(), endpoint.Path, endpoint.Description)
+			}
+			if authToken != "" {
+				fmt.Printf("🔒 API requires Authorization: Bearer <token>\n")
+			}
+			if len(checks.Branches) > 0 {
+				fmt.Printf("🔎 Checking branches matching: %s\n", strings.Join(checks.Branches, ", "))
+			}
+			if watchDryRun {
+				fmt.Printf("🧪 Dry run: notifications will be printed, not sent\n")
+			}
 			fmt.Printf("Press Ctrl+C to stop\n\n")
 		}
 
@@ -131,6 +208,25 @@ func init() {
 	// Add flags
 	watchCmd.Flags().StringVarP(&watchInterval, "interval", "i", "5m", "Check interval (e.g., 5m, 10m, 1h)")
 	watchCmd.Flags().IntVarP(&watchPort, "port", "p", 8080, "Server port")
-	watchCmd.Flags().StringVarP(&watchNotifyMethod, "notify-method", "n", "system", "Notification method: system, webhook, or both")
-	watchCmd.Flags().BoolVarP(&watchQuiet, "quiet", "q", false, "Suppress output except errors")
+	watchCmd.Flags().StringVarP(&watchNotifyMethod, "notify-method", "n", "system", "Notification method: system; webhook and both are not supported yet (also --notify)")
+	watchCmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "notify" {
+			name = "notify-method"
+		}
+		return pflag.NormalizedName(name)
+	})
+	watchCmd.Flags().StringVar(&watchBind, "bind", "127.0.0.1", "Address to listen on, overriding watch.bind_address; use 0.0.0.0 to accept connections from other machines")
+	watchCmd.Flags().StringVar(&watchStateFile, "state-file", "", "File used to persist conflict state between restarts (default: <git dir>/workie/watch-state.json)")
+	watchCmd.Flags().BoolVar(&watchDryRun, "dry-run", false, "Run checks and print the notifications that would be sent without sending them")
+	watchCmd.Flags().BoolVar(&watchIncludeMain, "include-main", false, "Also check whether the main branch would conflict rebasing onto origin (default from watch.include_main)")
+	watchCmd.Flags().StringSliceVar(&watchBranches, "branches", nil, "Comma-separated glob patterns; only matching branches are checked (default from watch.branches)")
+}
+
+// isLoopback reports whether a bind address only accepts local connections
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
@@ -4,8 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 )
@@ -26,27 +31,97 @@ type SystemNotificationConfig struct {
 
 // WatchConfig represents configuration for the watch command
 type WatchConfig struct {
-	Enabled           bool     `yaml:"enabled" mapstructure:"enabled"`                                 // Enable watch functionality
-	IntervalMinutes   int      `yaml:"interval_minutes,omitempty" mapstructure:"interval_minutes"`     // Check interval in minutes (default: 5)
-	NotifyOnConflicts bool     `yaml:"notify_on_conflicts" mapstructure:"notify_on_conflicts"`         // Send notifications for conflicts
-	BranchesToIgnore  []string `yaml:"branches_to_ignore,omitempty" mapstructure:"branches_to_ignore"` // Glob patterns for branches to ignore
-	Port              int      `yaml:"port,omitempty" mapstructure:"port"`                             // HTTP server port (default: 8080)
+	Enabled               bool     `yaml:"enabled" mapstructure:"enabled"`                                           // Enable watch functionality
+	IntervalMinutes       int      `yaml:"interval_minutes,omitempty" mapstructure:"interval_minutes"`               // Check interval in minutes (default: 5)
+	NotifyOnConflicts     bool     `yaml:"notify_on_conflicts" mapstructure:"notify_on_conflicts"`                   // Send notifications for conflicts
+	BranchesToIgnore      []string `yaml:"branches_to_ignore,omitempty" mapstructure:"branches_to_ignore"`           // Glob patterns for branches to ignore
+	Branches              []string `yaml:"branches,omitempty" mapstructure:"branches"`                               // Glob patterns for branches to check (default: all)
+	IncludeMain           bool     `yaml:"include_main,omitempty" mapstructure:"include_main"`                       // Also check whether main has diverged from origin
+	MainBranch            string   `yaml:"main_branch,omitempty" mapstructure:"main_branch"`                         // Branch conflicts are checked against (default: main or master, else origin/HEAD)
+	Port                  int      `yaml:"port,omitempty" mapstructure:"port"`                                       // HTTP server port (default: 8080)
+	BindAddress           string   `yaml:"bind_address,omitempty" mapstructure:"bind_address"`                       // Interface the HTTP server listens on (default: 127.0.0.1)
+	AuthToken             string   `yaml:"auth_token,omitempty" mapstructure:"auth_token"`                           // Bearer token required by the HTTP API (prefer auth_token_env)
+	AuthTokenEnv          string   `yaml:"auth_token_env,omitempty" mapstructure:"auth_token_env"`                   // Environment variable holding the bearer token
+	StateFile             string   `yaml:"state_file,omitempty" mapstructure:"state_file"`                           // Where watch state is persisted, relative to the repo root (default: <git dir>/workie/watch-state.json)
+	NotifyCooldownMinutes int      `yaml:"notify_cooldown_minutes,omitempty" mapstructure:"notify_cooldown_minutes"` // Minimum minutes between notifications for the same branch (default: 60)
+
+	// ConflictMessageTemplate is a text/template for conflict notifications, rendered
+	// with .Branch, .Files, .FileCount and .Repo
+	ConflictMessageTemplate string `yaml:"conflict_message_template,omitempty" mapstructure:"conflict_message_template"`
+}
+
+// GetAuthToken returns the watch API bearer token, preferring the environment variable
+func (w *WatchConfig) GetAuthToken() string {
+	if w == nil {
+		return ""
+	}
+	if w.AuthTokenEnv != "" {
+		if token := os.Getenv(w.AuthTokenEnv); token != "" {
+			return token
+		}
+	}
+	return w.AuthToken
+}
+
+// PRConfig represents defaults for pull requests opened with 'workie pr'
+type PRConfig struct {
+	Draft     bool     `yaml:"draft,omitempty" mapstructure:"draft"`         // Open pull requests as drafts
+	Reviewers []string `yaml:"reviewers,omitempty" mapstructure:"reviewers"` // Reviewers to request (use org/team for teams)
+	Open      bool     `yaml:"open,omitempty" mapstructure:"open"`           // Open the created pull request in a browser
+}
+
+// IssueCacheConfig controls the on-disk cache of issues fetched from providers
+type IssueCacheConfig struct {
+	Disabled bool          `yaml:"disabled,omitempty" mapstructure:"disabled"` // Always fetch issues from the provider
+	TTL      time.Duration `yaml:"ttl,omitempty" mapstructure:"ttl"`           // How long a fetched issue is reused (default: 5m)
+}
+
+// ToolsConfig represents configuration for the AI agent tools
+type ToolsConfig struct {
+	SandboxDir string             `yaml:"sandbox_dir,omitempty" mapstructure:"sandbox_dir"` // Directory tools are confined to, relative to the repo root (default: repo root)
+	Agent      *AgentLimitsConfig `yaml:"agent,omitempty" mapstructure:"agent"`             // Limits on the agent's tool loop
+	Git        *GitToolConfig     `yaml:"git,omitempty" mapstructure:"git"`                 // What the git tool may run
+}
+
+// GitToolConfig restricts the git commands the agent's git tool may run
+type GitToolConfig struct {
+	AllowedCommands []string `yaml:"allowed_commands,omitempty" mapstructure:"allowed_commands"` // Git subcommands the agent may run (default: status, branch, diff, log)
+}
+
+// AgentLimitsConfig bounds how much work the agent may do for a single query
+type AgentLimitsConfig struct {
+	MaxIterations        int            `yaml:"max_iterations,omitempty" mapstructure:"max_iterations"`                 // LLM round trips per query (default: 5)
+	MaxCallsPerTool      int            `yaml:"max_calls_per_tool,omitempty" mapstructure:"max_calls_per_tool"`         // Invocations of any one tool per query (default: 10)
+	ToolCallLimits       map[string]int `yaml:"tool_call_limits,omitempty" mapstructure:"tool_call_limits"`             // Per-tool overrides, e.g. filesystem: 5
+	TimeoutSeconds       int            `yaml:"timeout_seconds,omitempty" mapstructure:"timeout_seconds"`               // Wall-clock budget per query (default: 120)
+	MaxConsecutiveErrors int            `yaml:"max_consecutive_errors,omitempty" mapstructure:"max_consecutive_errors"` // Abort after this many failed tool calls in a row (default: 3)
 }
 
 // Hooks represents the configuration for lifecycle hooks
 type Hooks struct {
-	PostCreate     []string `yaml:"post_create" mapstructure:"post_create"`
-	PreRemove      []string `yaml:"pre_remove" mapstructure:"pre_remove"`
-	TimeoutMinutes int      `yaml:"timeout_minutes,omitempty" mapstructure:"timeout_minutes"` // Hook execution timeout in minutes (default: 5)
+	PreCreate      []HookCommand `yaml:"pre_create,omitempty" mapstructure:"pre_create"` // Run in the repository before the worktree is created; a failure aborts creation
+	PreCopy        []HookCommand `yaml:"pre_copy,omitempty" mapstructure:"pre_copy"`     // Run in the repository after the worktree is created, before files_to_copy are copied
+	PostCopy       []HookCommand `yaml:"post_copy,omitempty" mapstructure:"post_copy"`   // Run in the new worktree after files are copied, before post_create
+	PostCreate     []HookCommand `yaml:"post_create" mapstructure:"post_create"`
+	PreRemove      []HookCommand `yaml:"pre_remove" mapstructure:"pre_remove"`
+	PostRemove     []HookCommand `yaml:"post_remove,omitempty" mapstructure:"post_remove"`         // Run in the repository after the worktree is removed
+	PreFinish      []HookCommand `yaml:"pre_finish,omitempty" mapstructure:"pre_finish"`           // Checks (e.g. tests) that must pass before 'workie finish' pushes or merges
+	TimeoutMinutes int           `yaml:"timeout_minutes,omitempty" mapstructure:"timeout_minutes"` // Hook execution timeout in minutes (default: 5); a hook's own timeout takes precedence
+	Shell          string        `yaml:"shell,omitempty" mapstructure:"shell"`                     // Shell for commands that need one, e.g. bash or pwsh (default: sh, cmd on Windows)
+
+	// Environment of hook commands: workie's own, or with clean_env only PATH
+	// and HOME; env is set on top, then the WORKIE_* variables
+	CleanEnv bool              `yaml:"clean_env,omitempty" mapstructure:"clean_env"` // Don't pass workie's environment on to hooks
+	Env      map[string]string `yaml:"env,omitempty" mapstructure:"env"`             // Variables set for every hook command
 
 	// Claude Code hook events
-	ClaudePreToolUse       []string `yaml:"claude_pre_tool_use,omitempty" mapstructure:"claude_pre_tool_use"`             // Before Claude uses a tool
-	ClaudePostToolUse      []string `yaml:"claude_post_tool_use,omitempty" mapstructure:"claude_post_tool_use"`           // After Claude uses a tool
-	ClaudeNotification     []string `yaml:"claude_notification,omitempty" mapstructure:"claude_notification"`             // On Claude notifications
-	ClaudeUserPromptSubmit []string `yaml:"claude_user_prompt_submit,omitempty" mapstructure:"claude_user_prompt_submit"` // When user submits prompt
-	ClaudeStop             []string `yaml:"claude_stop,omitempty" mapstructure:"claude_stop"`                             // When Claude finishes responding
-	ClaudeSubagentStop     []string `yaml:"claude_subagent_stop,omitempty" mapstructure:"claude_subagent_stop"`           // When subagent finishes
-	ClaudePreCompact       []string `yaml:"claude_pre_compact,omitempty" mapstructure:"claude_pre_compact"`               // Before context compaction
+	ClaudePreToolUse       []HookCommand `yaml:"claude_pre_tool_use,omitempty" mapstructure:"claude_pre_tool_use"`             // Before Claude uses a tool
+	ClaudePostToolUse      []HookCommand `yaml:"claude_post_tool_use,omitempty" mapstructure:"claude_post_tool_use"`           // After Claude uses a tool
+	ClaudeNotification     []HookCommand `yaml:"claude_notification,omitempty" mapstructure:"claude_notification"`             // On Claude notifications
+	ClaudeUserPromptSubmit []HookCommand `yaml:"claude_user_prompt_submit,omitempty" mapstructure:"claude_user_prompt_submit"` // When user submits prompt
+	ClaudeStop             []HookCommand `yaml:"claude_stop,omitempty" mapstructure:"claude_stop"`                             // When Claude finishes responding
+	ClaudeSubagentStop     []HookCommand `yaml:"claude_subagent_stop,omitempty" mapstructure:"claude_subagent_stop"`           // When subagent finishes
+	ClaudePreCompact       []HookCommand `yaml:"claude_pre_compact,omitempty" mapstructure:"claude_pre_compact"`               // Before context compaction
 
 	// AI decision configuration
 	AIDecision *AIDecisionConfig `yaml:"ai_decision,omitempty" mapstructure:"ai_decision"`
@@ -76,22 +151,205 @@ type OllamaConfig struct {
 	NumGPU    int               `yaml:"num_gpu" mapstructure:"num_gpu"`
 }
 
+// OpenAIConfig represents the configuration of an OpenAI-compatible API, used
+// when ai.model.provider is "openai"
+type OpenAIConfig struct {
+	BaseURL   string `yaml:"base_url,omitempty" mapstructure:"base_url"`       // API base URL, e.g. https://llm.internal.example.com/v1 (default: OpenAI's)
+	APIKeyEnv string `yaml:"api_key_env,omitempty" mapstructure:"api_key_env"` // Environment variable holding the API key (default: OPENAI_API_KEY)
+}
+
+// AIProviders are the supported values of ai.model.provider
+var AIProviders = []string{"ollama", "openai"}
+
+// CopyBackends are the supported values of copy_backend
+var CopyBackends = []string{"native", "rsync"}
+
+// CopyModes are the supported values of copy_mode
+var CopyModes = []string{"copy", "hardlink", "reflink", "symlink"}
+
+// WorktreeDirNamings are the supported values of worktree_dir_naming
+var WorktreeDirNamings = []string{"nested", "flat"}
+
 // AIConfig represents AI configuration
 type AIConfig struct {
-	Enabled bool         `yaml:"enabled" mapstructure:"enabled"`
-	Model   AIModel      `yaml:"model" mapstructure:"model"`
-	Ollama  OllamaConfig `yaml:"ollama" mapstructure:"ollama"`
+	Enabled      bool         `yaml:"enabled" mapstructure:"enabled"`
+	Model        AIModel      `yaml:"model" mapstructure:"model"`
+	Ollama       OllamaConfig `yaml:"ollama" mapstructure:"ollama"`
+	OpenAI       OpenAIConfig `yaml:"openai" mapstructure:"openai"`
+	SystemPrompt string       `yaml:"system_prompt,omitempty" mapstructure:"system_prompt"` // Instructions prepended to the agent's prompt (tone, coding standards)
+	UsageLog     string       `yaml:"usage_log,omitempty" mapstructure:"usage_log"`         // JSON lines file recording each AI call's size, tokens and latency, relative to the repo root
+	Require      bool         `yaml:"require,omitempty" mapstructure:"require"`             // Fail when an AI call fails instead of falling back to non-AI behavior
+}
+
+// CopyEntry is an item in files_to_copy. It is written either as a plain path,
+// copied to the same path in the worktree, or as a mapping. Paths may be glob
+// patterns:
+//
+//	files_to_copy:
+//	  - .env.example
+//	  - config/*.example
+//	  - src: .env.production
+//	    dst: .env
+type CopyEntry struct {
+	Src string `yaml:"src" mapstructure:"src"` // Path relative to the repository root
+	Dst string `yaml:"dst" mapstructure:"dst"` // Path relative to the worktree; a trailing slash copies into that directory
+}
+
+// UnmarshalYAML accepts either a plain path or a {src, dst} mapping
+func (e *CopyEntry) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*e = CopyEntry{Src: node.Value}
+		return nil
+	}
+
+	type plain CopyEntry
+	var entry plain
+	if err := node.Decode(&entry); err != nil {
+		return err
+	}
+	*e = CopyEntry(entry)
+	return nil
+}
+
+// Destination returns the worktree-relative path the entry is copied to
+func (e CopyEntry) Destination() string {
+	if e.Dst == "" {
+		return e.Src
+	}
+	if strings.HasSuffix(e.Dst, "/") || strings.HasSuffix(e.Dst, string(filepath.Separator)) {
+		return filepath.Join(e.Dst, filepath.Base(e.Src))
+	}
+	return e.Dst
+}
+
+// IsGlob reports whether Src is a pattern such as config/*.example
+func (e CopyEntry) IsGlob() bool {
+	return strings.ContainsAny(e.Src, "*?[")
+}
+
+// Validate checks that the destination stays inside the worktree
+func (e CopyEntry) Validate() error {
+	if e.Dst == "" {
+		return nil
+	}
+	if strings.TrimSpace(e.Src) == "" {
+		return fmt.Errorf("entry with dst '%s' is missing src", e.Dst)
+	}
+	if filepath.IsAbs(e.Dst) {
+		return fmt.Errorf("dst '%s' must be relative to the worktree", e.Dst)
+	}
+	if dst := filepath.Clean(e.Destination()); dst == ".." || strings.HasPrefix(dst, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("dst '%s' escapes the worktree", e.Dst)
+	}
+	return nil
+}
+
+// String returns the entry as shown in output, e.g. ".env.production → .env"
+func (e CopyEntry) String() string {
+	if e.Dst == "" {
+		return e.Src
+	}
+	return e.Src + " → " + e.Dst
+}
+
+// copyEntryDecodeHook lets Viper decode plain string files_to_copy items into a CopyEntry
+func copyEntryDecodeHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if to != reflect.TypeOf(CopyEntry{}) || from.Kind() != reflect.String {
+		return data, nil
+	}
+	return CopyEntry{Src: data.(string)}, nil
+}
+
+// HookCommand is a command run by a hook. It is written either as a plain
+// command, which runs with the hooks' timeout_minutes, or as a mapping with its
+// own timeout:
+//
+//	post_create:
+//	  - npm install
+//	  - command: make test
+//	    timeout: 10m
+type HookCommand struct {
+	Command string        `yaml:"command" mapstructure:"command"`
+	Timeout time.Duration `yaml:"timeout,omitempty" mapstructure:"timeout"` // Overrides timeout_minutes for this command
+}
+
+// HookCommands builds hook commands without their own timeout
+func HookCommands(commands ...string) []HookCommand {
+	hooks := make([]HookCommand, len(commands))
+	for i, command := range commands {
+		hooks[i] = HookCommand{Command: command}
+	}
+	return hooks
+}
+
+// UnmarshalYAML accepts either a plain command or a {command, timeout} mapping
+func (h *HookCommand) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*h = HookCommand{Command: node.Value}
+		return nil
+	}
+
+	type plain HookCommand
+	var hook plain
+	if err := node.Decode(&hook); err != nil {
+		return err
+	}
+	*h = HookCommand(hook)
+	return nil
+}
+
+// MarshalYAML writes commands without their own timeout as plain strings
+func (h HookCommand) MarshalYAML() (interface{}, error) {
+	if h.Timeout == 0 {
+		return h.Command, nil
+	}
+	return struct {
+		Command string `yaml:"command"`
+		Timeout string `yaml:"timeout"`
+	}{h.Command, h.Timeout.String()}, nil
+}
+
+// String returns the hook as shown in output, e.g. "make test (timeout 10m0s)"
+func (h HookCommand) String() string {
+	if h.Timeout == 0 {
+		return h.Command
+	}
+	return fmt.Sprintf("%s (timeout %v)", h.Command, h.Timeout)
+}
+
+// hookCommandDecodeHook lets Viper decode plain string hook commands into a HookCommand
+func hookCommandDecodeHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if to != reflect.TypeOf(HookCommand{}) || from.Kind() != reflect.String {
+		return data, nil
+	}
+	return HookCommand{Command: data.(string)}, nil
 }
 
 // Config represents the YAML configuration structure
 type Config struct {
-	FilesToCopy     []string               `yaml:"files_to_copy" mapstructure:"files_to_copy"`
-	Hooks           *Hooks                 `yaml:"hooks,omitempty" mapstructure:"hooks"`
-	AI              AIConfig               `yaml:"ai" mapstructure:"ai"`
-	Providers       map[string]interface{} `yaml:"providers,omitempty" mapstructure:"providers"`               // Provider configurations
-	DefaultProvider string                 `yaml:"default_provider,omitempty" mapstructure:"default_provider"` // Default issue provider
-	Watch           *WatchConfig           `yaml:"watch,omitempty" mapstructure:"watch"`                       // Watch configuration
-	LoadedFrom      string                 `yaml:"-" mapstructure:"-"`                                         // Path to the loaded config file (not serialized)
+	FilesToCopy       []CopyEntry            `yaml:"files_to_copy" mapstructure:"files_to_copy"`
+	CopyBackend       string                 `yaml:"copy_backend,omitempty" mapstructure:"copy_backend"`         // Directory copy backend: native (default) or rsync
+	CopyMode          string                 `yaml:"copy_mode,omitempty" mapstructure:"copy_mode"`               // How files are placed: copy (default), hardlink, reflink or symlink
+	FollowSymlinks    bool                   `yaml:"follow_symlinks,omitempty" mapstructure:"follow_symlinks"`   // Copy symlink targets instead of recreating the links
+	CopyConcurrency   int                    `yaml:"copy_concurrency,omitempty" mapstructure:"copy_concurrency"` // Files copied at once within a directory (default: number of CPUs)
+	MaxCopySize       string                 `yaml:"max_copy_size,omitempty" mapstructure:"max_copy_size"`       // Largest total size files_to_copy may seed into a worktree, e.g. 500MB (default: unlimited)
+	Hooks             *Hooks                 `yaml:"hooks,omitempty" mapstructure:"hooks"`
+	AI                AIConfig               `yaml:"ai" mapstructure:"ai"`
+	Providers         map[string]interface{} `yaml:"providers,omitempty" mapstructure:"providers"`                                       // Provider configurations
+	DefaultProvider   string                 `yaml:"default_provider,omitempty" mapstructure:"default_provider"`                         // Default issue provider
+	IssueCache        *IssueCacheConfig      `yaml:"issue_cache,omitempty" mapstructure:"issue_cache"`                                   // Cache of fetched issues
+	Watch             *WatchConfig           `yaml:"watch,omitempty" mapstructure:"watch"`                                               // Watch configuration
+	Tools             *ToolsConfig           `yaml:"tools,omitempty" mapstructure:"tools"`                                               // AI agent tool configuration
+	PR                *PRConfig              `yaml:"pr,omitempty" mapstructure:"pr"`                                                     // Pull request defaults
+	IssueTemplate     string                 `yaml:"issue_worktree_template,omitempty" mapstructure:"issue_worktree_template"`           // text/template rendered with the issue when beginning work from an issue
+	IssueTemplatePath string                 `yaml:"issue_worktree_template_path,omitempty" mapstructure:"issue_worktree_template_path"` // Worktree-relative path the rendered issue template is written to
+	WorktreeMetadata  bool                   `yaml:"worktree_metadata,omitempty" mapstructure:"worktree_metadata"`                       // Record .workie/meta.json in each new worktree and an index in the worktrees directory
+	Editor            string                 `yaml:"editor,omitempty" mapstructure:"editor"`                                             // Command 'begin --open' runs with the worktree path, e.g. "code" (default: $VISUAL, then $EDITOR)
+	WorktreeDirNaming string                 `yaml:"worktree_dir_naming,omitempty" mapstructure:"worktree_dir_naming"`                   // How branch names become worktree directories: nested (default, feature/foo → feature/foo) or flat (feature-foo)
+	LoadedFrom        string                 `yaml:"-" mapstructure:"-"`                                                                 // Path to the loaded config file (not serialized)
+	LoadedFiles       []string               `yaml:"-" mapstructure:"-"`                                                                 // Every file merged into the config: LoadedFrom, then any local override (not serialized)
+	Warnings          []Problem              `yaml:"-" mapstructure:"-"`                                                                 // Settings that were ignored, e.g. unknown keys, each naming its file (not serialized)
+	AIDisabled        bool                   `yaml:"-" mapstructure:"-"`                                                                 // AI is turned off for this invocation (--no-ai), whatever the ai section says (not serialized)
 }
 
 // LoadConfig attempts to load configuration from the specified file path,
@@ -129,24 +387,56 @@ func LoadConfig(repoPath, customPath string) (*Config, error) {
 		}
 	}
 
+	// Personal overrides in .workie.local.yaml are merged over the discovered
+	// config, or used alone without one. An explicit --config is used as is.
+	localPath := ""
+	if customPath == "" {
+		localPath = findLocalConfig(repoPath)
+		if configPath == "" {
+			configPath, localPath = localPath, ""
+		}
+	}
+
 	// If no config file is found, return empty config (not an error)
 	if configPath == "" {
 		return config, nil
 	}
 
-	// Read the config file
-	data, err := os.ReadFile(configPath)
+	root, warnings, err := parseConfigFile(configPath, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+		return nil, err
 	}
+	for i := range warnings {
+		warnings[i].File = filepath.Base(configPath)
+	}
+	loadedFiles := []string{configPath}
 
-	// Use yaml.v3 for parsing
-	if err := yaml.Unmarshal(data, config); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML from %s: %w", configPath, err)
+	files := make(map[*yaml.Node]string)
+	if localPath != "" {
+		local, localWarnings, err := parseConfigFile(localPath, filepath.Base(localPath))
+		if err != nil {
+			return nil, err
+		}
+		nodeFiles(local, filepath.Base(localPath), files)
+		root = mergeNodes(root, local)
+		loadedFiles = append(loadedFiles, localPath)
+		warnings = append(warnings, localWarnings...)
+	}
+
+	if root.Kind != 0 {
+		if err := root.Decode(config); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML from %s: %w", strings.Join(loadedFiles, " + "), err)
+		}
+	}
+
+	if err := config.validateDocument(root, files); err != nil {
+		return nil, fmt.Errorf("invalid configuration in %s: %w", configPath, err)
 	}
 
 	// Set the path where config was loaded from
 	config.LoadedFrom = configPath
+	config.LoadedFiles = loadedFiles
+	config.Warnings = warnings
 
 	return config, nil
 }
@@ -156,6 +446,142 @@ func (c *Config) HasFilesToCopy() bool {
 	return c != nil && len(c.FilesToCopy) > 0
 }
 
+// GetCopyBackend returns the configured directory copy backend, defaulting to "native"
+func (c *Config) GetCopyBackend() string {
+	if c == nil || c.CopyBackend == "" {
+		return "native"
+	}
+	return strings.ToLower(c.CopyBackend)
+}
+
+// GetCopyMode returns the configured file copy mode, defaulting to "copy"
+func (c *Config) GetCopyMode() string {
+	if c == nil || c.CopyMode == "" {
+		return "copy"
+	}
+	return strings.ToLower(c.CopyMode)
+}
+
+// GetWorktreeDirNaming returns how branch names map to worktree directories,
+// defaulting to "nested"
+func (c *Config) GetWorktreeDirNaming() string {
+	if c == nil || c.WorktreeDirNaming == "" {
+		return "nested"
+	}
+	return strings.ToLower(c.WorktreeDirNaming)
+}
+
+// GetCopyConcurrency returns how many files may be copied at once, defaulting to
+// the number of CPUs
+func (c *Config) GetCopyConcurrency() int {
+	if c == nil || c.CopyConcurrency <= 0 {
+		return runtime.NumCPU()
+	}
+	return c.CopyConcurrency
+}
+
+// GetMaxCopySize returns the largest total size in bytes that files_to_copy may
+// copy into a worktree, or 0 when there is no limit
+func (c *Config) GetMaxCopySize() (int64, error) {
+	if c == nil || c.MaxCopySize == "" {
+		return 0, nil
+	}
+	return ParseByteSize(c.MaxCopySize)
+}
+
+// ParseByteSize parses a size such as "512", "500MB", "1.5G" or "2GiB". Units are
+// case-insensitive powers of 1024, matching how sizes are reported.
+func ParseByteSize(s string) (int64, error) {
+	number := strings.ToUpper(strings.TrimSpace(s))
+	multiplier := int64(1)
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(number, unit.suffix) {
+			number = strings.TrimSpace(strings.TrimSuffix(number, unit.suffix))
+			multiplier = unit.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q: use a number of bytes or a size like 500MB or 2GB", s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// byteSizeUnits are the suffixes ParseByteSize accepts, longest first so that
+// "MB" is not read as a number ending in "B"
+var byteSizeUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"TIB", 1 << 40}, {"GIB", 1 << 30}, {"MIB", 1 << 20}, {"KIB", 1 << 10},
+	{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+	{"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10},
+	{"B", 1},
+}
+
+// GetIssueTemplatePath returns the worktree-relative path for the rendered issue
+// template, defaulting to .workie/PR_DESCRIPTION.md
+func (c *Config) GetIssueTemplatePath() string {
+	if c == nil || c.IssueTemplatePath == "" {
+		return filepath.Join(".workie", "PR_DESCRIPTION.md")
+	}
+	return c.IssueTemplatePath
+}
+
+// GetDefaultProvider returns the issue provider used for bare issue IDs:
+// default_provider, or its alias providers.default. It is empty when neither is set.
+func (c *Config) GetDefaultProvider() string {
+	if c == nil {
+		return ""
+	}
+	if c.DefaultProvider != "" {
+		return c.DefaultProvider
+	}
+	if name, ok := c.Providers["default"].(string); ok {
+		return strings.TrimSpace(name)
+	}
+	return ""
+}
+
+// GetEditor returns the command that opens a worktree: the editor setting,
+// then $VISUAL, then $EDITOR. It is empty when none is set.
+func (c *Config) GetEditor() string {
+	if c != nil && strings.TrimSpace(c.Editor) != "" {
+		return strings.TrimSpace(c.Editor)
+	}
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	return ""
+}
+
+// DefaultIssueCacheTTL is how long fetched issues are reused unless issue_cache.ttl is set
+const DefaultIssueCacheTTL = 5 * time.Minute
+
+// GetIssueCacheTTL returns how long fetched issues are cached, or 0 when the
+// cache is disabled
+func (c *Config) GetIssueCacheTTL() time.Duration {
+	if c == nil || c.IssueCache == nil {
+		return DefaultIssueCacheTTL
+	}
+	if c.IssueCache.Disabled {
+		return 0
+	}
+	if c.IssueCache.TTL > 0 {
+		return c.IssueCache.TTL
+	}
+	return DefaultIssueCacheTTL
+}
+
+// ShouldFollowSymlinks returns true if symlinks should be followed when copying directories
+func (c *Config) ShouldFollowSymlinks() bool {
+	return c != nil && c.FollowSymlinks
+}
+
 // LoadConfigWithViper loads configuration using Viper library
 // This provides enhanced features like environment variable support, defaults, etc.
 func LoadConfigWithViper(repoRoot string, customConfigPath string) (*Config, error) {
@@ -233,9 +659,18 @@ func LoadConfigWithViper(repoRoot string, customConfigPath string) (*Config, err
 	}
 
 	// Unmarshal configuration
-	if err := v.Unmarshal(config); err != nil {
+	decodeHook := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		copyEntryDecodeHook,
+		hookCommandDecodeHook,
+	))
+	if err := v.Unmarshal(config, decodeHook); err != nil {
 		return nil, fmt.Errorf("failed to parse configuration: %w", err)
 	}
+	if err := config.validateLoaded(v.ConfigFileUsed()); err != nil {
+		return nil, fmt.Errorf("invalid configuration in %s: %w", v.ConfigFileUsed(), err)
+	}
 
 	// Store the loaded config file path
 	config.LoadedFrom = v.ConfigFileUsed()
@@ -245,7 +680,20 @@ func LoadConfigWithViper(repoRoot string, customConfigPath string) (*Config, err
 
 // IsAIEnabled returns true if AI features are enabled
 func (c *Config) IsAIEnabled() bool {
-	return c != nil && c.AI.Model.Provider != "" && c.AI.Model.Name != ""
+	return c != nil && !c.AIDisabled && c.AI.Model.Provider != "" && c.AI.Model.Name != ""
+}
+
+// GetAIProvider returns the configured model provider, defaulting to "ollama"
+func (c *Config) GetAIProvider() string {
+	if c == nil || c.AI.Model.Provider == "" {
+		return "ollama"
+	}
+	return strings.ToLower(c.AI.Model.Provider)
+}
+
+// RequiresAI returns true if AI failures must be errors rather than fallbacks
+func (c *Config) RequiresAI() bool {
+	return c != nil && !c.AIDisabled && c.AI.Require
 }
 
 // GetOllamaEndpoint returns the full Ollama API endpoint for a given operation
@@ -273,9 +721,19 @@ func (c *Config) GetOllamaEndpoint(operation string) string {
 
 // Providers represents the issue provider configurations
 type Providers struct {
-	GitHub *GitHubProvider `yaml:"github,omitempty" mapstructure:"github"`
-	Jira   *JiraProvider   `yaml:"jira,omitempty" mapstructure:"jira"`
-	Linear *LinearProvider `yaml:"linear,omitempty" mapstructure:"linear"`
+	Default   string             `yaml:"default,omitempty" mapstructure:"default"` // Alias for default_provider
+	GitHub    *GitHubProvider    `yaml:"github,omitempty" mapstructure:"github"`
+	Jira      *JiraProvider      `yaml:"jira,omitempty" mapstructure:"jira"`
+	Linear    *LinearProvider    `yaml:"linear,omitempty" mapstructure:"linear"`
+	Bitbucket *BitbucketProvider `yaml:"bitbucket,omitempty" mapstructure:"bitbucket"`
+}
+
+// BranchNameConfig controls how a provider formats branch names for issues
+type BranchNameConfig struct {
+	Separator      string `yaml:"separator,omitempty" mapstructure:"separator"`               // Separator between words: -, _ or . (default: -)
+	MaxLength      int    `yaml:"max_length,omitempty" mapstructure:"max_length"`             // Longest title part (default: 63)
+	PreserveCase   bool   `yaml:"preserve_case,omitempty" mapstructure:"preserve_case"`       // Keep the title's letter case
+	PreserveIDCase bool   `yaml:"preserve_id_case,omitempty" mapstructure:"preserve_id_case"` // Keep issue IDs such as PROJ-456 as they are
 }
 
 // GitHubProvider represents GitHub configuration
@@ -283,6 +741,7 @@ type GitHubProvider struct {
 	Enabled      bool              `yaml:"enabled" mapstructure:"enabled"`
 	Settings     GitHubSettings    `yaml:"settings" mapstructure:"settings"`
 	BranchPrefix map[string]string `yaml:"branch_prefix,omitempty" mapstructure:"branch_prefix"`
+	BranchName   *BranchNameConfig `yaml:"branch_name,omitempty" mapstructure:"branch_name"`
 }
 
 // GitHubSettings contains GitHub-specific settings
@@ -294,9 +753,11 @@ type GitHubSettings struct {
 
 // JiraProvider represents Jira configuration
 type JiraProvider struct {
-	Enabled      bool              `yaml:"enabled" mapstructure:"enabled"`
-	Settings     JiraSettings      `yaml:"settings" mapstructure:"settings"`
-	BranchPrefix map[string]string `yaml:"branch_prefix,omitempty" mapstructure:"branch_prefix"`
+	Enabled      bool                `yaml:"enabled" mapstructure:"enabled"`
+	Settings     JiraSettings        `yaml:"settings" mapstructure:"settings"`
+	BranchPrefix map[string]string   `yaml:"branch_prefix,omitempty" mapstructure:"branch_prefix"`
+	BranchName   *BranchNameConfig   `yaml:"branch_name,omitempty" mapstructure:"branch_name"`
+	StatusMap    map[string][]string `yaml:"status_map,omitempty" mapstructure:"status_map"`
 }
 
 // JiraSettings contains Jira-specific settings
@@ -309,9 +770,11 @@ type JiraSettings struct {
 
 // LinearProvider represents Linear configuration
 type LinearProvider struct {
-	Enabled      bool              `yaml:"enabled" mapstructure:"enabled"`
-	Settings     LinearSettings    `yaml:"settings" mapstructure:"settings"`
-	BranchPrefix map[string]string `yaml:"branch_prefix,omitempty" mapstructure:"branch_prefix"`
+	Enabled      bool                `yaml:"enabled" mapstructure:"enabled"`
+	Settings     LinearSettings      `yaml:"settings" mapstructure:"settings"`
+	BranchPrefix map[string]string   `yaml:"branch_prefix,omitempty" mapstructure:"branch_prefix"`
+	BranchName   *BranchNameConfig   `yaml:"branch_name,omitempty" mapstructure:"branch_name"`
+	StatusMap    map[string][]string `yaml:"status_map,omitempty" mapstructure:"status_map"`
 }
 
 // LinearSettings contains Linear-specific settings
@@ -319,3 +782,20 @@ type LinearSettings struct {
 	APIKeyEnv string `yaml:"api_key_env" mapstructure:"api_key_env"`
 	TeamID    string `yaml:"team_id,omitempty" mapstructure:"team_id"`
 }
+
+// BitbucketProvider represents Bitbucket Cloud configuration
+type BitbucketProvider struct {
+	Enabled      bool                `yaml:"enabled" mapstructure:"enabled"`
+	Settings     BitbucketSettings   `yaml:"settings" mapstructure:"settings"`
+	BranchPrefix map[string]string   `yaml:"branch_prefix,omitempty" mapstructure:"branch_prefix"`
+	BranchName   *BranchNameConfig   `yaml:"branch_name,omitempty" mapstructure:"branch_name"`
+	StatusMap    map[string][]string `yaml:"status_map,omitempty" mapstructure:"status_map"`
+}
+
+// BitbucketSettings contains Bitbucket-specific settings
+type BitbucketSettings struct {
+	Workspace      string `yaml:"workspace" mapstructure:"workspace"`
+	RepoSlug       string `yaml:"repo_slug" mapstructure:"repo_slug"`
+	UsernameEnv    string `yaml:"username_env" mapstructure:"username_env"`
+	AppPasswordEnv string `yaml:"app_password_env" mapstructure:"app_password_env"`
+}
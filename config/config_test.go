@@ -1,10 +1,16 @@
 package config
 
 import (
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -54,7 +60,7 @@ func TestLoadConfig(t *testing.T) {
 		}
 
 		for i, expected := range expectedFiles {
-			if i >= len(config.FilesToCopy) || config.FilesToCopy[i] != expected {
+			if i >= len(config.FilesToCopy) || config.FilesToCopy[i].Src != expected {
 				t.Errorf("Expected file %s at index %d, got %v", expected, i, config.FilesToCopy)
 			}
 		}
@@ -81,7 +87,7 @@ func TestLoadConfig(t *testing.T) {
 			t.Fatal("Expected config to be returned, got nil")
 		}
 
-		if len(config.FilesToCopy) != 1 || config.FilesToCopy[0] != "README.md" {
+		if len(config.FilesToCopy) != 1 || config.FilesToCopy[0].Src != "README.md" {
 			t.Errorf("Expected [README.md], got %v", config.FilesToCopy)
 		}
 	})
@@ -104,22 +110,144 @@ invalid yaml: [[[`
 	})
 }
 
+func TestCopyEntries(t *testing.T) {
+	tempDir := t.TempDir()
+
+	t.Run("plain paths and mappings", func(t *testing.T) {
+		configContent := `files_to_copy:
+  - .env.example
+  - src: .env.production
+    dst: .env
+  - src: scripts/setup.sh
+    dst: bin/`
+
+		if err := os.WriteFile(filepath.Join(tempDir, ".workie.yaml"), []byte(configContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		config, err := LoadConfig(tempDir, "")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		want := map[string]string{
+			".env.example":     ".env.example",
+			".env.production":  ".env",
+			"scripts/setup.sh": filepath.Join("bin", "setup.sh"),
+		}
+		if len(config.FilesToCopy) != len(want) {
+			t.Fatalf("Expected %d entries, got %v", len(want), config.FilesToCopy)
+		}
+		for _, entry := range config.FilesToCopy {
+			if got := entry.Destination(); got != want[entry.Src] {
+				t.Errorf("Expected %s to be copied to %s, got %s", entry.Src, want[entry.Src], got)
+			}
+		}
+	})
+
+	t.Run("rejects destinations outside the worktree", func(t *testing.T) {
+		for _, dst := range []string{"/etc/passwd", "../outside", "nested/../../outside"} {
+			configContent := "files_to_copy:\n  - src: .env\n    dst: " + dst + "\n"
+			if err := os.WriteFile(filepath.Join(tempDir, ".workie.yaml"), []byte(configContent), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadConfig(tempDir, ""); err == nil {
+				t.Errorf("Expected error for dst %q", dst)
+			}
+		}
+	})
+
+	t.Run("viper loader accepts both forms", func(t *testing.T) {
+		configContent := `files_to_copy:
+  - .env.example
+  - src: .env.production
+    dst: .env`
+		if err := os.WriteFile(filepath.Join(tempDir, ".workie.yaml"), []byte(configContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		config, err := LoadConfigWithViper(tempDir, filepath.Join(tempDir, ".workie.yaml"))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		want := []CopyEntry{{Src: ".env.example"}, {Src: ".env.production", Dst: ".env"}}
+		if len(config.FilesToCopy) != 2 || config.FilesToCopy[0] != want[0] || config.FilesToCopy[1] != want[1] {
+			t.Errorf("Expected %v, got %v", want, config.FilesToCopy)
+		}
+	})
+}
+
 func TestHasFilesToCopy(t *testing.T) {
 	t.Run("empty config", func(t *testing.T) {
-		config := &Config{FilesToCopy: []string{}}
+		config := &Config{FilesToCopy: []CopyEntry{}}
 		if config.HasFilesToCopy() {
 			t.Error("Expected HasFilesToCopy to return false for empty config")
 		}
 	})
 
 	t.Run("config with files", func(t *testing.T) {
-		config := &Config{FilesToCopy: []string{".env.example"}}
+		config := &Config{FilesToCopy: []CopyEntry{{Src: ".env.example"}}}
 		if !config.HasFilesToCopy() {
 			t.Error("Expected HasFilesToCopy to return true for config with files")
 		}
 	})
 }
 
+func TestHookCommands(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `hooks:
+  timeout_minutes: 2
+  post_create:
+    - npm install
+    - command: make test
+      timeout: 10m`
+	if err := os.WriteFile(filepath.Join(tempDir, ".workie.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	want := []HookCommand{{Command: "npm install"}, {Command: "make test", Timeout: 10 * time.Minute}}
+
+	t.Run("plain commands and mappings", func(t *testing.T) {
+		config, err := LoadConfig(tempDir, "")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !reflect.DeepEqual(config.Hooks.PostCreate, want) {
+			t.Errorf("Expected %v, got %v", want, config.Hooks.PostCreate)
+		}
+	})
+
+	t.Run("viper loader accepts both forms", func(t *testing.T) {
+		config, err := LoadConfigWithViper(tempDir, filepath.Join(tempDir, ".workie.yaml"))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !reflect.DeepEqual(config.Hooks.PostCreate, want) {
+			t.Errorf("Expected %v, got %v", want, config.Hooks.PostCreate)
+		}
+	})
+
+	t.Run("marshals commands without a timeout as strings", func(t *testing.T) {
+		data, err := yaml.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(data); got != "- npm install\n- command: make test\n  timeout: 10m0s\n" {
+			t.Errorf("Unexpected YAML:\n%s", got)
+		}
+	})
+
+	t.Run("rejects negative timeouts", func(t *testing.T) {
+		configContent := "hooks:\n  post_create:\n    - command: make test\n      timeout: -1m\n"
+		if err := os.WriteFile(filepath.Join(tempDir, ".workie.yaml"), []byte(configContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadConfig(tempDir, "")
+		if err == nil || !strings.Contains(err.Error(), "hooks.post_create[0].timeout") {
+			t.Errorf("Expected a negative timeout error, got: %v", err)
+		}
+	})
+}
+
 func TestHookValidation(t *testing.T) {
 	// Create a temporary directory for testing
 	tempDir, err := os.MkdirTemp("", "workie-hook-test")
@@ -284,6 +412,26 @@ hooks:
 		}
 	})
 
+	t.Run("invalid env names", func(t *testing.T) {
+		configContent := `hooks:
+  clean_env: true
+  env:
+    NODE_ENV: test
+    "A=B": x
+`
+
+		configPath := filepath.Join(tempDir, ".workie.yaml")
+		err := os.WriteFile(configPath, []byte(configContent), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = LoadConfig(tempDir, "")
+		if err == nil || !strings.Contains(err.Error(), "'A=B' is not a valid environment variable name") {
+			t.Errorf("Expected an error for the invalid name, got: %v", err)
+		}
+	})
+
 	t.Run("privilege escalation", func(t *testing.T) {
 		configContent := `hooks:
   pre_remove:
@@ -325,3 +473,550 @@ hooks:
 		}
 	})
 }
+
+func TestValidationProblemLines(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `files_to_copy:
+  - .env.example
+hooks:
+  post_create:
+    - "npm install"
+    - ""
+  pre_remove:
+    - "sudo rm -rf build"
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".workie.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadConfig(tempDir, "")
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a ValidationError, got: %v", err)
+	}
+
+	want := []Problem{
+		{Field: "hooks.post_create[1]", Line: 6},
+		{Field: "hooks.pre_remove[0]", Line: 8},
+	}
+	if len(validationErr.Problems) != len(want) {
+		t.Fatalf("Expected %d problems, got: %v", len(want), validationErr.Problems)
+	}
+	for i, problem := range validationErr.Problems {
+		if problem.Field != want[i].Field || problem.Line != want[i].Line {
+			t.Errorf("Expected %s on line %d, got %s on line %d", want[i].Field, want[i].Line, problem.Field, problem.Line)
+		}
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int64
+	}{
+		{"1048576", 1048576},
+		{"512B", 512},
+		{"10k", 10 << 10},
+		{"500MB", 500 << 20},
+		{"1.5G", 3 << 29},
+		{"2 GiB", 2 << 30},
+		{"1TB", 1 << 40},
+	}
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseByteSize(%q) = %d, %v; want %d", tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "lots", "-1MB", "5PB"} {
+		if _, err := ParseByteSize(input); err == nil {
+			t.Errorf("Expected ParseByteSize(%q) to fail", input)
+		}
+	}
+
+	problems := (&Config{MaxCopySize: "big"}).Validate()
+	if len(problems) != 1 || problems[0].Field != "max_copy_size" {
+		t.Errorf("Expected an invalid max_copy_size to be reported, got %+v", problems)
+	}
+}
+
+func TestLoadConfigLocalOverrides(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	base := `files_to_copy:
+  - .env.example
+  - config/
+hooks:
+  post_create:
+    - "npm install"
+  timeout_minutes: 5
+default_provider: github
+providers:
+  github:
+    enabled: true
+    settings:
+      owner: acme
+      repo: app
+`
+
+	t.Run("no local file", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, ".workie.yaml", base)
+
+		cfg, err := LoadConfig(dir, "")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if want := []string{filepath.Join(dir, ".workie.yaml")}; !reflect.DeepEqual(cfg.LoadedFiles, want) {
+			t.Errorf("Expected LoadedFiles %v, got %v", want, cfg.LoadedFiles)
+		}
+		if len(cfg.FilesToCopy) != 2 || cfg.DefaultProvider != "github" {
+			t.Errorf("Unexpected config: %+v", cfg)
+		}
+	})
+
+	t.Run("merge precedence", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, ".workie.yaml", base)
+		write(t, dir, ".workie.local.yaml", `files_to_copy:
+  - config/
+  - .env.local
+hooks:
+  post_create:
+    - "npm install"
+    - "direnv allow"
+  timeout_minutes: 10
+default_provider: linear
+providers:
+  github:
+    settings:
+      owner: me
+  linear:
+    enabled: true
+`)
+
+		cfg, err := LoadConfig(dir, "")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if want := []string{filepath.Join(dir, ".workie.yaml"), filepath.Join(dir, ".workie.local.yaml")}; !reflect.DeepEqual(cfg.LoadedFiles, want) {
+			t.Errorf("Expected LoadedFiles %v, got %v", want, cfg.LoadedFiles)
+		}
+		if cfg.LoadedFrom != filepath.Join(dir, ".workie.yaml") {
+			t.Errorf("Expected LoadedFrom to stay the shared config, got %s", cfg.LoadedFrom)
+		}
+
+		var files []string
+		for _, entry := range cfg.FilesToCopy {
+			files = append(files, entry.Src)
+		}
+		if want := []string{".env.example", "config/", ".env.local"}; !reflect.DeepEqual(files, want) {
+			t.Errorf("Expected files_to_copy %v, got %v", want, files)
+		}
+
+		var hooks []string
+		for _, hook := range cfg.Hooks.PostCreate {
+			hooks = append(hooks, hook.Command)
+		}
+		if want := []string{"npm install", "direnv allow"}; !reflect.DeepEqual(hooks, want) {
+			t.Errorf("Expected post_create %v, got %v", want, hooks)
+		}
+		if cfg.Hooks.TimeoutMinutes != 10 || cfg.DefaultProvider != "linear" {
+			t.Errorf("Expected scalars to be overridden, got timeout %d and provider %s", cfg.Hooks.TimeoutMinutes, cfg.DefaultProvider)
+		}
+
+		github := cfg.Providers["github"].(map[string]interface{})
+		settings := github["settings"].(map[string]interface{})
+		if github["enabled"] != true || settings["owner"] != "me" || settings["repo"] != "app" {
+			t.Errorf("Expected provider settings to merge per key, got %+v", github)
+		}
+		if _, ok := cfg.Providers["linear"]; !ok {
+			t.Error("Expected the local provider to be added")
+		}
+	})
+
+	t.Run("local file alone", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "workie.local.yaml", "files_to_copy:\n  - .env\n")
+
+		cfg, err := LoadConfig(dir, "")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if cfg.LoadedFrom != filepath.Join(dir, "workie.local.yaml") || len(cfg.FilesToCopy) != 1 {
+			t.Errorf("Expected the local file to be loaded, got %+v", cfg)
+		}
+	})
+
+	t.Run("problems name the local file", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, ".workie.yaml", base)
+		write(t, dir, ".workie.local.yaml", "hooks:\n  post_create:\n    - \"\"\n")
+
+		_, err := LoadConfig(dir, "")
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("Expected a ValidationError, got: %v", err)
+		}
+		if problem := validationErr.Problems[0]; problem.File != ".workie.local.yaml" || problem.Line != 3 {
+			t.Errorf("Expected the problem on line 3 of .workie.local.yaml, got %+v", problem)
+		}
+	})
+
+	t.Run("explicit config ignores local file", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "custom.yaml", base)
+		write(t, dir, ".workie.local.yaml", "default_provider: linear\n")
+
+		cfg, err := LoadConfig(dir, filepath.Join(dir, "custom.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.DefaultProvider != "github" || len(cfg.LoadedFiles) != 1 {
+			t.Errorf("Expected --config to be used as is, got %+v", cfg)
+		}
+	})
+}
+
+func TestMergeWorktreeHooks(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	hooks := &Hooks{
+		PostCreate:     HookCommands("npm install"),
+		PreRemove:      []HookCommand{{Command: "make clean", Timeout: 2 * time.Minute}},
+		TimeoutMinutes: 5,
+	}
+
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	merged, path, err := MergeWorktreeHooks(hooks, dir)
+	if err != nil || path != "" || merged != hooks {
+		t.Fatalf("Expected the hooks unchanged without a local file, got %+v, %q, %v", merged, path, err)
+	}
+
+	local := `default_provider: linear
+hooks:
+  post_create:
+    - npm install
+    - docker compose up -d search
+  pre_remove:
+    - docker compose down search
+  timeout_minutes: 10
+`
+	if err := os.WriteFile(filepath.Join(dir, ".workie.local.yaml"), []byte(local), 0644); err != nil {
+		t.Fatal(err)
+	}
+	merged, path, err = MergeWorktreeHooks(hooks, dir)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if path != filepath.Join(dir, ".workie.local.yaml") {
+		t.Errorf("Expected the local file to be named, got %q", path)
+	}
+	if want := HookCommands("npm install", "docker compose up -d search"); !reflect.DeepEqual(merged.PostCreate, want) {
+		t.Errorf("Expected post_create to be extended without duplicates, got %+v", merged.PostCreate)
+	}
+	if want := []HookCommand{{Command: "make clean", Timeout: 2 * time.Minute}, {Command: "docker compose down search"}}; !reflect.DeepEqual(merged.PreRemove, want) {
+		t.Errorf("Expected pre_remove to be extended, got %+v", merged.PreRemove)
+	}
+	if merged.TimeoutMinutes != 10 || hooks.TimeoutMinutes != 5 {
+		t.Errorf("Expected timeout_minutes to be overridden on a copy, got %d (was %d)", merged.TimeoutMinutes, hooks.TimeoutMinutes)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".workie.local.yaml"), []byte("hooks:\n  pre_remove:\n    - \"\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var validationErr *ValidationError
+	if _, _, err := MergeWorktreeHooks(hooks, dir); !errors.As(err, &validationErr) || validationErr.Problems[0].File != ".workie.local.yaml" {
+		t.Errorf("Expected a problem in .workie.local.yaml, got: %v", err)
+	}
+
+	// Outside a repository git can't tell whether the file is tracked, so it
+	// is refused rather than trusted
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outside, ".workie.local.yaml"), []byte(local), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if merged, _, err := MergeWorktreeHooks(hooks, outside); err == nil || !strings.Contains(err.Error(), "cannot tell whether git tracks") {
+		t.Errorf("Expected the file to be refused when git fails, got %+v, %v", merged, err)
+	}
+}
+
+func TestAIProviderValidation(t *testing.T) {
+	for provider, valid := range map[string]bool{"": true, "ollama": true, "OpenAI": true, "anthropic": false} {
+		cfg := &Config{}
+		cfg.AI.Model.Provider = provider
+		problems := cfg.Validate()
+		if valid && len(problems) != 0 {
+			t.Errorf("Expected provider %q to be valid, got %+v", provider, problems)
+		}
+		if !valid && (len(problems) != 1 || problems[0].Field != "ai.model.provider") {
+			t.Errorf("Expected provider %q to be reported, got %+v", provider, problems)
+		}
+	}
+}
+
+func TestDiffAgainstDefaults(t *testing.T) {
+	data := []byte(`files_to_copy:
+  - .env.example
+copy_mode: hardlink
+copy_backend: native
+hooks:
+  timeout_minutes: 10
+  post_creat:
+    - "npm install"
+ai:
+  model:
+    name: llama3.2
+    temprature: 0.2
+providers:
+  github:
+    enabled: true
+    branch_prefix:
+      bug: "fix/"
+`)
+
+	diff, err := DiffAgainstDefaults(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChanged := []KeyDiff{
+		{Key: "copy_mode", Value: "hardlink", Default: "copy"},
+		{Key: "hooks.timeout_minutes", Value: "10", Default: "5"},
+	}
+	if !reflect.DeepEqual(diff.Changed, expectedChanged) {
+		t.Errorf("Expected changed %+v, got %+v", expectedChanged, diff.Changed)
+	}
+
+	expectedUnknown := []string{"ai.model.temprature", "hooks.post_creat"}
+	if !reflect.DeepEqual(diff.Unknown, expectedUnknown) {
+		t.Errorf("Expected unknown %v, got %v", expectedUnknown, diff.Unknown)
+	}
+
+	unset := make(map[string]string)
+	for _, k := range diff.Unset {
+		unset[k.Key] = k.Default
+	}
+	for key, def := range map[string]string{"max_copy_size": "", "hooks.post_create": "", "ai.model.provider": "ollama", "providers.github.settings": ""} {
+		if got, ok := unset[key]; !ok || got != def {
+			t.Errorf("Expected %s to be unset with default %q, got %q (listed: %v)", key, def, got, ok)
+		}
+	}
+	for _, key := range []string{"copy_mode", "providers.jira", "hooks.timeout_minutes", "providers.github.branch_prefix.feature"} {
+		if _, ok := unset[key]; ok {
+			t.Errorf("Expected %s not to be listed as unset", key)
+		}
+	}
+}
+
+func TestGetEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "vim")
+	if got := (&Config{}).GetEditor(); got != "vim" {
+		t.Errorf("Expected $EDITOR, got %q", got)
+	}
+
+	t.Setenv("VISUAL", "code --wait")
+	if got := (&Config{}).GetEditor(); got != "code --wait" {
+		t.Errorf("Expected $VISUAL to take precedence over $EDITOR, got %q", got)
+	}
+	if got := (&Config{Editor: " zed "}).GetEditor(); got != "zed" {
+		t.Errorf("Expected the editor setting to take precedence, got %q", got)
+	}
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := (*Config)(nil).GetEditor(); got != "" {
+		t.Errorf("Expected no editor, got %q", got)
+	}
+}
+
+func TestSchemaProblems(t *testing.T) {
+	t.Run("type mismatches are located errors", func(t *testing.T) {
+		tempDir := t.TempDir()
+		configContent := `files_to_copy: .env.example
+hooks:
+  timeout_minutes: ten
+  post_create:
+    - command: make test
+      timeout: soon
+`
+		if err := os.WriteFile(filepath.Join(tempDir, ".workie.yaml"), []byte(configContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := LoadConfig(tempDir, "")
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("Expected a ValidationError, got: %v", err)
+		}
+
+		want := []Problem{
+			{Field: "files_to_copy", Line: 1, Column: 16},
+			{Field: "hooks.timeout_minutes", Line: 3, Column: 20},
+			{Field: "hooks.post_create[0].timeout", Line: 6, Column: 16},
+		}
+		if len(validationErr.Problems) != len(want) {
+			t.Fatalf("Expected %d problems, got: %v", len(want), validationErr.Problems)
+		}
+		for i, problem := range validationErr.Problems {
+			if problem.Field != want[i].Field || problem.Line != want[i].Line || problem.Column != want[i].Column {
+				t.Errorf("Expected %s at %d:%d, got %s at %d:%d", want[i].Field, want[i].Line, want[i].Column, problem.Field, problem.Line, problem.Column)
+			}
+		}
+		if !strings.Contains(validationErr.Problems[0].Message, "expected a list") {
+			t.Errorf("Expected files_to_copy to need a list, got %q", validationErr.Problems[0].Message)
+		}
+	})
+
+	t.Run("unknown settings are warnings", func(t *testing.T) {
+		tempDir := t.TempDir()
+		configContent := `files_to_copy:
+  - .env.example
+  - src: .env.production
+    dts: .env
+hooks:
+  post_creat:
+    - npm install
+timeout_minutes: 3
+providers:
+  github:
+    enabled: true
+    branch_prefix:
+      bug: "fix/"
+`
+		if err := os.WriteFile(filepath.Join(tempDir, ".workie.yaml"), []byte(configContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := LoadConfig(tempDir, "")
+		if err != nil {
+			t.Fatalf("Expected unknown settings not to fail loading, got: %v", err)
+		}
+
+		want := []string{
+			".workie.yaml line 4, column 5: files_to_copy[1].dts: unknown setting; did you mean files_to_copy[1].dst?",
+			".workie.yaml line 6, column 3: hooks.post_creat: unknown setting; did you mean hooks.post_create?",
+			".workie.yaml line 8, column 1: timeout_minutes: unknown setting here; did you mean hooks.timeout_minutes?",
+		}
+		if len(cfg.Warnings) != len(want) {
+			t.Fatalf("Expected %d warnings, got: %v", len(want), cfg.Warnings)
+		}
+		for i, warning := range cfg.Warnings {
+			if warning.Error() != want[i] {
+				t.Errorf("Expected warning %q, got %q", want[i], warning.Error())
+			}
+		}
+	})
+}
+
+func TestRangeValidation(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `copy_mode: softlink
+worktree_dir_naming: deep
+hooks:
+  timeout_minutes: -1
+ai:
+  model:
+    temperature: 2.5
+    top_p: 0.9
+watch:
+  port: 70000
+tools:
+  agent:
+    tool_call_limits:
+      filesystem: -2
+  git:
+    allowed_commands: [status, -c]
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".workie.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadConfig(tempDir, "")
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a ValidationError, got: %v", err)
+	}
+
+	want := []Problem{
+		{Field: "copy_mode", Line: 1, Message: "unknown value 'softlink' (use one of: copy, hardlink, reflink, symlink)"},
+		{Field: "worktree_dir_naming", Line: 2, Message: "unknown value 'deep' (use one of: nested, flat)"},
+		{Field: "hooks.timeout_minutes", Line: 4, Message: "must not be negative, got -1 (leave it out to use the default)"},
+		{Field: "ai.model.temperature", Line: 7, Message: "must be between 0 and 2, got 2.5"},
+		{Field: "watch.port", Line: 10, Message: "must be a port between 1 and 65535, got 70000 (leave it out to use 8080)"},
+		{Field: "tools.agent.tool_call_limits.filesystem", Line: 14, Message: "must not be negative, got -2 (leave it out to use the default)"},
+		{Field: "tools.git.allowed_commands", Line: 16, Message: "'-c' is not a git subcommand (list names like status or log)"},
+	}
+	if len(validationErr.Problems) != len(want) {
+		t.Fatalf("Expected %d problems, got: %v", len(want), validationErr.Problems)
+	}
+	for i, problem := range validationErr.Problems {
+		if problem != want[i] {
+			t.Errorf("Expected %+v, got %+v", want[i], problem)
+		}
+	}
+
+	valid := &Config{CopyMode: "Hardlink", CopyBackend: "rsync"}
+	valid.AI.Model.Temperature = 0.7
+	valid.AI.Model.TopP = 1
+	if problems := valid.Validate(); len(problems) != 0 {
+		t.Errorf("Expected no problems, got %+v", problems)
+	}
+}
+
+func TestGetDefaultProvider(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `providers:
+  default: jira
+  jira:
+    enabled: true
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".workie.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(tempDir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.GetDefaultProvider(); got != "jira" {
+		t.Errorf("Expected providers.default to be used, got %q", got)
+	}
+	if len(cfg.Warnings) != 0 {
+		t.Errorf("Expected providers.default to be a known setting, got %v", cfg.Warnings)
+	}
+
+	cfg.DefaultProvider = "github"
+	if got := cfg.GetDefaultProvider(); got != "github" {
+		t.Errorf("Expected default_provider to take precedence, got %q", got)
+	}
+}
+
+func TestAIDisabled(t *testing.T) {
+	cfg := &Config{}
+	cfg.AI.Model.Provider = "ollama"
+	cfg.AI.Model.Name = "llama3.2"
+	cfg.AI.Require = true
+	if !cfg.IsAIEnabled() || !cfg.RequiresAI() {
+		t.Fatal("Expected AI to be enabled and required")
+	}
+
+	cfg.AIDisabled = true
+	if cfg.IsAIEnabled() {
+		t.Error("Expected AIDisabled to turn AI off")
+	}
+	if cfg.RequiresAI() {
+		t.Error("Expected AIDisabled to drop ai.require")
+	}
+}
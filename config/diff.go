@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Defaults are the values workie uses for settings that are not configured,
+// keyed by their path in the configuration file
+var Defaults = map[string]string{
+	"copy_backend":                       "native",
+	"copy_mode":                          "copy",
+	"hooks.timeout_minutes":              "5",
+	"ai.model.provider":                  "ollama",
+	"ai.model.name":                      "llama3.2",
+	"ai.model.temperature":               "0.7",
+	"ai.model.max_tokens":                "2048",
+	"ai.model.context_length":            "4096",
+	"ai.model.top_p":                     "0.9",
+	"ai.model.timeout":                   "60",
+	"ai.ollama.base_url":                 "http://localhost:11434",
+	"ai.ollama.keep_alive":               "5m",
+	"ai.openai.api_key_env":              "OPENAI_API_KEY",
+	"ai.ollama.num_thread":               "4",
+	"ai.ollama.num_gpu":                  "0",
+	"issue_cache.ttl":                    "5m",
+	"issue_worktree_template_path":       ".workie/PR_DESCRIPTION.md",
+	"worktree_dir_naming":                "nested",
+	"watch.interval_minutes":             "5",
+	"watch.port":                         "8080",
+	"watch.bind_address":                 "127.0.0.1",
+	"watch.notify_cooldown_minutes":      "60",
+	"tools.agent.max_iterations":         "5",
+	"tools.agent.max_calls_per_tool":     "10",
+	"tools.agent.timeout_seconds":        "120",
+	"tools.agent.max_consecutive_errors": "3",
+	"tools.git.allowed_commands":         "[status, branch, diff, log]",
+}
+
+// KeyDiff is a setting that differs between a configuration and the defaults
+type KeyDiff struct {
+	Key     string // Path of the setting, e.g. "hooks.timeout_minutes"
+	Value   string // Value in the configuration, for changed settings
+	Default string // Default value, if workie has one
+}
+
+// ConfigDiff compares a configuration file with what workie supports
+type ConfigDiff struct {
+	Changed []KeyDiff // Settings configured to something other than their default
+	Unset   []KeyDiff // Supported settings that are not configured: top-level ones, and those of configured sections
+	Unknown []string  // Settings workie does not recognize, e.g. misspelled or removed ones
+}
+
+// DiffAgainstDefaults compares the YAML configuration in data with the settings
+// workie supports and their defaults
+func DiffAgainstDefaults(data []byte) (*ConfigDiff, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	diff := &ConfigDiff{}
+	node := &root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		node = &yaml.Node{Kind: yaml.MappingNode}
+	}
+
+	diff.walk(node, "", configSchema(reflect.TypeOf(Config{})))
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Key < diff.Changed[j].Key })
+	sort.Slice(diff.Unset, func(i, j int) bool { return diff.Unset[i].Key < diff.Unset[j].Key })
+	sort.Strings(diff.Unknown)
+	return diff, nil
+}
+
+// walk compares a mapping node with the settings schema allows at prefix
+func (d *ConfigDiff) walk(node *yaml.Node, prefix string, schema map[string]reflect.Type) {
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		path := joinKey(prefix, key)
+		seen[key] = true
+
+		t, ok := schema[key]
+		if !ok {
+			d.Unknown = append(d.Unknown, path)
+			continue
+		}
+		if children := configSchema(t); children != nil && value.Kind == yaml.MappingNode {
+			d.walk(value, path, children)
+			continue
+		}
+		if def, ok := Defaults[path]; ok && value.Kind == yaml.ScalarNode && value.Value != def {
+			d.Changed = append(d.Changed, KeyDiff{Key: path, Value: value.Value, Default: def})
+		}
+	}
+
+	// Providers are alternatives rather than options, so absent ones aren't listed
+	if prefix == "providers" {
+		return
+	}
+	for key := range schema {
+		if !seen[key] {
+			path := joinKey(prefix, key)
+			d.Unset = append(d.Unset, KeyDiff{Key: path, Default: Defaults[path]})
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocalConfigNames are the personal override files merged over the shared
+// configuration found next to them. They are meant to be gitignored.
+var LocalConfigNames = []string{".workie.local.yaml", "workie.local.yaml"}
+
+// findLocalConfig returns the local override file in dir, or "" if there is none
+func findLocalConfig(dir string) string {
+	for _, name := range LocalConfigNames {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// MergeWorktreeHooks merges the hooks section of a .workie.local.yaml in a
+// worktree over hooks, so one worktree can have setup and teardown of its own.
+// As with the repository's local overrides, hook lists are extended and other
+// settings replaced. It returns the merged hooks and the file they came from,
+// or hooks and "" if the worktree has no local file with hooks.
+//
+// A local file committed on the worktree's branch is refused: it comes from
+// whoever wrote the branch rather than from the user, so its hooks could run
+// anything. So is any file when git can't tell whether it is tracked.
+func MergeWorktreeHooks(hooks *Hooks, worktreePath string) (*Hooks, string, error) {
+	path := findLocalConfig(worktreePath)
+	if path == "" {
+		return hooks, "", nil
+	}
+	tracked, err := isTracked(worktreePath, filepath.Base(path))
+	if err != nil {
+		return nil, "", err
+	}
+	if tracked {
+		return nil, "", fmt.Errorf("%s is committed on the branch; only an untracked local file may add hooks", path)
+	}
+
+	local, _, err := parseConfigFile(path, filepath.Base(path))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(local.Content) == 0 {
+		return hooks, "", nil
+	}
+	i := mappingValueIndex(local.Content[0], "hooks")
+	if i < 0 {
+		return hooks, "", nil
+	}
+
+	var base yaml.Node
+	if hooks != nil {
+		if err := base.Encode(hooks); err != nil {
+			return nil, "", fmt.Errorf("failed to merge hooks from %s: %w", path, err)
+		}
+	}
+	merged := &Hooks{}
+	if err := mergeNodes(&base, local.Content[0].Content[i]).Decode(merged); err != nil {
+		return nil, "", fmt.Errorf("failed to merge hooks from %s: %w", path, err)
+	}
+
+	if problems := merged.validate(); len(problems) > 0 {
+		for i := range problems {
+			problems[i].File = filepath.Base(path)
+		}
+		return nil, "", fmt.Errorf("invalid hooks in %s: %w", path, &ValidationError{Problems: problems})
+	}
+	return merged, path, nil
+}
+
+// isTracked reports whether git tracks name in dir. It fails closed: when git
+// can't tell, e.g. because dir is not in a repository, the error is returned.
+func isTracked(dir, name string) (bool, error) {
+	cmd := exec.Command("git", "ls-files", "--error-unmatch", "--", name)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		return false, nil // --error-unmatch: the file is not tracked
+	default:
+		return false, fmt.Errorf("cannot tell whether git tracks %s: %v %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+}
+
+// parseConfigFile reads a config file into a YAML document, checking that it
+// decodes into a Config so type errors name the file and line they are in.
+// Unknown settings are returned as warnings. file names a local override file,
+// as in Problem.File.
+func parseConfigFile(path, file string) (*yaml.Node, []Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse YAML from %s: %w", path, err)
+	}
+	if root.Kind == 0 {
+		return &root, nil, nil
+	}
+
+	errs, warnings := checkSchema(&root, file)
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("invalid configuration in %s: %w", path, &ValidationError{Problems: errs})
+	}
+	if err := root.Decode(&Config{}); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse YAML from %s: %w", path, err)
+	}
+	return &root, warnings, nil
+}
+
+// mergeNodes deep-merges override into base and returns the result: mappings
+// merge key by key, sequences are concatenated without duplicates, and anything
+// else in override replaces what base has
+func mergeNodes(base, override *yaml.Node) *yaml.Node {
+	if override == nil || override.Kind == 0 {
+		return base
+	}
+	if base == nil || base.Kind == 0 {
+		return override
+	}
+	if base.Kind == yaml.DocumentNode && override.Kind == yaml.DocumentNode {
+		if len(base.Content) == 0 {
+			return override
+		}
+		if len(override.Content) > 0 {
+			base.Content[0] = mergeNodes(base.Content[0], override.Content[0])
+		}
+		return base
+	}
+	if base.Kind != override.Kind {
+		return override
+	}
+
+	switch base.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(override.Content); i += 2 {
+			key, value := override.Content[i], override.Content[i+1]
+			if j := mappingValueIndex(base, key.Value); j >= 0 {
+				base.Content[j] = mergeNodes(base.Content[j], value)
+				continue
+			}
+			base.Content = append(base.Content, key, value)
+		}
+		return base
+	case yaml.SequenceNode:
+		for _, item := range override.Content {
+			if !containsNode(base.Content, item) {
+				base.Content = append(base.Content, item)
+			}
+		}
+		return base
+	default:
+		return override
+	}
+}
+
+// mappingValueIndex returns the index in a mapping node's content of the value
+// for key, or -1 if the key is missing
+func mappingValueIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// containsNode reports whether nodes holds an item with the same value as node,
+// e.g. the same files_to_copy entry or hook command listed in both files
+func containsNode(nodes []*yaml.Node, node *yaml.Node) bool {
+	var want interface{}
+	if node.Decode(&want) != nil {
+		return false
+	}
+	for _, existing := range nodes {
+		var got interface{}
+		if existing.Decode(&got) == nil && reflect.DeepEqual(got, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeFiles maps every node of a document to the name of the file it came from,
+// so problems in merged settings can point at the right file
+func nodeFiles(root *yaml.Node, name string, files map[*yaml.Node]string) {
+	files[root] = name
+	for _, child := range root.Content {
+		nodeFiles(child, name, files)
+	}
+}
@@ -0,0 +1,246 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// checkSchema compares a parsed config file with the settings Config accepts.
+// Values of the wrong type are returned as errors, since they cannot be loaded;
+// unknown settings are returned as warnings, since they are ignored. file names
+// a local override file, as in Problem.File.
+func checkSchema(root *yaml.Node, file string) (errs, warnings []Problem) {
+	node := root
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		node = node.Content[0]
+	}
+
+	c := &schemaChecker{file: file}
+	c.check(node, reflect.TypeOf(Config{}), "")
+	return c.errs, c.warnings
+}
+
+// schemaChecker collects the problems found by checkSchema
+type schemaChecker struct {
+	file     string
+	errs     []Problem
+	warnings []Problem
+}
+
+// problem locates a problem at node
+func (c *schemaChecker) problem(node *yaml.Node, field, message string) Problem {
+	return Problem{Field: field, Line: node.Line, Column: node.Column, File: c.file, Message: message}
+}
+
+// check compares node with the Go type it is decoded into
+func (c *schemaChecker) check(node *yaml.Node, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Tag == "!!null" || t.Kind() == reflect.Interface {
+		return
+	}
+
+	// CopyEntry and HookCommand are written as a plain string or as a mapping
+	if reflect.PointerTo(t).Implements(reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()) && node.Kind == yaml.ScalarNode {
+		return
+	}
+
+	switch {
+	case t == reflect.TypeOf(time.Duration(0)):
+		c.checkScalar(node, t, path)
+	case t.Kind() == reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			c.errs = append(c.errs, c.problem(node, path, "expected a mapping of settings, got "+describeNode(node)))
+			return
+		}
+		schema := configSchema(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinKey(path, key.Value)
+			fieldType, ok := schema[key.Value]
+			if !ok {
+				c.warnings = append(c.warnings, c.problem(key, keyPath, unknownSettingMessage(key.Value, path, schema)))
+				continue
+			}
+			c.check(value, fieldType, keyPath)
+		}
+	case t.Kind() == reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			c.errs = append(c.errs, c.problem(node, path, "expected a mapping, got "+describeNode(node)))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			c.check(node.Content[i+1], t.Elem(), joinKey(path, node.Content[i].Value))
+		}
+	case t.Kind() == reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			c.errs = append(c.errs, c.problem(node, path, "expected a list, got "+describeNode(node)+` (start each item on its own line with "- ")`))
+			return
+		}
+		for i, item := range node.Content {
+			c.check(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	default:
+		c.checkScalar(node, t, path)
+	}
+}
+
+// checkScalar checks that node holds a single value that decodes into t
+func (c *schemaChecker) checkScalar(node *yaml.Node, t reflect.Type, path string) {
+	expected := describeType(t)
+	if node.Kind != yaml.ScalarNode {
+		c.errs = append(c.errs, c.problem(node, path, fmt.Sprintf("expected %s, got %s", expected, describeNode(node))))
+		return
+	}
+	if err := node.Decode(reflect.New(t).Interface()); err != nil {
+		c.errs = append(c.errs, c.problem(node, path, fmt.Sprintf("expected %s, got %s", expected, describeNode(node))))
+	}
+}
+
+// describeType names what a setting of type t is written as
+func describeType(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return "a duration such as 90s or 10m"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a whole number"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	}
+	return "a string"
+}
+
+// describeNode names what a YAML node holds, e.g. `the string "abc"`
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.MappingNode:
+		return "a mapping"
+	}
+	switch node.Tag {
+	case "!!int", "!!float":
+		return fmt.Sprintf("the number %s", node.Value)
+	case "!!bool":
+		return fmt.Sprintf("the boolean %s", node.Value)
+	}
+	return fmt.Sprintf("the string %q", node.Value)
+}
+
+// unknownSettingMessage describes an unknown key, suggesting a similarly spelled
+// setting of the same section or the section a misplaced setting belongs in
+func unknownSettingMessage(key, section string, schema map[string]reflect.Type) string {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if d := editDistance(key, name); d <= 2 && d*3 <= len(key) {
+			return fmt.Sprintf("unknown setting; did you mean %s?", joinKey(section, name))
+		}
+	}
+	for _, path := range settingPaths() {
+		if path != joinKey(section, key) && (path == key || strings.HasSuffix(path, "."+key)) {
+			return fmt.Sprintf("unknown setting here; did you mean %s?", path)
+		}
+	}
+	return "unknown setting (it is ignored)"
+}
+
+// settingPaths lists the path of every setting Config accepts, e.g.
+// "hooks.timeout_minutes", in sorted order
+func settingPaths() []string {
+	var paths []string
+	var walk func(prefix string, schema map[string]reflect.Type)
+	walk = func(prefix string, schema map[string]reflect.Type) {
+		for name, t := range schema {
+			path := joinKey(prefix, name)
+			paths = append(paths, path)
+			if children := configSchema(t); children != nil {
+				walk(path, children)
+			}
+		}
+	}
+	walk("", configSchema(reflect.TypeOf(Config{})))
+	sort.Strings(paths)
+	return paths
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and
+// swaps of adjacent characters that turn a into b
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// configSchema returns the settings a struct type accepts, keyed by their YAML
+// name, or nil if t is not a section of settings. The free-form providers map
+// is described by the Providers struct.
+func configSchema(t reflect.Type) map[string]reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Duration(0)) {
+		return nil
+	}
+
+	schema := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		fieldType := field.Type
+		if t == reflect.TypeOf(Config{}) && name == "providers" {
+			fieldType = reflect.TypeOf(Providers{})
+		}
+		schema[name] = fieldType
+	}
+	return schema
+}
+
+// joinKey appends key to a dotted settings path
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
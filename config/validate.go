@@ -0,0 +1,341 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaxHooksPerEvent is the most commands a single hook event may run
+const MaxHooksPerEvent = 20
+
+// Problem is a configuration error found by validation
+type Problem struct {
+	Field   string // Path to the setting, e.g. "hooks.post_create[1]"
+	Line    int    // Line in the config file, or 0 if unknown
+	Column  int    // Column in the config file, or 0 if unknown
+	File    string // Local override file the line is in, or "" for the main config file
+	Message string // What is wrong with the setting
+}
+
+// Error formats the problem as "line 4: hooks.post_create[1]: empty command",
+// adding the column when it is known and naming the file when the setting
+// comes from a local override
+func (p Problem) Error() string {
+	msg := p.Field + ": " + p.Message
+	if p.Line == 0 {
+		return msg
+	}
+
+	location := fmt.Sprintf("line %d", p.Line)
+	if p.Column > 0 {
+		location += fmt.Sprintf(", column %d", p.Column)
+	}
+	if p.File != "" {
+		location = p.File + " " + location
+	}
+	return location + ": " + msg
+}
+
+// ValidationError reports every problem found in a configuration
+type ValidationError struct {
+	Problems []Problem
+}
+
+// Error lists the problems, one per line when there are several
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0].Error()
+	}
+	lines := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		lines[i] = "  • " + problem.Error()
+	}
+	return fmt.Sprintf("%d problems:\n%s", len(e.Problems), strings.Join(lines, "\n"))
+}
+
+var (
+	// dangerousCommand matches commands that can destroy a system or disk
+	dangerousCommand = regexp.MustCompile(`\brm\s+-[a-zA-Z]*[rR][a-zA-Z]*\s+(/|/\*|~/?|\*)(\s|;|&|\||$)|:\(\)\s*\{|\bmkfs(\.\w+)?\b|\bdd\s+if=|>\s*/dev/(sd|nvme|hd)|\bchmod\s+-R\s+777\s+/(\s|$)`)
+	// networkCommand matches commands that start servers or listeners
+	networkCommand = regexp.MustCompile(`\bhttp\.server\b|\bSimpleHTTPServer\b|\b(nc|ncat|netcat)\s+(-\w*\s+)*-\w*l`)
+	// privilegedCommand matches commands run through sudo, su or doas
+	privilegedCommand = regexp.MustCompile(`(^|[;&|(]\s*)(sudo|su|doas)(\s|$)`)
+)
+
+// Validate checks the configuration and returns every problem found
+func (c *Config) Validate() []Problem {
+	var problems []Problem
+
+	for i, entry := range c.FilesToCopy {
+		if err := entry.Validate(); err != nil {
+			problems = append(problems, Problem{Field: fmt.Sprintf("files_to_copy[%d]", i), Message: err.Error()})
+		}
+	}
+
+	if c.Hooks != nil {
+		problems = append(problems, c.Hooks.validate()...)
+	}
+
+	if c.IssueCache != nil && c.IssueCache.TTL < 0 {
+		problems = append(problems, Problem{Field: "issue_cache.ttl", Message: fmt.Sprintf("negative ttl %v", c.IssueCache.TTL)})
+	}
+
+	if provider := c.GetAIProvider(); !slices.Contains(AIProviders, provider) {
+		problems = append(problems, Problem{Field: "ai.model.provider", Message: fmt.Sprintf("unknown provider '%s' (use one of: %s)", c.AI.Model.Provider, strings.Join(AIProviders, ", "))})
+	}
+
+	if _, err := c.GetMaxCopySize(); err != nil {
+		problems = append(problems, Problem{Field: "max_copy_size", Message: err.Error()})
+	}
+
+	problems = append(problems, c.validateRanges()...)
+
+	return problems
+}
+
+// validateRanges checks that numbers are within their allowed range and that
+// settings with a fixed set of values use one of them. Zero leaves a number at
+// its default, so only negative values are rejected for counts and timeouts.
+func (c *Config) validateRanges() []Problem {
+	var problems []Problem
+	notNegative := func(field string, value int) {
+		if value < 0 {
+			problems = append(problems, Problem{Field: field, Message: fmt.Sprintf("must not be negative, got %d (leave it out to use the default)", value)})
+		}
+	}
+	between := func(field string, value, low, high float64) {
+		if value < low || value > high {
+			problems = append(problems, Problem{Field: field, Message: fmt.Sprintf("must be between %g and %g, got %g", low, high, value)})
+		}
+	}
+	oneOf := func(field, value string, allowed []string) {
+		if value != "" && !slices.Contains(allowed, strings.ToLower(value)) {
+			problems = append(problems, Problem{Field: field, Message: fmt.Sprintf("unknown value '%s' (use one of: %s)", value, strings.Join(allowed, ", "))})
+		}
+	}
+
+	oneOf("copy_backend", c.CopyBackend, CopyBackends)
+	oneOf("copy_mode", c.CopyMode, CopyModes)
+	oneOf("worktree_dir_naming", c.WorktreeDirNaming, WorktreeDirNamings)
+	notNegative("copy_concurrency", c.CopyConcurrency)
+
+	if c.Hooks != nil {
+		notNegative("hooks.timeout_minutes", c.Hooks.TimeoutMinutes)
+	}
+
+	between("ai.model.temperature", c.AI.Model.Temperature, 0, 2)
+	between("ai.model.top_p", c.AI.Model.TopP, 0, 1)
+	notNegative("ai.model.max_tokens", c.AI.Model.MaxTokens)
+	notNegative("ai.model.context_length", c.AI.Model.ContextLength)
+	notNegative("ai.model.timeout", c.AI.Model.Timeout)
+	notNegative("ai.ollama.num_thread", c.AI.Ollama.NumThread)
+	notNegative("ai.ollama.num_gpu", c.AI.Ollama.NumGPU)
+
+	if c.Watch != nil {
+		notNegative("watch.interval_minutes", c.Watch.IntervalMinutes)
+		notNegative("watch.notify_cooldown_minutes", c.Watch.NotifyCooldownMinutes)
+		if c.Watch.Port < 0 || c.Watch.Port > 65535 {
+			problems = append(problems, Problem{Field: "watch.port", Message: fmt.Sprintf("must be a port between 1 and 65535, got %d (leave it out to use 8080)", c.Watch.Port)})
+		}
+	}
+
+	if c.Tools != nil && c.Tools.Agent != nil {
+		agent := c.Tools.Agent
+		notNegative("tools.agent.max_iterations", agent.MaxIterations)
+		notNegative("tools.agent.max_calls_per_tool", agent.MaxCallsPerTool)
+		notNegative("tools.agent.timeout_seconds", agent.TimeoutSeconds)
+		notNegative("tools.agent.max_consecutive_errors", agent.MaxConsecutiveErrors)
+		tools := make([]string, 0, len(agent.ToolCallLimits))
+		for tool := range agent.ToolCallLimits {
+			tools = append(tools, tool)
+		}
+		slices.Sort(tools)
+		for _, tool := range tools {
+			notNegative("tools.agent.tool_call_limits."+tool, agent.ToolCallLimits[tool])
+		}
+	}
+
+	if c.Tools != nil && c.Tools.Git != nil {
+		for _, command := range c.Tools.Git.AllowedCommands {
+			if command == "" || strings.HasPrefix(command, "-") || strings.ContainsAny(command, " \t") {
+				problems = append(problems, Problem{Field: "tools.git.allowed_commands", Message: fmt.Sprintf("'%s' is not a git subcommand (list names like status or log)", command)})
+			}
+		}
+	}
+
+	return problems
+}
+
+// validate checks the commands of every hook event
+func (h *Hooks) validate() []Problem {
+	var problems []Problem
+
+	value := reflect.ValueOf(*h)
+	for i := 0; i < value.NumField(); i++ {
+		commands, ok := value.Field(i).Interface().([]HookCommand)
+		if !ok {
+			continue
+		}
+		event := strings.Split(value.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		problems = append(problems, validateHookCommands("hooks."+event, commands)...)
+	}
+
+	names := make([]string, 0, len(h.Env))
+	for name := range h.Env {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, "= \t") {
+			problems = append(problems, Problem{Field: "hooks.env", Message: fmt.Sprintf("'%s' is not a valid environment variable name", name)})
+		}
+	}
+
+	return problems
+}
+
+// validateHookCommands checks the commands configured for one hook event
+func validateHookCommands(field string, commands []HookCommand) []Problem {
+	var problems []Problem
+
+	if len(commands) > MaxHooksPerEvent {
+		problems = append(problems, Problem{Field: field, Message: fmt.Sprintf("%d commands configured; more than %d hooks per event might impact performance", len(commands), MaxHooksPerEvent)})
+	}
+
+	seen := make(map[string]int)
+	for i, hook := range commands {
+		command := hook.Command
+		commandField := fmt.Sprintf("%s[%d]", field, i)
+		if hook.Timeout < 0 {
+			problems = append(problems, Problem{Field: commandField + ".timeout", Message: fmt.Sprintf("negative timeout %v", hook.Timeout)})
+		}
+		if message := checkHookCommand(command); message != "" {
+			problems = append(problems, Problem{Field: commandField, Message: message})
+			continue
+		}
+		if first, ok := seen[command]; ok {
+			problems = append(problems, Problem{Field: commandField, Message: fmt.Sprintf("duplicate command '%s' (also at index %d)", command, first)})
+			continue
+		}
+		seen[command] = i
+	}
+
+	return problems
+}
+
+// checkHookCommand returns why a hook command is rejected, or "" if it is acceptable
+func checkHookCommand(command string) string {
+	switch {
+	case strings.TrimSpace(command) == "":
+		return "empty command"
+	case strings.TrimLeft(command, " \t") != command:
+		return "command starts with whitespace"
+	case hasUnbalancedQuotes(command):
+		return fmt.Sprintf("command '%s' has unbalanced quotes", command)
+	case dangerousCommand.MatchString(command):
+		return fmt.Sprintf("potentially dangerous command '%s'", command)
+	case privilegedCommand.MatchString(command):
+		return fmt.Sprintf("command '%s' uses privilege escalation", command)
+	case networkCommand.MatchString(command):
+		return fmt.Sprintf("potentially risky network command '%s'", command)
+	}
+	return ""
+}
+
+// hasUnbalancedQuotes reports whether a shell command leaves a quote open
+func hasUnbalancedQuotes(command string) bool {
+	var quote rune
+	escaped := false
+	for _, r := range command {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote == 0 && (r == '\'' || r == '"'):
+			quote = r
+		case r == quote:
+			quote = 0
+		}
+	}
+	return quote != 0
+}
+
+// validateLoaded validates a loaded configuration, locating each problem in the
+// config file when it can be parsed
+func (c *Config) validateLoaded(configPath string) error {
+	var root *yaml.Node
+	if data, err := os.ReadFile(configPath); err == nil {
+		var doc yaml.Node
+		if yaml.Unmarshal(data, &doc) == nil {
+			root = &doc
+		}
+	}
+	return c.validateDocument(root, nil)
+}
+
+// validateDocument validates a configuration decoded from root, locating each
+// problem in it. files names the local override file of nodes merged from one.
+func (c *Config) validateDocument(root *yaml.Node, files map[*yaml.Node]string) error {
+	problems := c.Validate()
+	if len(problems) == 0 {
+		return nil
+	}
+
+	if root != nil {
+		for i := range problems {
+			if node := fieldNode(root, problems[i].Field); node != nil {
+				problems[i].Line = node.Line
+				problems[i].File = files[node]
+			}
+		}
+	}
+
+	return &ValidationError{Problems: problems}
+}
+
+// fieldNode returns the node of a field path like "hooks.post_create[1]" in a
+// parsed YAML document, or nil if it cannot be found. An index past the end of
+// a sequence returns the sequence.
+func fieldNode(root *yaml.Node, field string) *yaml.Node {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, part := range strings.Split(field, ".") {
+		key, index, hasIndex := strings.Cut(part, "[")
+		node = mappingValue(node, key)
+		if node == nil {
+			return nil
+		}
+		if hasIndex {
+			i, err := strconv.Atoi(strings.TrimSuffix(index, "]"))
+			if err != nil || node.Kind != yaml.SequenceNode || i >= len(node.Content) {
+				return node
+			}
+			node = node.Content[i]
+		}
+	}
+	return node
+}
+
+// mappingValue returns the value for key in a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
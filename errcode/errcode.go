@@ -0,0 +1,99 @@
+// Package errcode classifies errors with stable codes, so tools that drive
+// workie (editor extensions, GUIs) can tell failures apart without parsing
+// the human-readable messages.
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Code identifies a kind of failure. Codes are part of workie's interface for
+// tools and do not change once released.
+type Code string
+
+const (
+	Unknown               Code = "error"                   // Not classified
+	Usage                 Code = "usage"                   // Invalid flags or arguments
+	NotARepo              Code = "not-a-repo"              // Not run inside a git repository
+	BranchExists          Code = "branch-exists"           // The branch to create already exists
+	BranchNotFound        Code = "branch-not-found"        // The branch to check out does not exist
+	BranchCheckedOut      Code = "branch-checked-out"      // The branch is checked out in another worktree
+	WorktreeExists        Code = "worktree-exists"         // The worktree directory already exists
+	WorktreeNotFound      Code = "worktree-not-found"      // No worktree for the given branch
+	UncommittedChanges    Code = "uncommitted-changes"     // The worktree has changes that would be lost
+	ConfigNotFound        Code = "config-not-found"        // The --config file does not exist
+	ConfigInvalid         Code = "config-invalid"          // The configuration cannot be parsed or fails validation
+	HookFailed            Code = "hook-failed"             // A hook that can abort the command failed
+	ProviderNotConfigured Code = "provider-not-configured" // The issue provider is missing or lacks settings
+	ProviderAmbiguous     Code = "provider-ambiguous"      // A bare issue ID could belong to several providers
+	ProviderAuth          Code = "provider-auth"           // The provider rejected the credentials
+	ProviderRateLimited   Code = "provider-rate-limited"   // The provider's rate limit was exceeded
+	NotFound              Code = "not-found"               // The provider has no such issue or resource
+	RebaseConflicts       Code = "rebase-conflicts"        // Branches would conflict rebasing on the main branch
+)
+
+// Error is an error classified with a Code. Its message is that of the
+// underlying error.
+type Error struct {
+	Code Code
+	Err  error
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Wrap classifies err with code; a nil err stays nil
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// Errorf formats an error, as fmt.Errorf does, classified with code
+func Errorf(code Code, format string, a ...interface{}) error {
+	return &Error{Code: code, Err: fmt.Errorf(format, a...)}
+}
+
+// Of returns the code of the outermost classified error in err's chain, or
+// Unknown if there is none
+func Of(err error) Code {
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return Unknown
+}
+
+// hintMarker introduces the list of suggestions at the end of workie's errors
+const hintMarker = "\n\nTo fix this:\n"
+
+// Report is the JSON form of an error printed by --json-errors
+type Report struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"` // Suggestions, one per line
+}
+
+// NewReport describes err for tools. The "To fix this:" suggestions of the
+// message become the hint, without their bullets.
+func NewReport(err error) Report {
+	message, hints, _ := strings.Cut(err.Error(), hintMarker)
+
+	var lines []string
+	for _, line := range strings.Split(hints, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "•"))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return Report{
+		Code:    Of(err),
+		Message: strings.TrimSpace(message),
+		Hint:    strings.Join(lines, "\n"),
+	}
+}
@@ -0,0 +1,43 @@
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestOf(t *testing.T) {
+	base := Errorf(BranchExists, "branch 'main' already exists")
+	if got := Of(base); got != BranchExists {
+		t.Errorf("Expected %s, got %s", BranchExists, got)
+	}
+	if got := Of(fmt.Errorf("begin failed: %w", base)); got != BranchExists {
+		t.Errorf("Expected the code to survive wrapping, got %s", got)
+	}
+	if got := Of(Wrap(ConfigInvalid, fmt.Errorf("load: %w", base))); got != ConfigInvalid {
+		t.Errorf("Expected the outermost code, got %s", got)
+	}
+	if got := Of(errors.New("plain")); got != Unknown {
+		t.Errorf("Expected %s for an unclassified error, got %s", Unknown, got)
+	}
+	if Wrap(Usage, nil) != nil {
+		t.Error("Expected Wrap to keep nil errors nil")
+	}
+	if base.Error() != "branch 'main' already exists" {
+		t.Errorf("Expected the message to be unchanged, got %q", base.Error())
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	err := Errorf(NotARepo, "not in a git repository\n\nTo fix this:\n  • Change into a repository\n  • Or run: git init")
+	report := NewReport(fmt.Errorf("failed: %w", err))
+	want := Report{Code: NotARepo, Message: "failed: not in a git repository", Hint: "Change into a repository\nOr run: git init"}
+	if report != want {
+		t.Errorf("NewReport() = %+v, want %+v", report, want)
+	}
+
+	report = NewReport(errors.New("something broke"))
+	if report != (Report{Code: Unknown, Message: "something broke"}) {
+		t.Errorf("Expected a report without a hint, got %+v", report)
+	}
+}
@@ -0,0 +1,210 @@
+// Package ignore implements .workieignore files, which use gitignore syntax to
+// exclude paths from worktree file copying and from the AI tools.
+package ignore
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FileName is the name of the ignore file at the repository root
+const FileName = ".workieignore"
+
+// rule is a single compiled pattern from an ignore file
+type rule struct {
+	pattern string
+	re      *regexp.Regexp
+	negate  bool // Pattern started with "!" and re-includes matching paths
+	dirOnly bool // Pattern ended with "/" and only matches directories
+}
+
+// Matcher reports whether repository-relative paths are ignored.
+// A nil Matcher ignores nothing.
+type Matcher struct {
+	rules []rule
+}
+
+// Load reads the .workieignore file in root. A missing file yields a nil
+// Matcher and no error.
+func Load(root string) (*Matcher, error) {
+	path := filepath.Join(root, FileName)
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	m, err := Parse(lines)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Parse compiles gitignore-style pattern lines into a Matcher
+func Parse(lines []string) (*Matcher, error) {
+	m := &Matcher{}
+	for i, line := range lines {
+		r, ok, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if ok {
+			m.rules = append(m.rules, r)
+		}
+	}
+	return m, nil
+}
+
+// parseLine compiles one line, returning ok=false for blank lines and comments
+func parseLine(line string) (rule, bool, error) {
+	// Trailing spaces are ignored unless escaped
+	line = strings.TrimRight(line, " \t\r")
+	if strings.HasSuffix(line, "\\") {
+		line += " "
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return rule{}, false, nil
+	}
+
+	r := rule{pattern: line}
+	if strings.HasPrefix(line, "!") {
+		r.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, "\\!") || strings.HasPrefix(line, "\\#") {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if line == "" {
+		return rule{}, false, nil
+	}
+
+	// Patterns containing a slash are anchored to the root; others match at any depth
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := globToRegexp(line)
+	if anchored {
+		expr = "^" + expr + "$"
+	} else {
+		expr = "^(?:.*/)?" + expr + "$"
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return rule{}, false, fmt.Errorf("invalid pattern %q: %w", r.pattern, err)
+	}
+	r.re = re
+	return r, true, nil
+}
+
+// globToRegexp translates a gitignore glob into a regular expression fragment
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				atStart := i == 0 || glob[i-1] == '/'
+				i++ // consume second '*'
+				if atStart && i+1 < len(glob) && glob[i+1] == '/' {
+					// "**/" matches zero or more directories
+					i++
+					b.WriteString("(?:.*/)?")
+				} else if atStart && i+1 == len(glob) {
+					// Trailing "/**" matches everything inside
+					b.WriteString(".*")
+				} else {
+					b.WriteString("[^/]*")
+				}
+				continue
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, "\\", "\\\\") + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// Empty reports whether the matcher has no rules, so nothing is ignored
+func (m *Matcher) Empty() bool {
+	return m == nil || len(m.rules) == 0
+}
+
+// Match reports whether the repository-relative path is ignored. As with git,
+// a path inside an ignored directory is ignored regardless of later rules.
+func (m *Matcher) Match(relPath string, isDir bool) bool {
+	if m == nil || len(m.rules) == 0 {
+		return false
+	}
+
+	relPath = filepath.ToSlash(filepath.Clean(relPath))
+	if relPath == "." || relPath == ".." || strings.HasPrefix(relPath, "../") {
+		return false
+	}
+
+	// Check each parent directory first
+	parts := strings.Split(relPath, "/")
+	for i := 1; i < len(parts); i++ {
+		if m.matchOne(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+
+	return m.matchOne(relPath, isDir)
+}
+
+// matchOne applies the rules to a single path; the last matching rule wins
+func (m *Matcher) matchOne(path string, isDir bool) bool {
+	ignored := false
+	for _, r := range m.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		if r.re.MatchString(path) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
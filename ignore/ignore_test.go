@@ -0,0 +1,81 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	m, err := Parse([]string{
+		"# secrets never leave the main checkout",
+		"secrets/",
+		".env",
+		"*.log",
+		"!keep.log",
+		"/build",
+		"docs/**/*.pdf",
+		"assets/**",
+		"",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	tests := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"secrets", true, true},
+		{"secrets/api.key", false, true},
+		{"nested/secrets/api.key", false, true},
+		{"secrets", false, false}, // dir-only rule does not match a file
+		{".env", false, true},
+		{"config/.env", false, true},
+		{".env.example", false, false},
+		{"app.log", false, true},
+		{"logs/keep.log", false, false},
+		{"build", true, true},
+		{"build/out.bin", false, true},
+		{"src/build", true, false}, // anchored to root
+		{"docs/guide.pdf", false, true},
+		{"docs/a/b/guide.pdf", false, true},
+		{"docs/guide.md", false, false},
+		{"assets/img/logo.png", false, true},
+		{"main.go", false, false},
+		{"../outside/.env", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := m.Match(tt.path, tt.isDir); got != tt.ignored {
+			t.Errorf("Match(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.ignored)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		m, err := Load(t.TempDir())
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if m.Match(".env", false) {
+			t.Error("Expected nil matcher to ignore nothing")
+		}
+	})
+
+	t.Run("reads file", func(t *testing.T) {
+		root := t.TempDir()
+		if err := os.WriteFile(filepath.Join(root, FileName), []byte("secrets/\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		m, err := Load(root)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !m.Match("secrets/token", false) {
+			t.Error("Expected secrets/token to be ignored")
+		}
+	})
+}
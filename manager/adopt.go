@@ -0,0 +1,140 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/agoodway/workie/errcode"
+)
+
+// UseMainWorktree points the manager at the repository's main worktree when it
+// was detected from inside a linked one, so the configuration and worktrees
+// directory are those of the main worktree
+func (wm *WorktreeManager) UseMainWorktree() error {
+	worktrees, err := wm.GetWorktrees()
+	if err != nil {
+		return err
+	}
+	if len(worktrees) == 0 || canonicalPath(worktrees[0].Path) == canonicalPath(wm.RepoPath) {
+		return nil
+	}
+
+	wm.RepoPath = worktrees[0].Path
+	wm.RepoName = filepath.Base(wm.RepoPath)
+	wm.WorktreesDir = filepath.Join(filepath.Dir(wm.RepoPath), wm.RepoName+"-worktrees")
+	return nil
+}
+
+// AdoptWorktree brings a worktree created outside workie, e.g. with git worktree
+// add, under its management. With move it is first moved to its place under
+// WorktreesDir; then files are copied, metadata recorded and hooks run as for a
+// worktree created by begin. It returns the worktree's path.
+func (wm *WorktreeManager) AdoptWorktree(path string, move bool) (string, error) {
+	wt, err := wm.findAdoptable(path)
+	if err != nil {
+		return "", err
+	}
+
+	worktreePath := wt.Path
+	target := wm.WorktreePath(wt.Branch)
+	move = move && canonicalPath(worktreePath) != canonicalPath(target)
+	if move {
+		if _, err := os.Stat(target); err == nil {
+			return "", errcode.Errorf(errcode.WorktreeExists, "cannot move the worktree to %s: the path already exists\n\nTo fix this:\n  • Remove or rename what is there\n  • Or adopt the worktree where it is by dropping --move", target)
+		}
+		if collision := wm.nestedPathCollision(target); collision != "" {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: %s collides with %s; set worktree_dir_naming: flat to keep worktrees one level deep\n", target, collision)
+		}
+	}
+
+	if wm.Options.DryRun {
+		fmt.Printf("🔍 Dry run: no changes will be made\n")
+		fmt.Printf("   Branch: %s\n", wt.Branch)
+		fmt.Printf("   Path: %s\n", worktreePath)
+		if move {
+			fmt.Printf("📝 Would run: git worktree move %s %s\n", worktreePath, target)
+			worktreePath = target
+		}
+		if wm.Options.NoCopy {
+			fmt.Printf("📂 Configured files would not be copied (--no-copy)\n")
+		} else if err := wm.copyConfiguredFiles(worktreePath); err != nil {
+			return "", fmt.Errorf("failed to list configured files: %w", err)
+		}
+		fmt.Printf("📝 Would record the worktree in %s\n", wm.MetadataIndexPath())
+		if wm.HasPostCreateHooks() {
+			fmt.Printf("🪝 post_create hooks that would run in %s:\n", worktreePath)
+			for _, hook := range wm.Config.Hooks.PostCreate {
+				fmt.Printf("   • %s\n", hook)
+			}
+		}
+		return worktreePath, nil
+	}
+
+	if move {
+		if err := wm.moveWorktree(worktreePath, target); err != nil {
+			return "", err
+		}
+		worktreePath = target
+	}
+
+	// Adopting is about recording the worktree, so setupWorktree writes its
+	// metadata even when worktree_metadata is off
+	wm.Metadata.AdoptedFrom = wt.Path
+	wm.HookTarget = HookTarget{Branch: wt.Branch, WorktreePath: worktreePath}
+	if err := wm.setupWorktree(wt.Branch, worktreePath); err != nil {
+		return "", err
+	}
+
+	return worktreePath, nil
+}
+
+// findAdoptable returns the linked worktree of this repository at path, which
+// must have a branch checked out
+func (wm *WorktreeManager) findAdoptable(path string) (WorktreeInfo, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return WorktreeInfo{}, fmt.Errorf("invalid path '%s': %w", path, err)
+	}
+
+	worktrees, err := wm.GetWorktrees()
+	if err != nil {
+		return WorktreeInfo{}, err
+	}
+
+	for i, wt := range worktrees {
+		if canonicalPath(wt.Path) != canonicalPath(abs) {
+			continue
+		}
+		switch {
+		case i == 0:
+			return wt, errcode.Errorf(errcode.Usage, "%s is the repository's main worktree, which workie already manages\n\nTo fix this:\n  • Pass the path of a worktree created with git worktree add", wt.Path)
+		case wt.Prunable:
+			return wt, errcode.Errorf(errcode.WorktreeNotFound, "the worktree at %s no longer exists\n\nTo fix this:\n  • Drop its record with: workie prune", wt.Path)
+		case wt.Branch == "":
+			return wt, errcode.Errorf(errcode.Usage, "the worktree at %s has a detached HEAD; workie tracks worktrees by branch\n\nTo fix this:\n  • Check out a branch in it: git -C %s switch -c <branch>", wt.Path, wt.Path)
+		}
+		return wt, nil
+	}
+
+	return WorktreeInfo{}, errcode.Errorf(errcode.WorktreeNotFound, "%s is not a worktree of %s\n\nTo fix this:\n  • List the repository's worktrees: git worktree list\n  • Or create a new one with: workie begin <branch>", abs, wm.RepoPath)
+}
+
+// moveWorktree moves a worktree with git worktree move, creating the parent of
+// the new location first
+func (wm *WorktreeManager) moveWorktree(from, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(to), err)
+	}
+
+	wm.printf("📝 Moving worktree to %s...\n", to)
+	cmd := exec.Command("git", "worktree", "move", from, to)
+	cmd.Dir = wm.RepoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to move the worktree: %s\n\nTo fix this:\n  • Unlock it if it is locked: git worktree unlock %s\n  • Worktrees with submodules can't be moved; adopt it where it is by dropping --move", strings.TrimSpace(string(output)), from)
+	}
+	wm.printf("✓ Moved worktree from %s\n", from)
+	return nil
+}
@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agoodway/workie/config"
+	"github.com/agoodway/workie/errcode"
+)
+
+// newAdoptTestRepo creates a repository with a worktree for feature/manual made
+// by git worktree add outside the worktrees directory, and returns a manager
+// for the repository and the worktree's path
+func newAdoptTestRepo(t *testing.T) (*WorktreeManager, string) {
+	t.Helper()
+	wm := newStashTestRepo(t)
+	wm.Options.MoveChanges = false
+	if err := os.WriteFile(filepath.Join(wm.RepoPath, ".env"), []byte("SECRET=1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wm.Config = &config.Config{FilesToCopy: []config.CopyEntry{{Src: ".env"}}}
+
+	manual := filepath.Join(filepath.Dir(wm.RepoPath), "manual")
+	runGit(t, wm.RepoPath, "worktree", "add", "-q", "-b", "feature/manual", manual)
+	return wm, manual
+}
+
+func TestAdoptWorktree(t *testing.T) {
+	wm, manual := newAdoptTestRepo(t)
+
+	path, err := wm.AdoptWorktree(manual, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if path != manual {
+		t.Errorf("Expected the worktree to stay at %s, got %s", manual, path)
+	}
+	if _, err := os.Stat(filepath.Join(manual, ".env")); err != nil {
+		t.Errorf("Expected .env to be copied into the worktree: %v", err)
+	}
+
+	// Metadata is recorded even though worktree_metadata is off
+	meta, err := wm.GetWorktreeMetadata("feature/manual")
+	if err != nil {
+		t.Fatalf("Expected metadata, got: %v", err)
+	}
+	if meta.AdoptedFrom != manual || meta.Path != manual {
+		t.Errorf("Expected metadata for %s, got %+v", manual, meta)
+	}
+	if index, _ := wm.LoadMetadataIndex(); index["feature/manual"].Path != manual {
+		t.Errorf("Expected the worktree in the index, got %+v", index)
+	}
+}
+
+func TestAdoptWorktreeMove(t *testing.T) {
+	wm, manual := newAdoptTestRepo(t)
+	wm.Options.NoCopy = true
+
+	path, err := wm.AdoptWorktree(manual, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := filepath.Join(wm.WorktreesDir, "feature", "manual")
+	if path != want {
+		t.Errorf("Expected the worktree to move to %s, got %s", want, path)
+	}
+	if _, err := os.Stat(manual); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be gone, got: %v", manual, err)
+	}
+	if found, err := wm.FindWorktree("feature/manual"); err != nil || found != want {
+		t.Errorf("Expected git to know the new location, got %s, %v", found, err)
+	}
+	if _, err := os.Stat(filepath.Join(want, ".env")); !os.IsNotExist(err) {
+		t.Errorf("Expected --no-copy to skip .env, got: %v", err)
+	}
+}
+
+func TestAdoptWorktreeRejects(t *testing.T) {
+	wm, manual := newAdoptTestRepo(t)
+	runGit(t, manual, "switch", "-q", "--detach")
+
+	tests := []struct {
+		name string
+		path string
+		want errcode.Code
+	}{
+		{"main worktree", wm.RepoPath, errcode.Usage},
+		{"detached HEAD", manual, errcode.Usage},
+		{"not a worktree", t.TempDir(), errcode.WorktreeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := wm.AdoptWorktree(tt.path, false)
+			if got := errcode.Of(err); got != tt.want {
+				t.Errorf("Expected %s, got %s (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agoodway/workie/config"
+)
+
+// writeBenchTree creates dirs*filesPerDir files of the given size under root
+func writeBenchTree(b *testing.B, root string, dirs, filesPerDir, size int) int64 {
+	b.Helper()
+	content := []byte(strings.Repeat("x", size))
+	var total int64
+	for d := 0; d < dirs; d++ {
+		dir := filepath.Join(root, fmt.Sprintf("dir%02d", d), "nested")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			b.Fatal(err)
+		}
+		for f := 0; f < filesPerDir; f++ {
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%03d.txt", f)), content, 0644); err != nil {
+				b.Fatal(err)
+			}
+			total += int64(size)
+		}
+	}
+	return total
+}
+
+func BenchmarkCopyDirectory(b *testing.B) {
+	sizes := []struct {
+		name        string
+		dirs, files int
+		size        int
+	}{
+		{"many-small-files", 20, 50, 1 << 10},
+		{"few-large-files", 4, 4, 4 << 20},
+	}
+
+	for _, s := range sizes {
+		b.Run(s.name, func(b *testing.B) {
+			src := b.TempDir()
+			total := writeBenchTree(b, src, s.dirs, s.files, s.size)
+
+			wm := New()
+			wm.Options.Quiet = true
+			wm.Config = &config.Config{}
+
+			b.SetBytes(total)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				dst := filepath.Join(b.TempDir(), "out")
+				if err := wm.copyDirectory(src, dst); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkCopyDirectoryConcurrency compares copying a tree of many small files
+// one at a time with copying them on a worker pool
+func BenchmarkCopyDirectoryConcurrency(b *testing.B) {
+	src := b.TempDir()
+	total := writeBenchTree(b, src, 20, 100, 4<<10)
+
+	for _, c := range []struct {
+		name        string
+		concurrency int
+	}{
+		{"serial", 1},
+		{"parallel", 0}, // default: number of CPUs
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			wm := New()
+			wm.Options.Quiet = true
+			wm.Config = &config.Config{CopyConcurrency: c.concurrency}
+
+			b.SetBytes(total)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				dst := filepath.Join(b.TempDir(), "out")
+				if err := wm.copyDirectory(src, dst); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// runGit runs a git command in dir for test and benchmark fixtures
+func runGit(tb testing.TB, dir string, args ...string) {
+	tb.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=bench", "-c", "user.email=bench@example.com"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		tb.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+func BenchmarkCheckRebaseConflicts(b *testing.B) {
+	if _, err := exec.LookPath("git"); err != nil {
+		b.Skip("git not available")
+	}
+
+	root := b.TempDir()
+	origin := filepath.Join(root, "origin.git")
+	repo := filepath.Join(root, "repo")
+
+	runGit(b, root, "init", "--quiet", "--bare", "--initial-branch=main", origin)
+	runGit(b, root, "clone", "--quiet", origin, repo)
+	runGit(b, repo, "checkout", "--quiet", "-B", "main")
+	for i := 0; i < 20; i++ {
+		if err := os.WriteFile(filepath.Join(repo, fmt.Sprintf("file%02d.txt", i)), []byte("base\n"), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	runGit(b, repo, "add", ".")
+	runGit(b, repo, "commit", "--quiet", "-m", "base")
+	runGit(b, repo, "push", "--quiet", "origin", "main")
+
+	// Half of the branches touch a file that main also changes, so they conflict
+	const branches = 8
+	for i := 0; i < branches; i++ {
+		branch := fmt.Sprintf("feature-%d", i)
+		wtPath := filepath.Join(root, "worktrees", branch)
+		runGit(b, repo, "worktree", "add", "--quiet", "-b", branch, wtPath)
+
+		file := fmt.Sprintf("file%02d.txt", 10+i)
+		if i%2 == 0 {
+			file = "file00.txt"
+		}
+		if err := os.WriteFile(filepath.Join(wtPath, file), []byte(branch+"\n"), 0644); err != nil {
+			b.Fatal(err)
+		}
+		runGit(b, wtPath, "commit", "--quiet", "-am", branch)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "file00.txt"), []byte("main\n"), 0644); err != nil {
+		b.Fatal(err)
+	}
+	runGit(b, repo, "commit", "--quiet", "-am", "main change")
+	runGit(b, repo, "push", "--quiet", "origin", "main")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.Config = &config.Config{}
+
+	// Sanity check the fixture before measuring
+	conflicts, err := wm.CheckRebaseConflicts()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if len(conflicts) != branches/2 {
+		b.Fatalf("Expected %d conflicting branches, got %d", branches/2, len(conflicts))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := wm.CheckRebaseConflicts(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/agoodway/workie/ai"
 	"github.com/agoodway/workie/config"
 )
 
@@ -46,6 +45,10 @@ var hookTypeMapping = map[string]string{
 
 // GenerateClaudeConfig generates Claude Code settings configuration
 func (wm *WorktreeManager) GenerateClaudeConfig(selectedHooks []string, useAI bool) (string, error) {
+	if useAI && wm.Config.RequiresAI() && !wm.Config.IsAIEnabled() {
+		return "", fmt.Errorf("AI requested but AI is not configured (ai.require is set)")
+	}
+
 	// Determine which hooks to include
 	hooksToInclude := make(map[string]bool)
 
@@ -92,6 +95,9 @@ func (wm *WorktreeManager) GenerateClaudeConfig(selectedHooks []string, useAI bo
 				// For tool-specific hooks, add a matcher for common tools
 				if useAI {
 					matcher, err := wm.generateMatcherWithAI(workieHook)
+					if err != nil && wm.Config.RequiresAI() {
+						return "", fmt.Errorf("AI matcher generation failed: %w", err)
+					}
 					if err == nil && matcher != "" {
 						entry.Matcher = matcher
 					}
@@ -114,6 +120,9 @@ func (wm *WorktreeManager) GenerateClaudeConfig(selectedHooks []string, useAI bo
 	// If AI is enabled, enhance the configuration
 	if useAI && wm.Config != nil && wm.Config.IsAIEnabled() {
 		enhancedConfig, err := wm.enhanceConfigWithAI(config)
+		if err != nil && wm.Config.RequiresAI() {
+			return "", fmt.Errorf("AI configuration enhancement failed: %w", err)
+		}
 		if err == nil {
 			config = enhancedConfig
 		}
@@ -196,7 +205,7 @@ func (wm *WorktreeManager) generateMatcherWithAI(hookType string) (string, error
 	}
 
 	// Create AI service
-	aiService, err := ai.NewService(wm.Config)
+	aiService, err := wm.newAIService()
 	if err != nil {
 		return "", err
 	}
@@ -226,7 +235,7 @@ Example: Write|Edit|Bash`, hookType, hookType)
 
 	// Call AI
 	ctx := context.Background()
-	response, err := aiService.CallLLM(ctx, prompt)
+	response, err := aiService.CallLLM(ctx, "hook_matcher", prompt)
 	if err != nil {
 		return "", err
 	}
@@ -245,7 +254,7 @@ func (wm *WorktreeManager) enhanceConfigWithAI(config ClaudeHooksConfig) (Claude
 	}
 
 	// Create AI service
-	aiService, err := ai.NewService(wm.Config)
+	aiService, err := wm.newAIService()
 	if err != nil {
 		return config, err
 	}
@@ -292,7 +301,7 @@ Respond with ONLY the enhanced JSON configuration, nothing else.`, string(curren
 
 	// Call AI
 	ctx := context.Background()
-	response, err := aiService.CallLLM(ctx, prompt)
+	response, err := aiService.CallLLM(ctx, "hook_config", prompt)
 	if err != nil {
 		return config, err
 	}
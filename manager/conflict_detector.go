@@ -2,10 +2,16 @@ package manager
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/agoodway/workie/errcode"
 )
 
 // ConflictInfo represents information about a potential rebase conflict
@@ -19,9 +25,10 @@ type ConflictInfo struct {
 
 // WorktreeInfo represents information about a git worktree
 type WorktreeInfo struct {
-	Path   string
-	Branch string
-	Commit string
+	Path     string
+	Branch   string
+	Commit   string
+	Prunable bool // The directory is gone; git worktree prune will drop the record
 }
 
 // GetWorktrees retrieves all worktrees for the repository
@@ -55,6 +62,8 @@ func (wm *WorktreeManager) GetWorktrees() ([]WorktreeInfo, error) {
 		} else if strings.HasPrefix(line, "branch ") {
 			current.Branch = strings.TrimPrefix(line, "branch ")
 			current.Branch = strings.TrimPrefix(current.Branch, "refs/heads/")
+		} else if line == "prunable" || strings.HasPrefix(line, "prunable ") {
+			current.Prunable = true
 		}
 	}
 
@@ -65,8 +74,26 @@ func (wm *WorktreeManager) GetWorktrees() ([]WorktreeInfo, error) {
 	return worktrees, nil
 }
 
-// GetMainBranch determines the main/master branch name
+// GetMainBranch determines the main/master branch name. The result is reused
+// until RepoPath changes or ClearRepoCache is called.
 func (wm *WorktreeManager) GetMainBranch() (string, error) {
+	wm.repo.mu.Lock()
+	defer wm.repo.mu.Unlock()
+	if wm.repo.mainBranch != "" && wm.repo.mainBranchRepo == wm.RepoPath {
+		return wm.repo.mainBranch, nil
+	}
+
+	branch, err := wm.detectMainBranch()
+	if err != nil {
+		return "", err
+	}
+	wm.repo.mainBranch, wm.repo.mainBranchRepo = branch, wm.RepoPath
+	return branch, nil
+}
+
+// detectMainBranch asks git for the main branch: a local main or master, or
+// origin's default branch
+func (wm *WorktreeManager) detectMainBranch() (string, error) {
 	// Try common main branch names
 	branches := []string{"main", "master"}
 
@@ -90,11 +117,75 @@ func (wm *WorktreeManager) GetMainBranch() (string, error) {
 		return branch, nil
 	}
 
-	return "main", nil // Default to main if nothing else works
+	return "", errcode.Errorf(errcode.BranchNotFound, "could not determine the main branch: there is no local main or master branch and origin/HEAD is not set\n\nTo fix this:\n  • Let git look up origin's default branch: git remote set-head origin --auto\n  • Or pass the branch with --base where available\n  • Or, for conflict checks, set watch.main_branch in .workie.yaml")
+}
+
+// conflictBaseBranch returns the branch worktrees are checked against for
+// conflicts: watch.main_branch if set, otherwise the detected main branch
+func (wm *WorktreeManager) conflictBaseBranch() (string, error) {
+	if wm.Config != nil && wm.Config.Watch != nil && wm.Config.Watch.MainBranch != "" {
+		return wm.Config.Watch.MainBranch, nil
+	}
+	return wm.GetMainBranch()
+}
+
+// ConflictCheckOptions controls which worktree branches are checked for conflicts
+type ConflictCheckOptions struct {
+	IncludeMain bool     // Also check whether the main branch has diverged from origin
+	Branches    []string // Glob patterns; when set, only matching branches are checked
+}
+
+// matchesBranches reports whether branch should be checked under the Branches filter
+func (o ConflictCheckOptions) matchesBranches(branch string) bool {
+	if len(o.Branches) == 0 {
+		return true
+	}
+	for _, pattern := range o.Branches {
+		if matched, _ := filepath.Match(pattern, branch); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that all branch patterns are valid globs
+func (o ConflictCheckOptions) Validate() error {
+	for _, pattern := range o.Branches {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid branch pattern '%s': %w\n\nTo fix this:\n  • Use shell glob syntax, e.g. 'feature/*' or 'fix-*'\n  • Escape literal brackets with a backslash", pattern, err)
+		}
+	}
+	return nil
+}
+
+// IgnoresBranch reports whether branch matches one of the watch.branches_to_ignore
+// glob patterns, whose conflicts are not reported
+func (wm *WorktreeManager) IgnoresBranch(branch string) bool {
+	if wm.Config == nil || wm.Config.Watch == nil {
+		return false
+	}
+
+	for _, pattern := range wm.Config.Watch.BranchesToIgnore {
+		if matched, _ := filepath.Match(pattern, branch); matched {
+			return true
+		}
+	}
+
+	return false
 }
 
-// CheckRebaseConflicts checks all worktree branches for potential rebase conflicts
+// CheckRebaseConflicts checks all worktree branches except main for potential rebase conflicts
 func (wm *WorktreeManager) CheckRebaseConflicts() ([]ConflictInfo, error) {
+	return wm.CheckRebaseConflictsWithOptions(ConflictCheckOptions{})
+}
+
+// CheckRebaseConflictsWithOptions checks the worktree branches selected by opts for
+// potential rebase conflicts
+func (wm *WorktreeManager) CheckRebaseConflictsWithOptions(opts ConflictCheckOptions) ([]ConflictInfo, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
 	// First, fetch latest changes
 	if !wm.Options.Quiet {
 		wm.printf("🔄 Fetching latest changes from origin...\n")
@@ -105,14 +196,14 @@ func (wm *WorktreeManager) CheckRebaseConflicts() ([]ConflictInfo, error) {
 	if err := cmd.Run(); err != nil {
 		// Non-fatal, continue checking with local state
 		if !wm.Options.Quiet {
-			wm.printf("⚠️  Warning: Failed to fetch from origin: %v\n", err)
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Failed to fetch from origin: %v\n", err)
 		}
 	}
 
 	// Get main branch
-	mainBranch, err := wm.GetMainBranch()
+	mainBranch, err := wm.conflictBaseBranch()
 	if err != nil {
-		return nil, fmt.Errorf("failed to determine main branch: %w", err)
+		return nil, fmt.Errorf("failed to check for rebase conflicts: %w", err)
 	}
 
 	// Get all worktrees
@@ -125,8 +216,8 @@ func (wm *WorktreeManager) CheckRebaseConflicts() ([]ConflictInfo, error) {
 	checkTime := time.Now()
 
 	for _, wt := range worktrees {
-		// Skip if no branch (detached HEAD) or if it's the main branch
-		if wt.Branch == "" || wt.Branch == mainBranch {
+		// Skip detached HEADs, the main branch unless requested, and filtered branches
+		if wt.Branch == "" || (wt.Branch == mainBranch && !opts.IncludeMain) || !opts.matchesBranches(wt.Branch) {
 			continue
 		}
 
@@ -146,43 +237,184 @@ func (wm *WorktreeManager) CheckRebaseConflicts() ([]ConflictInfo, error) {
 
 // checkBranchConflicts checks a specific branch for rebase conflicts
 func (wm *WorktreeManager) checkBranchConflicts(wt WorktreeInfo, mainBranch string, checkTime time.Time) *ConflictInfo {
-	// Use merge-tree to detect conflicts without modifying working tree
-	cmd := exec.Command("git", "merge-tree", "--write-tree", "--no-messages",
-		fmt.Sprintf("origin/%s", mainBranch), wt.Branch)
-	cmd.Dir = wt.Path
+	base := fmt.Sprintf("origin/%s", mainBranch)
+
+	var conflictFiles []string
+	var err error
+	if wm.supportsWriteTree() {
+		conflictFiles, err = mergeTreeConflicts(wt.Path, base, wt.Branch)
+	} else {
+		conflictFiles, err = legacyMergeTreeConflicts(wt.Path, base, wt.Branch)
+	}
+
+	if err != nil {
+		// Record the failure so it shows up in watch output
+		return &ConflictInfo{
+			Branch:       wt.Branch,
+			WorktreePath: wt.Path,
+			LastChecked:  checkTime,
+			Error:        fmt.Sprintf("failed to check conflicts: %v", err),
+		}
+	}
+
+	if len(conflictFiles) == 0 {
+		// No conflicts
+		return nil
+	}
+
+	return &ConflictInfo{
+		Branch:        wt.Branch,
+		WorktreePath:  wt.Path,
+		ConflictFiles: conflictFiles,
+		LastChecked:   checkTime,
+	}
+}
+
+// mergeTreeConflicts uses merge-tree --write-tree (git 2.38+) to list the files that
+// would conflict when merging branch into base, without modifying the working tree
+func mergeTreeConflicts(dir, base, branch string) ([]string, error) {
+	cmd := exec.Command("git", "merge-tree", "--write-tree", "--name-only", "--no-messages", base, branch)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err == nil {
+		return nil, nil
+	}
+
+	// Exit status 1 means the merge has conflicts; the first line is the tree ID
+	// and the conflicted paths follow
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	for _, line := range lines[1:] {
+		if line == "" || seen[line] {
+			continue
+		}
+		files = append(files, line)
+		seen[line] = true
+	}
+	return files, nil
+}
+
+// legacyMergeTreeConflicts uses the three-argument form of merge-tree, available in
+// all git versions, for gits that lack --write-tree. It only reports content
+// conflicts, so modify/delete conflicts are not detected.
+func legacyMergeTreeConflicts(dir, base, branch string) ([]string, error) {
+	cmd := exec.Command("git", "merge-base", base, branch)
+	cmd.Dir = dir
 
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
 	output, err := cmd.Output()
 	if err != nil {
-		// Check if the error is due to conflicts
-		stderrStr := stderr.String()
-		outputStr := string(output)
-
-		if strings.Contains(stderrStr, "conflict") || strings.Contains(outputStr, "conflict") {
-			// Parse conflict files from output
-			conflictFiles := parseConflictFiles(outputStr + "\n" + stderrStr)
-
-			return &ConflictInfo{
-				Branch:        wt.Branch,
-				WorktreePath:  wt.Path,
-				ConflictFiles: conflictFiles,
-				LastChecked:   checkTime,
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to find merge base: %s", msg)
+		}
+		return nil, fmt.Errorf("failed to find merge base: %w", err)
+	}
+	mergeBase := strings.TrimSpace(string(output))
+
+	stderr.Reset()
+	cmd = exec.Command("git", "merge-tree", mergeBase, base, branch)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+
+	output, err = cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	return parseLegacyMergeTree(string(output)), nil
+}
+
+// parseLegacyMergeTree extracts conflicted paths from legacy merge-tree output,
+// where each changed path is listed as "  our    <mode> <sha> <path>" followed by
+// a diff that contains conflict markers if the merge failed
+func parseLegacyMergeTree(output string) []string {
+	var files []string
+	seen := make(map[string]bool)
+
+	current := ""
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "  base ") || strings.HasPrefix(line, "  our ") || strings.HasPrefix(line, "  their "):
+			fields := strings.SplitN(strings.TrimSpace(line), " ", 2)
+			parts := strings.SplitN(strings.TrimSpace(fields[1]), " ", 3)
+			if len(parts) == 3 {
+				current = parts[2]
+			}
+		case strings.HasPrefix(line, "+<<<<<<< "):
+			if current != "" && !seen[current] {
+				files = append(files, current)
+				seen[current] = true
 			}
 		}
+	}
 
-		// If it's not a conflict error, record it
-		return &ConflictInfo{
-			Branch:       wt.Branch,
-			WorktreePath: wt.Path,
-			LastChecked:  checkTime,
-			Error:        fmt.Sprintf("failed to check conflicts: %v", err),
+	return files
+}
+
+// supportsWriteTree reports whether the installed git supports merge-tree
+// --write-tree. The version is only checked once per manager.
+func (wm *WorktreeManager) supportsWriteTree() bool {
+	wm.writeTreeOnce.Do(func() {
+		wm.writeTreeSupported = true
+
+		output, err := exec.Command("git", "version").Output()
+		if err != nil {
+			return
 		}
+		major, minor, ok := parseGitVersion(string(output))
+		if !ok {
+			// Unknown format, assume a modern git
+			return
+		}
+		if major < 2 || (major == 2 && minor < 38) {
+			wm.writeTreeSupported = false
+			if !wm.Options.Quiet {
+				fmt.Fprintf(os.Stderr, "⚠️  git %d.%d does not support 'merge-tree --write-tree' (2.38+); using the legacy conflict check, which misses some conflict types\n", major, minor)
+			}
+		}
+	})
+	return wm.writeTreeSupported
+}
+
+// parseGitVersion extracts the major and minor version from `git version` output,
+// e.g. "git version 2.39.5" or "git version 2.37.1 (Apple Git-137.1)"
+func parseGitVersion(output string) (int, int, bool) {
+	fields := strings.Fields(output)
+	if len(fields) < 3 || fields[0] != "git" || fields[1] != "version" {
+		return 0, 0, false
 	}
 
-	// No conflicts
-	return nil
+	parts := strings.Split(fields[2], ".")
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
 }
 
 // parseConflictFiles extracts file paths from conflict output
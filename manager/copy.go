@@ -0,0 +1,541 @@
+package manager
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// copyDirectoryWithBackend copies a directory using the configured copy backend.
+// The rsync backend is opt-in and falls back to the native copy when rsync is
+// unavailable or cannot place files the configured way.
+func (wm *WorktreeManager) copyDirectoryWithBackend(src, dst string) error {
+	switch backend := wm.Config.GetCopyBackend(); backend {
+	case "native":
+		return wm.copyDirectoryWithProgress(src, dst)
+	case "rsync":
+		if wm.Config.GetCopyMode() == "reflink" {
+			// rsync cannot clone files, the native copy can
+			if wm.Options.Verbose {
+				wm.printf("     copy_mode reflink is not supported by rsync, using native copy\n")
+			}
+			return wm.copyDirectoryWithProgress(src, dst)
+		}
+		if _, err := exec.LookPath("rsync"); err != nil {
+			if wm.Options.Verbose {
+				wm.printf("     rsync not found in PATH, falling back to native copy\n")
+			}
+			return wm.copyDirectoryWithProgress(src, dst)
+		}
+		return wm.rsyncDirectory(src, dst)
+	default:
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: Unknown copy_backend '%s', using native copy\n", backend)
+		return wm.copyDirectoryWithProgress(src, dst)
+	}
+}
+
+// rsyncDirectory copies the contents of src into dst using rsync, preserving
+// permissions. Paths excluded by .workieignore are passed to rsync as excludes,
+// and with copy_mode: hardlink files are linked to src with --link-dest.
+func (wm *WorktreeManager) rsyncDirectory(src, dst string) error {
+	// Verify source directory exists
+	if info, err := os.Stat(src); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("source directory does not exist: %s", src)
+		}
+		return fmt.Errorf("cannot access source directory %s: %w", src, err)
+	} else if !info.IsDir() {
+		return fmt.Errorf("source path is not a directory: %s", src)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		if os.IsPermission(err) {
+			return fmt.Errorf("permission denied creating directory: %s", filepath.Dir(dst))
+		}
+		return fmt.Errorf("failed to create destination directory %s: %w", filepath.Dir(dst), err)
+	}
+
+	// Trailing slashes make rsync copy the directory contents rather than nesting src inside dst
+	args := []string{"-a"}
+	if wm.Config.ShouldFollowSymlinks() {
+		args = append(args, "--copy-links")
+	}
+	if wm.Config.GetCopyMode() == "hardlink" {
+		// rsync links files it would copy unchanged from the --link-dest directory
+		absSrc, err := filepath.Abs(src)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", src, err)
+		}
+		args = append(args, "--link-dest="+absSrc)
+	}
+	if wm.ignored != nil {
+		excludeFile, err := wm.writeRsyncExcludes(src)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(excludeFile)
+		args = append(args, "--exclude-from="+excludeFile)
+	}
+	args = append(args, strings.TrimRight(src, "/")+"/", strings.TrimRight(dst, "/")+"/")
+
+	if wm.Options.Verbose {
+		wm.printf("     Executing: rsync %s\n", strings.Join(args, " "))
+	}
+
+	cmd := exec.Command("rsync", args...)
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("rsync failed copying %s to %s: %v: %s", src, dst, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// writeRsyncExcludes writes the paths under src that .workieignore excludes to
+// a temporary file for rsync's --exclude-from, and returns its path. Matching
+// the paths here, rather than translating the patterns, keeps the semantics
+// exactly those of the native copy. Each path is anchored at src.
+func (wm *WorktreeManager) writeRsyncExcludes(src string) (string, error) {
+	var excludes strings.Builder
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // rsync reports unreadable paths itself
+		}
+		repoRel, err := filepath.Rel(wm.RepoPath, path)
+		if err != nil || path == src || !wm.ignored.Match(repoRel, info.IsDir()) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil || strings.ContainsAny(rel, "\n\r") {
+			return nil
+		}
+		pattern := "/" + filepath.ToSlash(rel)
+		// Backslashes only escape when the pattern has wildcards
+		if strings.ContainsAny(pattern, "*?[") {
+			pattern = rsyncWildcardEscaper.Replace(pattern)
+		}
+		if info.IsDir() {
+			excludes.WriteString(pattern + "/\n")
+			return filepath.SkipDir
+		}
+		excludes.WriteString(pattern + "\n")
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list excluded files in %s: %w", src, err)
+	}
+
+	file, err := os.CreateTemp("", "workie-rsync-exclude-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to write rsync excludes: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(excludes.String()); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write rsync excludes: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// rsyncWildcardEscaper escapes the characters rsync treats as wildcards
+var rsyncWildcardEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`)
+
+// placeFile places a single file at dst according to the configured copy mode.
+// Hardlinks and reflinks fall back to a regular copy when the filesystem does not
+// support them or src and dst live on different filesystems, and symlinks when
+// they cannot be created (e.g. on Windows without the privilege).
+func (wm *WorktreeManager) placeFile(src, dst string) error {
+	switch mode := wm.Config.GetCopyMode(); mode {
+	case "copy":
+		return wm.copyFile(src, dst)
+	case "hardlink":
+		if err := linkFile(src, dst); err != nil {
+			if wm.Options.Verbose {
+				wm.printf("     Hardlink failed for %s (%v), falling back to copy\n", src, err)
+			}
+			return wm.copyFile(src, dst)
+		}
+		wm.progress.addFile(src)
+		return nil
+	case "reflink":
+		if err := reflinkFile(src, dst); err != nil {
+			if wm.Options.Verbose {
+				wm.printf("     Reflink failed for %s (%v), falling back to copy\n", src, err)
+			}
+			return wm.copyFile(src, dst)
+		}
+		wm.progress.addFile(src)
+		return nil
+	case "symlink":
+		if err := symlinkPath(src, dst); err != nil {
+			if wm.Options.Verbose {
+				wm.printf("     Symlink failed for %s (%v), falling back to copy\n", src, err)
+			}
+			return wm.copyFile(src, dst)
+		}
+		wm.progress.addFile(src)
+		return nil
+	default:
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: Unknown copy_mode '%s', using regular copy\n", mode)
+		return wm.copyFile(src, dst)
+	}
+}
+
+// plannedCopy is a file a dry run would copy, relative to the repository (Src)
+// and the new worktree (Dst)
+type plannedCopy struct {
+	Src string
+	Dst string
+}
+
+// planCopy lists the files copying the repository-relative item to dst would
+// place, walking directories and honoring .workieignore like copyTree does
+func (wm *WorktreeManager) planCopy(item, dst string, isDir bool) ([]plannedCopy, error) {
+	if !isDir {
+		return []plannedCopy{{Src: item, Dst: dst}}, nil
+	}
+
+	var copies []plannedCopy
+	src := filepath.Join(wm.RepoPath, item)
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		repoRel, err := filepath.Rel(wm.RepoPath, path)
+		if err != nil {
+			return err
+		}
+		if wm.ignored.Match(repoRel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		copies = append(copies, plannedCopy{Src: repoRel, Dst: filepath.Join(dst, rel)})
+		return nil
+	})
+	return copies, err
+}
+
+// parallelCopier places files on a bounded number of goroutines and keeps the
+// first error. With a limit of 1 files are placed synchronously.
+type parallelCopier struct {
+	place func(src, dst string) error
+	slots chan struct{}
+	wg    sync.WaitGroup
+
+	mu       sync.Mutex
+	firstErr error
+}
+
+// newParallelCopier creates a copier that runs at most limit placements at once
+func newParallelCopier(limit int, place func(src, dst string) error) *parallelCopier {
+	if limit < 1 {
+		limit = 1
+	}
+	return &parallelCopier{place: place, slots: make(chan struct{}, limit)}
+}
+
+// copy places src at dst, waiting for a free slot first
+func (c *parallelCopier) copy(src, dst string) {
+	if cap(c.slots) == 1 {
+		c.run(src, dst)
+		return
+	}
+
+	c.slots <- struct{}{}
+	c.wg.Add(1)
+	go func() {
+		defer func() {
+			<-c.slots
+			c.wg.Done()
+		}()
+		c.run(src, dst)
+	}()
+}
+
+// run places one file, recording the error if it is the first
+func (c *parallelCopier) run(src, dst string) {
+	if err := c.place(src, dst); err != nil {
+		c.mu.Lock()
+		if c.firstErr == nil {
+			c.firstErr = fmt.Errorf("failed to copy file %s to %s: %w", src, dst, err)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// err returns the first error so far, letting callers stop submitting work
+func (c *parallelCopier) err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.firstErr
+}
+
+// wait blocks until all placements finish and returns the first error
+func (c *parallelCopier) wait() error {
+	c.wg.Wait()
+	return c.err()
+}
+
+// linkFile creates a hardlink at dst pointing to src, replacing any existing file
+func linkFile(src, dst string) error {
+	if err := prepareDestination(dst); err != nil {
+		return err
+	}
+	return os.Link(src, dst)
+}
+
+// symlinkPath creates a symlink at dst pointing to src, relative to dst's directory
+// so the link keeps working when the repository and its worktrees move together.
+// An existing file or link at dst is replaced, but not a directory.
+func symlinkPath(src, dst string) error {
+	if info, err := os.Lstat(dst); err == nil && info.IsDir() {
+		return fmt.Errorf("%s already exists as a directory", dst)
+	}
+	if err := prepareDestination(dst); err != nil {
+		return err
+	}
+	target, err := filepath.Rel(filepath.Dir(dst), src)
+	if err != nil {
+		target = src
+	}
+	if err := os.Symlink(target, dst); err != nil {
+		return fmt.Errorf("failed to create symlink %s: %w", dst, err)
+	}
+	return nil
+}
+
+// prepareDestination creates the parent directory of dst and removes any existing
+// file at dst so that link-based copy modes can create it fresh
+func prepareDestination(dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory %s: %w", filepath.Dir(dst), err)
+	}
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to replace existing file %s: %w", dst, err)
+	}
+	return nil
+}
+
+// copySymlink handles a symlink found while copying a directory. By default the link
+// itself is recreated at dst; with follow_symlinks the target is copied instead,
+// skipping links that would loop back into a directory already being copied.
+func (wm *WorktreeManager) copySymlink(src, dst string, ancestors map[string]bool) error {
+	if wm.Config.ShouldFollowSymlinks() {
+		target, err := filepath.EvalSymlinks(src)
+		if err != nil {
+			// Broken links have nothing to follow, so keep them as links
+			if wm.Options.Verbose {
+				wm.printf("     Cannot resolve symlink %s (%v), recreating link\n", src, err)
+			}
+			return recreateSymlink(src, dst)
+		}
+
+		info, err := os.Stat(target)
+		if err != nil {
+			return fmt.Errorf("cannot access symlink target %s: %w", target, err)
+		}
+
+		if !info.IsDir() {
+			if err := wm.placeFile(target, dst); err != nil {
+				return fmt.Errorf("failed to copy file %s to %s: %w", src, dst, err)
+			}
+			return nil
+		}
+
+		if isSymlinkCycle(target, ancestors) {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Skipping symlink %s → %s (would create a copy loop)\n", src, target)
+			return nil
+		}
+		return wm.copyTree(target, dst, ancestors)
+	}
+
+	return recreateSymlink(src, dst)
+}
+
+// recreateSymlink creates a symlink at dst with the same target as the link at src
+func recreateSymlink(src, dst string) error {
+	target, err := os.Readlink(src)
+	if err != nil {
+		return fmt.Errorf("failed to read symlink %s: %w", src, err)
+	}
+	if err := prepareDestination(dst); err != nil {
+		return err
+	}
+	if err := os.Symlink(target, dst); err != nil {
+		return fmt.Errorf("failed to create symlink %s: %w", dst, err)
+	}
+	return nil
+}
+
+// isSymlinkCycle reports whether target is, or contains, a directory that is already
+// being copied. Following such a link would recurse forever.
+func isSymlinkCycle(target string, ancestors map[string]bool) bool {
+	for ancestor := range ancestors {
+		rel, err := filepath.Rel(target, ancestor)
+		if err != nil {
+			continue
+		}
+		if rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))) {
+			return true
+		}
+	}
+	return false
+}
+
+// progressThreshold is the minimum directory size that shows a copy progress bar
+const progressThreshold = 1 << 20 // 1 MiB
+
+// copyDirectoryWithProgress copies a directory natively, rendering a byte-level
+// progress bar for large directories when writing to a terminal
+func (wm *WorktreeManager) copyDirectoryWithProgress(src, dst string) error {
+	if wm.Options.Quiet || !isTerminal(os.Stdout) {
+		return wm.copyDirectory(src, dst)
+	}
+
+	// Count only what the copy places, so the bar reaches 100%
+	total, err := directorySize(src, wm.isIgnored)
+	if err != nil || total < progressThreshold {
+		// Let copyDirectory report any access errors
+		return wm.copyDirectory(src, dst)
+	}
+
+	wm.progress = &copyProgress{total: total, lastPercent: -1}
+	defer func() {
+		wm.progress.finish()
+		wm.progress = nil
+	}()
+
+	return wm.copyDirectory(src, dst)
+}
+
+// isIgnored reports whether .workieignore, which is relative to the repository
+// root, excludes the absolute path
+func (wm *WorktreeManager) isIgnored(path string, isDir bool) bool {
+	repoRel, err := filepath.Rel(wm.RepoPath, path)
+	return err == nil && wm.ignored.Match(repoRel, isDir)
+}
+
+// directorySize returns the total size in bytes of the regular files under
+// root, leaving out the files and directories skip reports (nil skips nothing)
+func directorySize(root string, skip func(path string, isDir bool) bool) (int64, error) {
+	var total int64
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if skip != nil && path != root && skip(path, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
+
+// copyProgress tracks bytes copied during a directory copy and renders a progress
+// bar. Files are copied concurrently, so updates are serialized by mu.
+type copyProgress struct {
+	mu          sync.Mutex
+	total       int64
+	copied      int64
+	lastPercent int
+	rendered    bool
+}
+
+// add records n copied bytes and redraws the bar when the percentage changes.
+// It is safe to call on a nil receiver.
+func (p *copyProgress) add(n int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.copied += int64(n)
+	if p.copied > p.total {
+		p.copied = p.total
+	}
+
+	percent := int(p.copied * 100 / p.total)
+	if percent == p.lastPercent {
+		return
+	}
+	p.lastPercent = percent
+	p.rendered = true
+	fmt.Printf("\r     Progress: [%s] %3d%% (%s/%s)", progressBar(percent), percent, formatBytes(p.copied), formatBytes(p.total))
+}
+
+// addFile records the full size of a file that was placed without copying its bytes
+func (p *copyProgress) addFile(path string) {
+	if p == nil {
+		return
+	}
+	if info, err := os.Stat(path); err == nil {
+		p.add(int(info.Size()))
+	}
+}
+
+// finish terminates the progress line if one was drawn
+func (p *copyProgress) finish() {
+	if p != nil && p.rendered {
+		fmt.Printf("\n")
+	}
+}
+
+// countingWriter wraps an io.Writer and reports the number of bytes written
+type countingWriter struct {
+	w       io.Writer
+	onWrite func(int)
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.onWrite(n)
+	return n, err
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// formatBytes renders a byte count in human readable units
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
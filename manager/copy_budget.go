@@ -0,0 +1,144 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agoodway/workie/ignore"
+)
+
+// maxCopyContributors is how many of the largest items an over-budget error names
+const maxCopyContributors = 5
+
+// copyContributor is a file or directory and how many bytes it adds to the copy
+type copyContributor struct {
+	Path string
+	Size int64
+}
+
+// checkCopyBudget sums what files_to_copy would copy and refuses to go on when
+// it exceeds max_copy_size, naming the largest contributors. Directories are
+// broken down by their immediate children, so a listed "./" points at e.g.
+// node_modules rather than at the whole repository. Missing sources are left
+// for copyConfiguredFiles to report. With copy_mode: symlink or hardlink no
+// extra disk space is used, so there is no budget to check; hardlinks that fall
+// back to a copy across filesystems are not counted.
+func (wm *WorktreeManager) checkCopyBudget() error {
+	limit, err := wm.Config.GetMaxCopySize()
+	if err != nil {
+		return fmt.Errorf("invalid max_copy_size: %w\n\nTo fix this:\n  • Use a number of bytes or a size like 500MB or 2GB", err)
+	}
+	if limit <= 0 || !wm.Config.HasFilesToCopy() {
+		return nil
+	}
+	if mode := wm.Config.GetCopyMode(); mode == "symlink" || mode == "hardlink" {
+		return nil
+	}
+
+	// An unreadable .workieignore is reported when the files are copied
+	wm.ignored, _ = ignore.Load(wm.RepoPath)
+
+	sizes := make(map[string]int64)
+	var total int64
+	for _, item := range wm.copySources() {
+		srcPath := filepath.Join(wm.RepoPath, item)
+		info, err := os.Stat(srcPath)
+		if err != nil || wm.ignored.Match(item, info.IsDir()) {
+			continue
+		}
+		if !info.IsDir() {
+			sizes[item] += info.Size()
+			total += info.Size()
+			continue
+		}
+
+		err = filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			repoRel, err := filepath.Rel(wm.RepoPath, path)
+			if err != nil {
+				return err
+			}
+			if wm.ignored.Match(repoRel, info.IsDir()) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(srcPath, path)
+			if err != nil {
+				return err
+			}
+			child, _, _ := strings.Cut(rel, string(filepath.Separator))
+			sizes[filepath.Join(item, child)] += info.Size()
+			total += info.Size()
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to measure %s for max_copy_size: %w", item, err)
+		}
+	}
+
+	if total <= limit {
+		return nil
+	}
+
+	contributors := make([]copyContributor, 0, len(sizes))
+	for path, size := range sizes {
+		contributors = append(contributors, copyContributor{Path: path, Size: size})
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		if contributors[i].Size != contributors[j].Size {
+			return contributors[i].Size > contributors[j].Size
+		}
+		return contributors[i].Path < contributors[j].Path
+	})
+	if len(contributors) > maxCopyContributors {
+		contributors = contributors[:maxCopyContributors]
+	}
+
+	width := 0
+	for _, c := range contributors {
+		width = max(width, len(c.Path))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "files_to_copy would copy %s, over the max_copy_size of %s\n\nLargest items:\n", formatBytes(total), formatBytes(limit))
+	for _, c := range contributors {
+		fmt.Fprintf(&b, "  • %-*s  %s\n", width, c.Path, formatBytes(c.Size))
+	}
+	fmt.Fprintf(&b, "\nTo fix this:\n  • Remove large entries from files_to_copy\n  • Exclude them in %s\n  • Or raise max_copy_size in your configuration", ignore.FileName)
+	return fmt.Errorf("%s", b.String())
+}
+
+// copySources returns the repository-relative sources files_to_copy names, with
+// glob patterns expanded. Unlike expandCopyEntries it reports nothing, since
+// unmatched patterns are warned about when the files are copied.
+func (wm *WorktreeManager) copySources() []string {
+	var sources []string
+	for _, entry := range wm.Config.FilesToCopy {
+		if strings.TrimSpace(entry.Src) == "" || entry.Validate() != nil {
+			continue
+		}
+		if !entry.IsGlob() {
+			sources = append(sources, filepath.Clean(entry.Src))
+			continue
+		}
+
+		matches, _ := filepath.Glob(filepath.Join(wm.RepoPath, entry.Src))
+		for _, match := range matches {
+			if rel, err := filepath.Rel(wm.RepoPath, match); err == nil {
+				sources = append(sources, rel)
+			}
+		}
+	}
+	return sources
+}
@@ -0,0 +1,806 @@
+package manager
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agoodway/workie/config"
+	"github.com/agoodway/workie/ignore"
+)
+
+// writeTestTree creates a small directory tree under root for copy tests
+func writeTestTree(t *testing.T, root string) {
+	t.Helper()
+	files := map[string]string{
+		"a.txt":          "alpha",
+		"nested/b.txt":   "bravo",
+		"nested/c/d.txt": "delta",
+	}
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// assertTestTree verifies the tree written by writeTestTree exists under root
+func assertTestTree(t *testing.T, root string) {
+	t.Helper()
+	expected := map[string]string{
+		"a.txt":          "alpha",
+		"nested/b.txt":   "bravo",
+		"nested/c/d.txt": "delta",
+	}
+	for rel, want := range expected {
+		got, err := os.ReadFile(filepath.Join(root, rel))
+		if err != nil {
+			t.Errorf("Expected %s to be copied: %v", rel, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("Expected %s to contain %q, got %q", rel, want, string(got))
+		}
+	}
+}
+
+func TestCopyDirectoryWithBackend(t *testing.T) {
+	t.Run("native backend", func(t *testing.T) {
+		src := t.TempDir()
+		dst := filepath.Join(t.TempDir(), "out")
+		writeTestTree(t, src)
+
+		wm := New()
+		wm.Options.Quiet = true
+		wm.Config = &config.Config{}
+
+		if err := wm.copyDirectoryWithBackend(src, dst); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		assertTestTree(t, dst)
+	})
+
+	t.Run("rsync backend", func(t *testing.T) {
+		src := t.TempDir()
+		dst := filepath.Join(t.TempDir(), "out")
+		writeTestTree(t, src)
+
+		wm := New()
+		wm.Options.Quiet = true
+		wm.Config = &config.Config{CopyBackend: "rsync"}
+
+		// Falls back to the native copy when rsync is not installed
+		if err := wm.copyDirectoryWithBackend(src, dst); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		assertTestTree(t, dst)
+	})
+
+	t.Run("rsync backend copies contents not directory", func(t *testing.T) {
+		if _, err := exec.LookPath("rsync"); err != nil {
+			t.Skip("rsync not available")
+		}
+		src := t.TempDir()
+		dst := filepath.Join(t.TempDir(), "out")
+		writeTestTree(t, src)
+
+		wm := New()
+		wm.Options.Quiet = true
+		wm.Config = &config.Config{CopyBackend: "rsync"}
+
+		if err := wm.rsyncDirectory(src, dst); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		assertTestTree(t, dst)
+	})
+}
+
+func TestWriteRsyncExcludes(t *testing.T) {
+	repo := t.TempDir()
+	src := filepath.Join(repo, "data")
+	writeTestTree(t, src)
+	if err := os.WriteFile(filepath.Join(src, "nested", "x[1].log"), []byte("log"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ignored, err := ignore.Parse([]string{"data/nested/c/", "a.txt", "*.log"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wm := New()
+	wm.RepoPath = repo
+	wm.ignored = ignored
+
+	path, err := wm.writeRsyncExcludes(src)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/a.txt\n/nested/c/\n/nested/x\\[1].log\n"; string(data) != want {
+		t.Errorf("Expected anchored excludes %q, got %q", want, data)
+	}
+}
+
+func TestRsyncDirectory(t *testing.T) {
+	if _, err := exec.LookPath("rsync"); err != nil {
+		t.Skip("rsync not available")
+	}
+
+	t.Run("honors workieignore", func(t *testing.T) {
+		repo := t.TempDir()
+		src := filepath.Join(repo, "data")
+		dst := filepath.Join(t.TempDir(), "data")
+		writeTestTree(t, src)
+		if err := os.WriteFile(filepath.Join(src, "nested", "x[1].log"), []byte("log"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		ignored, err := ignore.Parse([]string{"data/nested/c/", "a.txt", "*.log"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wm := New()
+		wm.Options.Quiet = true
+		wm.RepoPath = repo
+		wm.Config = &config.Config{CopyBackend: "rsync"}
+		wm.ignored = ignored
+
+		if err := wm.copyDirectoryWithBackend(src, dst); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dst, "nested", "b.txt")); err != nil {
+			t.Errorf("Expected nested/b.txt to be copied: %v", err)
+		}
+		for _, rel := range []string{"a.txt", "nested/c", "nested/x[1].log"} {
+			if _, err := os.Stat(filepath.Join(dst, rel)); !os.IsNotExist(err) {
+				t.Errorf("Expected %s to be excluded, got err=%v", rel, err)
+			}
+		}
+	})
+
+	t.Run("hardlinks with copy_mode hardlink", func(t *testing.T) {
+		src := t.TempDir()
+		dst := filepath.Join(t.TempDir(), "out")
+		writeTestTree(t, src)
+
+		wm := New()
+		wm.Options.Quiet = true
+		wm.Config = &config.Config{CopyBackend: "rsync", CopyMode: "hardlink"}
+
+		if err := wm.copyDirectoryWithBackend(src, dst); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		assertTestTree(t, dst)
+		srcInfo, _ := os.Stat(filepath.Join(src, "nested", "b.txt"))
+		dstInfo, _ := os.Stat(filepath.Join(dst, "nested", "b.txt"))
+		if !os.SameFile(srcInfo, dstInfo) {
+			t.Error("Expected rsync to hardlink the files")
+		}
+	})
+}
+
+func TestCopyDirectoryConcurrency(t *testing.T) {
+	for _, concurrency := range []int{1, 4} {
+		src := t.TempDir()
+		dst := filepath.Join(t.TempDir(), "out")
+		writeTestTree(t, src)
+
+		wm := New()
+		wm.Options.Quiet = true
+		wm.Config = &config.Config{CopyConcurrency: concurrency}
+
+		if err := wm.copyDirectory(src, dst); err != nil {
+			t.Fatalf("Expected no error with concurrency %d, got: %v", concurrency, err)
+		}
+		assertTestTree(t, dst)
+	}
+
+	t.Run("reports a failed file", func(t *testing.T) {
+		if os.Getuid() == 0 {
+			t.Skip("root can read unreadable files")
+		}
+		src := t.TempDir()
+		writeTestTree(t, src)
+		if err := os.Chmod(filepath.Join(src, "nested", "b.txt"), 0); err != nil {
+			t.Fatal(err)
+		}
+
+		wm := New()
+		wm.Options.Quiet = true
+		wm.Config = &config.Config{CopyConcurrency: 4}
+
+		err := wm.copyDirectory(src, filepath.Join(t.TempDir(), "out"))
+		if err == nil || !strings.Contains(err.Error(), "b.txt") {
+			t.Errorf("Expected an error naming b.txt, got: %v", err)
+		}
+	})
+}
+
+func TestPlaceFile(t *testing.T) {
+	for _, mode := range []string{"copy", "hardlink", "reflink"} {
+		t.Run(mode, func(t *testing.T) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "src.txt")
+			dst := filepath.Join(dir, "worktree", "dst.txt")
+			if err := os.WriteFile(src, []byte("payload"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			// An existing destination file must be replaced
+			if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(dst, []byte("stale"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			wm := New()
+			wm.Options.Quiet = true
+			wm.Config = &config.Config{CopyMode: mode}
+
+			if err := wm.placeFile(src, dst); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			got, err := os.ReadFile(dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != "payload" {
+				t.Errorf("Expected destination to contain %q, got %q", "payload", string(got))
+			}
+
+			srcInfo, _ := os.Stat(src)
+			dstInfo, _ := os.Stat(dst)
+			if linked := os.SameFile(srcInfo, dstInfo); linked != (mode == "hardlink") {
+				t.Errorf("Expected hardlinked=%v for mode %s, got %v", mode == "hardlink", mode, linked)
+			}
+		})
+	}
+}
+
+func TestCopyProgress(t *testing.T) {
+	p := &copyProgress{total: 4096, lastPercent: -1}
+	var buf bytes.Buffer
+	w := &countingWriter{w: &buf, onWrite: p.add}
+
+	if _, err := w.Write(make([]byte, 1024)); err != nil {
+		t.Fatal(err)
+	}
+	if p.copied != 1024 || p.lastPercent != 25 {
+		t.Errorf("Expected 1024 bytes at 25%%, got %d bytes at %d%%", p.copied, p.lastPercent)
+	}
+
+	// Files that grow during the copy must not push progress past 100%
+	p.add(8192)
+	if p.copied != p.total || p.lastPercent != 100 {
+		t.Errorf("Expected progress capped at total, got %d bytes at %d%%", p.copied, p.lastPercent)
+	}
+
+	// A nil progress tracker is a no-op
+	var nilProgress *copyProgress
+	nilProgress.add(10)
+	nilProgress.finish()
+}
+
+func TestDirectorySizeIgnored(t *testing.T) {
+	repo := t.TempDir()
+	src := filepath.Join(repo, "data")
+	files := map[string]int{"keep.txt": 100, "cache/big.bin": 5000, "debug.log": 300}
+	for name, size := range files {
+		path := filepath.Join(src, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ignored, err := ignore.Parse([]string{"data/cache/", "*.log"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wm := New()
+	wm.RepoPath = repo
+	wm.ignored = ignored
+
+	if total, err := directorySize(src, nil); err != nil || total != 5400 {
+		t.Errorf("Expected every file counted, got %d, %v", total, err)
+	}
+	// The progress total only counts the files the copy places
+	if total, err := directorySize(src, wm.isIgnored); err != nil || total != 100 {
+		t.Errorf("Expected files excluded by .workieignore to be left out, got %d, %v", total, err)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		2048:            "2.0 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	}
+	for in, want := range tests {
+		if got := formatBytes(in); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCopyDirectorySymlinks(t *testing.T) {
+	// setup creates src/real/file.txt, a file link, a directory link and a link back to src
+	setup := func(t *testing.T) string {
+		t.Helper()
+		src := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(src, "real"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(src, "real", "file.txt"), []byte("target"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		links := map[string]string{
+			"file-link": "real/file.txt",
+			"dir-link":  "real",
+			"loop":      ".",
+		}
+		for name, target := range links {
+			if err := os.Symlink(target, filepath.Join(src, name)); err != nil {
+				t.Skipf("symlinks not supported: %v", err)
+			}
+		}
+		return src
+	}
+
+	t.Run("recreates symlinks by default", func(t *testing.T) {
+		src := setup(t)
+		dst := filepath.Join(t.TempDir(), "out")
+
+		wm := New()
+		wm.Options.Quiet = true
+		wm.Config = &config.Config{}
+
+		if err := wm.copyDirectory(src, dst); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		for _, name := range []string{"file-link", "dir-link", "loop"} {
+			info, err := os.Lstat(filepath.Join(dst, name))
+			if err != nil {
+				t.Fatalf("Expected %s to exist: %v", name, err)
+			}
+			if info.Mode()&os.ModeSymlink == 0 {
+				t.Errorf("Expected %s to be a symlink", name)
+			}
+		}
+		if target, _ := os.Readlink(filepath.Join(dst, "dir-link")); target != "real" {
+			t.Errorf("Expected dir-link to point to %q, got %q", "real", target)
+		}
+	})
+
+	t.Run("follows symlinks and skips cycles", func(t *testing.T) {
+		src := setup(t)
+		dst := filepath.Join(t.TempDir(), "out")
+
+		wm := New()
+		wm.Options.Quiet = true
+		wm.Config = &config.Config{FollowSymlinks: true}
+
+		if err := wm.copyDirectory(src, dst); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		for _, rel := range []string{"file-link", "dir-link/file.txt"} {
+			path := filepath.Join(dst, rel)
+			info, err := os.Lstat(path)
+			if err != nil {
+				t.Fatalf("Expected %s to exist: %v", rel, err)
+			}
+			if info.Mode()&os.ModeSymlink != 0 {
+				t.Errorf("Expected %s to be a regular file, got a symlink", rel)
+			}
+			if got, _ := os.ReadFile(path); string(got) != "target" {
+				t.Errorf("Expected %s to contain %q, got %q", rel, "target", string(got))
+			}
+		}
+		if _, err := os.Lstat(filepath.Join(dst, "loop")); !os.IsNotExist(err) {
+			t.Errorf("Expected cyclic symlink to be skipped, got err=%v", err)
+		}
+	})
+}
+
+func TestCopyDirectoryHonorsWorkieignore(t *testing.T) {
+	repo := t.TempDir()
+	src := filepath.Join(repo, "data")
+	dst := filepath.Join(t.TempDir(), "data")
+	writeTestTree(t, src)
+
+	ignored, err := ignore.Parse([]string{"data/nested/c/", "a.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.Config = &config.Config{}
+	wm.ignored = ignored
+
+	if err := wm.copyDirectory(src, dst); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dst, "nested", "b.txt")); err != nil {
+		t.Errorf("Expected nested/b.txt to be copied: %v", err)
+	}
+	for _, rel := range []string{"a.txt", "nested/c"} {
+		if _, err := os.Stat(filepath.Join(dst, rel)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be excluded, got err=%v", rel, err)
+		}
+	}
+}
+
+func TestWriteSeedFiles(t *testing.T) {
+	worktree := t.TempDir()
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.SeedFiles = map[string][]byte{".workie/PR_DESCRIPTION.md": []byte("# Fix login\n")}
+
+	if err := wm.writeSeedFiles(worktree); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(worktree, ".workie", "PR_DESCRIPTION.md"))
+	if err != nil {
+		t.Fatalf("Expected seed file to be written: %v", err)
+	}
+	if string(got) != "# Fix login\n" {
+		t.Errorf("Expected seed file content %q, got %q", "# Fix login\n", string(got))
+	}
+
+	for _, path := range []string{"../outside.md", "/tmp/outside.md"} {
+		wm.SeedFiles = map[string][]byte{path: []byte("x")}
+		if err := wm.writeSeedFiles(worktree); err == nil {
+			t.Errorf("Expected error for seed path %q escaping the worktree", path)
+		}
+	}
+}
+
+func TestCopyConfiguredFilesGlob(t *testing.T) {
+	repo := t.TempDir()
+	worktree := t.TempDir()
+	files := map[string]string{
+		"config/app.example": "app",
+		"config/db.example":  "db",
+		"config/app.yaml":    "real",
+		"README.md":          "readme",
+	}
+	for rel, content := range files {
+		path := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.Config = &config.Config{FilesToCopy: []config.CopyEntry{
+		{Src: "config/*.example"},
+		{Src: "README.md"},
+		{Src: "config/db.*", Dst: "defaults"},
+		{Src: "missing/*.txt"},
+	}}
+
+	if err := wm.copyConfiguredFiles(worktree); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for rel, want := range map[string]string{
+		"config/app.example":  "app",
+		"config/db.example":   "db",
+		"README.md":           "readme",
+		"defaults/db.example": "db",
+	} {
+		got, err := os.ReadFile(filepath.Join(worktree, rel))
+		if err != nil {
+			t.Errorf("Expected %s to be copied: %v", rel, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("Expected %s to contain %q, got %q", rel, want, string(got))
+		}
+	}
+	if _, err := os.Stat(filepath.Join(worktree, "config", "app.yaml")); !os.IsNotExist(err) {
+		t.Errorf("Expected config/app.yaml not to be copied, got err=%v", err)
+	}
+}
+
+func TestCopyConfiguredFilesSymlink(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	worktree := filepath.Join(root, "worktrees", "feature")
+	for rel, content := range map[string]string{
+		".env":              "secret",
+		"assets/model.bin":  "weights",
+		"assets/nested/x":   "x",
+		"config/app.yaml":   "app",
+		"config/local.yaml": "local",
+	} {
+		path := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// An existing file in the worktree is replaced by the link
+	if err := os.MkdirAll(worktree, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(worktree, ".env"), []byte("stale"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.Config = &config.Config{
+		CopyMode:    "symlink",
+		FilesToCopy: []config.CopyEntry{{Src: ".env"}, {Src: "assets"}, {Src: "config/local.yaml", Dst: "local.yaml"}},
+	}
+
+	if err := wm.copyConfiguredFiles(worktree); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for rel, src := range map[string]string{
+		".env":       ".env",
+		"assets":     "assets",
+		"local.yaml": "config/local.yaml",
+	} {
+		dst := filepath.Join(worktree, rel)
+		target, err := os.Readlink(dst)
+		if err != nil {
+			t.Errorf("Expected %s to be a symlink: %v", rel, err)
+			continue
+		}
+		if filepath.IsAbs(target) {
+			t.Errorf("Expected a relative link target for %s, got %s", rel, target)
+		}
+		if got, want := filepath.Join(filepath.Dir(dst), target), filepath.Join(repo, src); got != want {
+			t.Errorf("Expected %s to point at %s, got %s", rel, want, got)
+		}
+	}
+
+	// The directory is linked as a whole, so its contents are the repository's
+	got, err := os.ReadFile(filepath.Join(worktree, "assets", "nested", "x"))
+	if err != nil || string(got) != "x" {
+		t.Errorf("Expected assets/nested/x through the link, got %q (%v)", got, err)
+	}
+	if err := os.WriteFile(filepath.Join(worktree, ".env"), []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(repo, ".env")); string(got) != "changed" {
+		t.Errorf("Expected a change through the link to reach the repository, got %q", got)
+	}
+}
+
+func TestCopyFilePreservesMode(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "scripts", "setup.sh")
+	dst := filepath.Join(dir, "worktree", "scripts", "setup.sh")
+	if err := os.MkdirAll(filepath.Dir(src), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, []byte("#!/bin/sh\necho setup\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// WriteFile is subject to the umask, so set the mode explicitly
+	if err := os.Chmod(src, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.Config = &config.Config{}
+
+	if err := wm.copyFile(src, dst); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("Expected destination mode 0755, got %o", info.Mode().Perm())
+	}
+}
+
+func TestCreateWorktreeBranchDryRun(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	for rel, content := range map[string]string{
+		".env":                 "SECRET=1",
+		"config/app.yaml":      "app",
+		"config/cache/tmp.txt": "tmp",
+		".workieignore":        "config/cache/\n",
+	} {
+		path := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.Options.DryRun = true
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+	wm.Config = &config.Config{
+		FilesToCopy: []config.CopyEntry{{Src: ".env"}, {Src: "config"}},
+		Hooks:       &config.Hooks{PreCreate: config.HookCommands("touch pre-create-ran"), PostCreate: config.HookCommands("touch post-create-ran")},
+	}
+	wm.SeedFiles = map[string][]byte{"ISSUE.md": []byte("issue")}
+
+	if err := wm.CreateWorktreeBranch("feature/preview"); err != nil {
+		t.Fatalf("Expected dry run to succeed, got: %v", err)
+	}
+
+	if _, err := os.Stat(wm.WorktreesDir); !os.IsNotExist(err) {
+		t.Errorf("Expected no worktrees directory, got err=%v", err)
+	}
+	if wm.BranchExists("feature/preview") {
+		t.Error("Expected no branch to be created")
+	}
+	if _, err := os.Stat(filepath.Join(repo, "pre-create-ran")); !os.IsNotExist(err) {
+		t.Errorf("Expected pre_create hooks not to run, got err=%v", err)
+	}
+
+	copies, err := wm.planCopy("config", "config", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(copies) != 1 || copies[0].Src != filepath.Join("config", "app.yaml") {
+		t.Errorf("Expected only config/app.yaml to be planned, got %+v", copies)
+	}
+}
+
+func TestCreateWorktreeBranchCheckout(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+	if err := os.MkdirAll(origin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, origin, "init", "-q", "-b", "main")
+	runGit(t, origin, "commit", "-q", "--allow-empty", "-m", "init")
+	runGit(t, origin, "branch", "feature/remote")
+
+	repo := filepath.Join(root, "repo")
+	runGit(t, root, "clone", "-q", origin, repo)
+	runGit(t, repo, "branch", "feature/local")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+	wm.Config = &config.Config{}
+
+	if err := wm.CreateWorktreeBranch("feature/local"); err == nil || !strings.Contains(err.Error(), "--checkout") {
+		t.Fatalf("Expected an existing branch to be refused with a --checkout hint, got: %v", err)
+	}
+
+	wm.Options.Checkout = true
+	if err := wm.CreateWorktreeBranch("feature/local"); err != nil {
+		t.Fatalf("Expected the local branch to be checked out, got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wm.WorktreesDir, "feature", "local", ".git")); err != nil {
+		t.Errorf("Expected a worktree for feature/local: %v", err)
+	}
+
+	if err := wm.CreateWorktreeBranch("feature/remote"); err != nil {
+		t.Fatalf("Expected the remote branch to be checked out, got: %v", err)
+	}
+	out, err := exec.Command("git", "-C", repo, "rev-parse", "--abbrev-ref", "feature/remote@{upstream}").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "origin/feature/remote" {
+		t.Errorf("Expected feature/remote to track origin/feature/remote, got %q, %v", out, err)
+	}
+
+	if err := wm.CreateWorktreeBranch("feature/missing"); err == nil {
+		t.Error("Expected --checkout of a missing branch to fail")
+	}
+}
+
+func TestCopyBudget(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	for rel, size := range map[string]int{
+		".env":                     100,
+		"node_modules/react/a.js":  4000,
+		"node_modules/react/b.js":  2000,
+		"node_modules/lodash/c.js": 1000,
+		"cache/blob":               8000,
+	} {
+		path := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".workieignore"), []byte("cache/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+	wm.Config = &config.Config{
+		FilesToCopy: []config.CopyEntry{{Src: ".env"}, {Src: "node_modules"}, {Src: "cache"}},
+		MaxCopySize: "5KB",
+	}
+
+	err := wm.CreateWorktreeBranch("feature/heavy")
+	if err == nil {
+		t.Fatal("Expected copying more than max_copy_size to be refused")
+	}
+	for _, want := range []string{"6.9 KiB", "5.0 KiB", filepath.Join("node_modules", "react") + "   5.9 KiB", "To fix this"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected the error to contain %q, got:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "cache") {
+		t.Errorf("Expected ignored files not to count, got:\n%v", err)
+	}
+	if wm.BranchExists("feature/heavy") {
+		t.Error("Expected no branch to be created")
+	}
+
+	// Hardlinks use no extra space, so they are not charged against the budget
+	wm.Config.CopyMode = "hardlink"
+	if err := wm.checkCopyBudget(); err != nil {
+		t.Errorf("Expected hardlinks not to count against max_copy_size, got: %v", err)
+	}
+	wm.Config.CopyMode = ""
+
+	wm.Config.MaxCopySize = "8KB"
+	if err := wm.CreateWorktreeBranch("feature/heavy"); err != nil {
+		t.Fatalf("Expected copying within max_copy_size to succeed, got: %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// editorCommand builds the command that opens path in the configured editor.
+// The editor setting may include arguments, e.g. "code --new-window".
+func (wm *WorktreeManager) editorCommand(path string) (*exec.Cmd, error) {
+	editor := wm.Config.GetEditor()
+	if editor == "" {
+		return nil, fmt.Errorf("no editor configured\n\nTo fix this:\n  • Set editor in .workie.yaml, e.g. editor: code\n  • Or export VISUAL or EDITOR in your shell")
+	}
+
+	fields := strings.Fields(editor)
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		return nil, fmt.Errorf("editor '%s' not found\n\nTo fix this:\n  • Install it or add its directory to your PATH\n  • Or set editor in .workie.yaml to a command that exists (e.g. for VS Code run 'Shell Command: Install code command in PATH')", fields[0])
+	}
+
+	cmd := exec.Command(fields[0], append(fields[1:], path)...)
+	cmd.Dir = path
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd, nil
+}
+
+// OpenInEditor opens a worktree in the configured editor and waits for the
+// editor command to return. Terminal editors take over the terminal until they
+// exit; GUI launchers such as code return right away.
+func (wm *WorktreeManager) OpenInEditor(path string) error {
+	cmd, err := wm.editorCommand(path)
+	if err != nil {
+		return err
+	}
+
+	wm.printf("\n📝 Opening %s in %s\n", path, strings.Join(cmd.Args[:len(cmd.Args)-1], " "))
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("editor exited with status %d", exitErr.ExitCode())
+		}
+		return fmt.Errorf("failed to start editor: %w", err)
+	}
+	return nil
+}
@@ -1,8 +1,13 @@
 package manager
 
 import (
+	"context"
 	"github.com/agoodway/workie/config"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -23,7 +28,7 @@ func TestExecuteHooks(t *testing.T) {
 			Hooks: &config.Hooks{},
 		}
 
-		err := wm.ExecuteHooks([]string{}, tempDir, "post_create")
+		err := wm.ExecuteHooks([]config.HookCommand{}, tempDir, "post_create")
 		if err != nil {
 			t.Errorf("Expected no error for empty hooks, got: %v", err)
 		}
@@ -37,7 +42,7 @@ func TestExecuteHooks(t *testing.T) {
 		}
 
 		// Use echo command which should be available on all systems
-		hooks := []string{"echo 'test successful'"}
+		hooks := config.HookCommands("echo 'test successful'")
 		err := wm.ExecuteHooks(hooks, tempDir, "post_create")
 		if err != nil {
 			t.Errorf("Expected no error for successful command, got: %v", err)
@@ -51,7 +56,7 @@ func TestExecuteHooks(t *testing.T) {
 			Hooks: &config.Hooks{},
 		}
 
-		hooks := []string{"nonexistent-command-12345"}
+		hooks := config.HookCommands("nonexistent-command-12345")
 		err := wm.ExecuteHooks(hooks, tempDir, "post_create")
 		// Note: We don't expect ExecuteHooks to fail completely for individual command failures
 		// It should continue processing and only fail if ALL hooks fail
@@ -68,11 +73,11 @@ func TestExecuteHooks(t *testing.T) {
 			Hooks: &config.Hooks{},
 		}
 
-		hooks := []string{
+		hooks := config.HookCommands(
 			"echo 'first command success'",
 			"nonexistent-command-12345",
 			"echo 'third command success'",
-		}
+		)
 		err := wm.ExecuteHooks(hooks, tempDir, "post_create")
 		// Should not fail completely since some commands succeed
 		if err != nil {
@@ -87,10 +92,10 @@ func TestExecuteHooks(t *testing.T) {
 			Hooks: &config.Hooks{},
 		}
 
-		hooks := []string{
+		hooks := config.HookCommands(
 			"nonexistent-command-1",
 			"nonexistent-command-2",
-		}
+		)
 		err := wm.ExecuteHooks(hooks, tempDir, "post_create")
 		if err == nil {
 			t.Error("Expected error when all hooks fail, got none")
@@ -108,7 +113,7 @@ func TestExecuteHooks(t *testing.T) {
 		}
 
 		invalidDir := "/nonexistent/directory/path"
-		hooks := []string{"echo 'test'"}
+		hooks := config.HookCommands("echo 'test'")
 		err := wm.ExecuteHooks(hooks, invalidDir, "post_create")
 		if err == nil {
 			t.Error("Expected error for invalid working directory, got none")
@@ -128,7 +133,7 @@ func TestExecuteHooks(t *testing.T) {
 		}
 
 		// Command that sleeps longer than timeout
-		hooks := []string{"sleep 65"} // 65 seconds > 1 minute timeout
+		hooks := config.HookCommands("sleep 65") // 65 seconds > 1 minute timeout
 
 		start := time.Now()
 		err := wm.ExecuteHooks(hooks, tempDir, "post_create")
@@ -153,11 +158,11 @@ func TestExecuteHooks(t *testing.T) {
 		}
 
 		// Include empty command in the list
-		hooks := []string{
+		hooks := config.HookCommands(
 			"echo 'before empty'",
 			"", // Empty command
 			"echo 'after empty'",
-		}
+		)
 		err := wm.ExecuteHooks(hooks, tempDir, "post_create")
 		if err != nil {
 			t.Errorf("Expected no error with empty command in list, got: %v", err)
@@ -171,7 +176,7 @@ func TestHasHooks(t *testing.T) {
 		wm := New()
 		wm.Config = &config.Config{
 			Hooks: &config.Hooks{
-				PostCreate: []string{"echo 'test'"},
+				PostCreate: config.HookCommands("echo 'test'"),
 			},
 		}
 
@@ -195,7 +200,7 @@ func TestHasHooks(t *testing.T) {
 		wm := New()
 		wm.Config = &config.Config{
 			Hooks: &config.Hooks{
-				PreRemove: []string{"echo 'cleanup'"},
+				PreRemove: config.HookCommands("echo 'cleanup'"),
 			},
 		}
 
@@ -228,7 +233,7 @@ func TestHookTimeout(t *testing.T) {
 		// Use reflection or indirect testing since getHookTimeout is not exported
 		// We'll test via ExecuteHooks with a command that should complete within default timeout
 		wm.Options.Quiet = true
-		hooks := []string{"echo 'timeout test'"}
+		hooks := config.HookCommands("echo 'timeout test'")
 		err := wm.ExecuteHooks(hooks, "/tmp", "test")
 		if err != nil {
 			t.Errorf("Expected no error with default timeout, got: %v", err)
@@ -244,10 +249,489 @@ func TestHookTimeout(t *testing.T) {
 		}
 
 		wm.Options.Quiet = true
-		hooks := []string{"echo 'custom timeout test'"}
+		hooks := config.HookCommands("echo 'custom timeout test'")
 		err := wm.ExecuteHooks(hooks, "/tmp", "test")
 		if err != nil {
 			t.Errorf("Expected no error with custom timeout, got: %v", err)
 		}
 	})
+
+	t.Run("per-hook timeout", func(t *testing.T) {
+		wm := New()
+		wm.Config = &config.Config{
+			Hooks: &config.Hooks{
+				TimeoutMinutes: 10,
+			},
+		}
+
+		result := wm.executeHookCommand(config.HookCommand{Command: "sleep 5", Timeout: 100 * time.Millisecond}, t.TempDir(), "post_create", 1)
+		if !result.TimedOut {
+			t.Fatalf("Expected the hook's own timeout to apply, got: %+v", result)
+		}
+		if result.Duration != 100*time.Millisecond {
+			t.Errorf("Expected a 100ms timeout, got %v", result.Duration)
+		}
+
+		// Every stage of a timed-out pipeline is stopped before the result is read
+		start := time.Now()
+		result = wm.executeHookCommand(config.HookCommand{Command: "sleep 5 | cat", Timeout: 100 * time.Millisecond}, t.TempDir(), "post_create", 1)
+		if !result.TimedOut || time.Since(start) > 3*time.Second {
+			t.Errorf("Expected the pipeline to be stopped at its timeout, got %+v after %v", result, time.Since(start))
+		}
+
+		if got := wm.hookTimeout(config.HookCommand{Command: "true"}); got != 10*time.Minute {
+			t.Errorf("Expected hooks without a timeout to use timeout_minutes, got %v", got)
+		}
+	})
+}
+
+// TestExecuteRequiredHooks tests that required hooks stop at the first failure
+func TestExecuteRequiredHooks(t *testing.T) {
+	tempDir := t.TempDir()
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.Config = &config.Config{
+		Hooks: &config.Hooks{},
+	}
+
+	if err := wm.ExecuteRequiredHooks(config.HookCommands("true", "echo ok"), tempDir, "pre_finish"); err != nil {
+		t.Errorf("Expected no error for passing hooks, got: %v", err)
+	}
+
+	// The hook after the failing one must not run
+	marker := tempDir + "/ran"
+	err := wm.ExecuteRequiredHooks(config.HookCommands("true", "false", "touch "+marker), tempDir, "pre_finish")
+	if err == nil || !strings.Contains(err.Error(), "pre_finish hook failed: false") {
+		t.Errorf("Expected error naming the failing hook, got: %v", err)
+	}
+	if _, statErr := os.Stat(marker); !os.IsNotExist(statErr) {
+		t.Error("Expected hooks after the failure to be skipped")
+	}
+}
+
+// TestHookEnvironment tests that hooks see the WORKIE_* variables for their worktree
+func TestHookEnvironment(t *testing.T) {
+	tempDir := t.TempDir()
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = "/src/app"
+	wm.RepoName = "app"
+	wm.HookTarget = HookTarget{Branch: "feature/env", WorktreePath: tempDir}
+	wm.Config = &config.Config{Hooks: &config.Hooks{}}
+
+	hooks := config.HookCommands("sh -c 'env | grep ^WORKIE_ | sort > env.txt'")
+	if err := wm.ExecuteHooks(hooks, tempDir, "post_create"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tempDir, "env.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "WORKIE_BRANCH=feature/env\n" +
+		"WORKIE_HOOK_TYPE=post_create\n" +
+		"WORKIE_REPO_NAME=app\n" +
+		"WORKIE_REPO_PATH=/src/app\n" +
+		"WORKIE_WORKTREE_PATH=" + tempDir + "\n"
+	if string(data) != want {
+		t.Errorf("Unexpected hook environment:\n%s\nwant:\n%s", data, want)
+	}
+
+	if _, ok := os.LookupEnv("WORKIE_BRANCH"); ok {
+		t.Error("Expected hook variables not to leak into this process")
+	}
+}
+
+// TestHookCleanEnvironment tests that hooks.clean_env keeps workie's environment
+// from hooks, except PATH, HOME, hooks.env and the WORKIE_* variables
+func TestHookCleanEnvironment(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("LEAKED_SETTING", "1")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.HookTarget = HookTarget{Branch: "feature/env", WorktreePath: tempDir}
+	wm.Config = &config.Config{Hooks: &config.Hooks{Env: map[string]string{"NODE_ENV": "test"}}}
+
+	readEnv := func() string {
+		t.Helper()
+		hooks := config.HookCommands("sh -c 'env > env.txt'")
+		if err := wm.ExecuteHooks(hooks, tempDir, "post_create"); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(tempDir, "env.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "\n" + string(data)
+	}
+
+	env := readEnv()
+	if !strings.Contains(env, "\nLEAKED_SETTING=1\n") || !strings.Contains(env, "\nNODE_ENV=test\n") {
+		t.Errorf("Expected workie's environment and hooks.env by default, got:\n%s", env)
+	}
+
+	wm.Config.Hooks.CleanEnv = true
+	env = readEnv()
+	if strings.Contains(env, "LEAKED_SETTING") {
+		t.Errorf("Expected workie's environment to be left out, got:\n%s", env)
+	}
+	for _, want := range []string{"\nPATH=" + os.Getenv("PATH") + "\n", "\nNODE_ENV=test\n", "\nWORKIE_BRANCH=feature/env\n"} {
+		if !strings.Contains(env, want) {
+			t.Errorf("Expected %q in the clean environment, got:\n%s", strings.TrimSpace(want), env)
+		}
+	}
+}
+
+// TestPreCreateHooks tests that a failing pre_create hook aborts worktree creation
+func TestPreCreateHooks(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+	wm.Config = &config.Config{
+		Hooks: &config.Hooks{PreCreate: config.HookCommands("touch pre-create-ran", "false")},
+	}
+
+	if err := wm.CreateWorktreeBranch("feature/blocked"); err == nil {
+		t.Fatal("Expected a failing pre_create hook to abort creation")
+	}
+	if _, err := os.Stat(filepath.Join(repo, "pre-create-ran")); err != nil {
+		t.Errorf("Expected pre_create hooks to run in the repository: %v", err)
+	}
+	if wm.BranchExists("feature/blocked") {
+		t.Error("Expected no branch to be created")
+	}
+	if _, err := os.Stat(filepath.Join(wm.WorktreesDir, "feature", "blocked")); !os.IsNotExist(err) {
+		t.Errorf("Expected no worktree directory, got err=%v", err)
+	}
+
+	wm.Config.Hooks.PreCreate = config.HookCommands("true")
+	if err := wm.CreateWorktreeBranch("feature/allowed"); err != nil {
+		t.Fatalf("Expected passing pre_create hooks to allow creation, got: %v", err)
+	}
+	if !wm.BranchExists("feature/allowed") {
+		t.Error("Expected branch to be created")
+	}
+}
+
+// TestPreCopyHooks tests that pre_copy hooks run in the repository before files are copied
+func TestPreCopyHooks(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+	wm.Config = &config.Config{
+		FilesToCopy: []config.CopyEntry{{Src: ".env"}},
+		Hooks: &config.Hooks{
+			PreCopy: config.HookCommands(`sh -c "echo SECRET=1 > .env && echo $WORKIE_HOOK_TYPE > hook-type"`),
+		},
+	}
+
+	if err := wm.CreateWorktreeBranch("feature/secrets"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(wm.WorktreesDir, "feature", "secrets", ".env"))
+	if err != nil || string(data) != "SECRET=1\n" {
+		t.Errorf("Expected the generated file to be copied into the worktree, got %q, %v", data, err)
+	}
+	if data, err := os.ReadFile(filepath.Join(repo, "hook-type")); err != nil || string(data) != "pre_copy\n" {
+		t.Errorf("Expected pre_copy hooks to run in the repository, got %q, %v", data, err)
+	}
+}
+
+// TestPostCopyHooks tests that post_copy hooks run in the worktree after files
+// are copied and before post_create hooks
+func TestPostCopyHooks(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+	if err := os.WriteFile(filepath.Join(repo, "setup.sh"), []byte("echo setup\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+	wm.Config = &config.Config{
+		FilesToCopy: []config.CopyEntry{{Src: "setup.sh"}},
+		Hooks: &config.Hooks{
+			PostCopy:   config.HookCommands("chmod +x setup.sh", `sh -c "echo $WORKIE_HOOK_TYPE >> hooks.log"`),
+			PostCreate: config.HookCommands(`sh -c "test -x setup.sh && echo $WORKIE_HOOK_TYPE >> hooks.log"`),
+		},
+	}
+
+	if err := wm.CreateWorktreeBranch("feature/post-copy"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(wm.WorktreesDir, "feature", "post-copy", "hooks.log"))
+	if err != nil || string(data) != "post_copy\npost_create\n" {
+		t.Errorf("Expected post_copy to run on the copied files before post_create, got %q, %v", data, err)
+	}
+}
+
+func TestWorktreeHooks(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+	wm.Config = &config.Config{
+		Hooks: &config.Hooks{
+			PostCreate: config.HookCommands(`sh -c "echo shared >> hooks.log"`),
+		},
+	}
+	wm.SeedFiles = map[string][]byte{
+		".workie.local.yaml": []byte("hooks:\n  post_create:\n    - sh -c \"echo worktree >> hooks.log\"\n"),
+	}
+
+	if err := wm.CreateWorktreeBranch("feature/search"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	worktree := filepath.Join(wm.WorktreesDir, "feature", "search")
+	data, err := os.ReadFile(filepath.Join(worktree, "hooks.log"))
+	if err != nil || string(data) != "shared\nworktree\n" {
+		t.Errorf("Expected the worktree's post_create hook after the shared one, got %q, %v", data, err)
+	}
+
+	// finish merges the file the same way before running pre_remove
+	other := New()
+	other.Options.Quiet = true
+	other.Config = &config.Config{Hooks: &config.Hooks{PreRemove: config.HookCommands("make clean")}}
+	other.UseWorktreeHooks(t.TempDir())
+	if len(other.Config.Hooks.PreRemove) != 1 || len(other.Config.Hooks.PostCreate) != 0 {
+		t.Errorf("Expected the configured hooks without a local file, got %+v", other.Config.Hooks)
+	}
+	other.UseWorktreeHooks(worktree)
+	if len(other.Config.Hooks.PreRemove) != 1 || len(other.Config.Hooks.PostCreate) != 1 {
+		t.Errorf("Expected the worktree's post_create hook to be merged, got %+v", other.Config.Hooks)
+	}
+}
+
+func TestCommittedWorktreeHooks(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+	runGit(t, repo, "checkout", "-q", "-b", "contributed")
+	local := "hooks:\n  post_create:\n    - sh -c \"echo contributed >> hooks.log\"\n"
+	if err := os.WriteFile(filepath.Join(repo, ".workie.local.yaml"), []byte(local), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".workie.local.yaml")
+	runGit(t, repo, "commit", "-q", "-m", "add hooks")
+	runGit(t, repo, "checkout", "-q", "main")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.Options.Checkout = true
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+	wm.Config = &config.Config{Hooks: &config.Hooks{}}
+
+	if err := wm.CreateWorktreeBranch("contributed"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	worktree := filepath.Join(wm.WorktreesDir, "contributed")
+	if _, err := os.Stat(filepath.Join(worktree, ".workie.local.yaml")); err != nil {
+		t.Fatalf("Expected the committed file to be checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(worktree, "hooks.log")); !os.IsNotExist(err) {
+		t.Errorf("Expected the committed file's post_create hook not to run, got: %v", err)
+	}
+	if len(wm.Config.Hooks.PostCreate) != 0 {
+		t.Errorf("Expected the committed file's hooks not to be merged, got %+v", wm.Config.Hooks)
+	}
+
+	// An untracked file in a worktree of a fork's pull request is ignored too
+	if err := os.WriteFile(filepath.Join(root, ".workie.local.yaml"), []byte(local), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fork := New()
+	fork.Options.Quiet = true
+	fork.Options.FromFork = true
+	fork.Config = &config.Config{Hooks: &config.Hooks{}}
+	fork.UseWorktreeHooks(root)
+	if len(fork.Config.Hooks.PostCreate) != 0 {
+		t.Errorf("Expected no hooks merged for a fork's pull request, got %+v", fork.Config.Hooks)
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	shell := func(command string) [][]string {
+		return [][]string{shellCommand(context.Background(), "", runtime.GOOS, command).Args}
+	}
+	tests := []struct {
+		command string
+		want    [][]string // Arguments of each command run
+	}{
+		{"npm install", [][]string{{"npm", "install"}}},
+		{"echo 'test successful'", [][]string{{"echo", "test successful"}}},
+		{`git log --oneline | grep "fix: a|b" | head -5`, [][]string{{"git", "log", "--oneline"}, {"grep", "fix: a|b"}, {"head", "-5"}}},
+		{"npm test && npm run build", shell("npm test && npm run build")},
+		{"make > build.log", shell("make > build.log")},
+		{"false || true", shell("false || true")},
+		{`echo "$HOME" | cat`, shell(`echo "$HOME" | cat`)},
+		{"rm *.tmp", shell("rm *.tmp")},
+		{"NODE_ENV=test npm test", shell("NODE_ENV=test npm test")},
+		{"echo 'unterminated", shell("echo 'unterminated")},
+	}
+
+	for _, tt := range tests {
+		cmds, err := parseCommand(context.Background(), tt.command, "")
+		if err != nil {
+			t.Errorf("parseCommand(%q) failed: %v", tt.command, err)
+			continue
+		}
+		var got [][]string
+		for _, cmd := range cmds {
+			got = append(got, cmd.Args)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseCommand(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+
+	if _, err := parseCommand(context.Background(), "  ", ""); err == nil {
+		t.Error("Expected an error for an empty command")
+	}
+}
+
+func TestHookPipeline(t *testing.T) {
+	for _, name := range []string{"printf", "sort", "false"} {
+		if _, err := exec.LookPath(name); err != nil {
+			t.Skipf("%s not available", name)
+		}
+	}
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.Config = &config.Config{Hooks: &config.Hooks{}}
+	dir := t.TempDir()
+
+	result := wm.executeHookCommand(config.HookCommand{Command: `printf 'b\na\n' | sort`}, dir, "post_create", 0)
+	if !result.Success || result.Stdout != "a\nb" {
+		t.Errorf("Expected the pipeline to print sorted lines, got %+v", result)
+	}
+
+	// As in sh, the last command decides the pipeline's exit code
+	result = wm.executeHookCommand(config.HookCommand{Command: "printf x | false"}, dir, "post_create", 0)
+	if result.Success || result.ExitCode != 1 {
+		t.Errorf("Expected the failing last stage to fail the pipeline, got %+v", result)
+	}
+	result = wm.executeHookCommand(config.HookCommand{Command: "false | sort"}, dir, "post_create", 0)
+	if !result.Success {
+		t.Errorf("Expected a failing first stage not to fail the pipeline, got %+v", result)
+	}
+
+	result = wm.executeHookCommand(config.HookCommand{Command: "printf x | nonexistent-command-12345"}, dir, "post_create", 0)
+	if result.Success || result.Error == nil {
+		t.Errorf("Expected a missing command to fail the pipeline, got %+v", result)
+	}
+}
+
+func TestShellCommand(t *testing.T) {
+	tests := []struct {
+		shell string
+		goos  string
+		want  []string
+	}{
+		{"", "linux", []string{"sh", "-c", "make && make test"}},
+		{"", "darwin", []string{"sh", "-c", "make && make test"}},
+		{"", "windows", []string{"cmd", "/C", "make && make test"}},
+		{"bash", "linux", []string{"bash", "-c", "make && make test"}},
+		{"pwsh", "linux", []string{"pwsh", "-NoProfile", "-Command", "make && make test"}},
+		{"powershell.exe", "windows", []string{"powershell.exe", "-NoProfile", "-Command", "make && make test"}},
+		{`C:\Windows\System32\cmd.exe`, "windows", []string{`C:\Windows\System32\cmd.exe`, "/C", "make && make test"}},
+		{"/usr/local/bin/zsh", "darwin", []string{"/usr/local/bin/zsh", "-c", "make && make test"}},
+	}
+
+	for _, tt := range tests {
+		if got := shellCommand(context.Background(), tt.shell, tt.goos, "make && make test").Args; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("shellCommand(%q, %q) = %q, want %q", tt.shell, tt.goos, got, tt.want)
+		}
+	}
+}
+
+func TestHookShell(t *testing.T) {
+	wm := New()
+	wm.Options.Quiet = true
+	wm.Config = &config.Config{Hooks: &config.Hooks{}}
+	dir := t.TempDir()
+
+	command := "echo first && echo second"
+	result := wm.executeHookCommand(config.HookCommand{Command: command}, dir, "post_create", 0)
+	if !result.Success {
+		t.Fatalf("Expected the platform shell to run %q, got %+v", command, result)
+	}
+	if lines := strings.Fields(result.Stdout); len(lines) != 2 || lines[1] != "second" {
+		t.Errorf("Expected both commands to run, got %q", result.Stdout)
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+	wm.Config.Hooks.Shell = "bash"
+	result = wm.executeHookCommand(config.HookCommand{Command: "echo $BASH_VERSION"}, dir, "post_create", 0)
+	if !result.Success || result.Stdout == "" {
+		t.Errorf("Expected hooks.shell to run the command in bash, got %+v", result)
+	}
 }
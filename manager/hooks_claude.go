@@ -9,9 +9,20 @@ import (
 	"time"
 
 	"github.com/agoodway/workie/ai"
+	"github.com/agoodway/workie/config"
 	"github.com/agoodway/workie/hooks"
 )
 
+// newAIService creates the AI service with usage reporting for this repository
+func (wm *WorktreeManager) newAIService() (*ai.Service, error) {
+	aiService, err := ai.NewService(wm.Config)
+	if err != nil {
+		return nil, err
+	}
+	aiService.SetUsageReporter(ai.NewUsageReporter(wm.Config, wm.RepoPath, wm.Options.Verbose))
+	return aiService, nil
+}
+
 // ExecuteClaudePreToolUseHooks executes PreToolUse hooks with AI decision support
 // It reads the hook input from stdin, executes hooks, and returns the decision as JSON
 func (wm *WorktreeManager) ExecuteClaudePreToolUseHooks(enableAI bool) error {
@@ -34,9 +45,16 @@ func (wm *WorktreeManager) ExecuteClaudePreToolUseHooks(enableAI bool) error {
 
 	var decision *hooks.HookDecision
 
+	if enableAI && wm.Config.RequiresAI() && !wm.Config.IsAIEnabled() {
+		return fmt.Errorf("AI decision requested but AI is not configured (ai.require is set)")
+	}
+
 	if enableAI && wm.Config.IsAIEnabled() {
 		// Use AI to make the decision
-		aiService, err := ai.NewService(wm.Config)
+		aiService, err := wm.newAIService()
+		if err != nil && wm.Config.RequiresAI() {
+			return fmt.Errorf("failed to create AI service: %w", err)
+		}
 		if err != nil {
 			wm.printf("Warning: Failed to create AI service: %v\n", err)
 			// Fall back to rule-based decision
@@ -46,6 +64,9 @@ func (wm *WorktreeManager) ExecuteClaudePreToolUseHooks(enableAI bool) error {
 			defer cancel()
 
 			decision, err = aiService.AnalyzeToolUse(ctx, &input, hookResults)
+			if err != nil && wm.Config.RequiresAI() {
+				return fmt.Errorf("AI analysis failed: %w", err)
+			}
 			if err != nil {
 				wm.printf("Warning: AI analysis failed: %v\n", err)
 				// Fall back to rule-based decision
@@ -66,11 +87,11 @@ func (wm *WorktreeManager) ExecuteClaudePreToolUseHooks(enableAI bool) error {
 }
 
 // executeHooksForDecision executes hooks and collects results for decision making
-func (wm *WorktreeManager) executeHooksForDecision(hookCommands []string, workDir string) []hooks.HookExecutionResult {
+func (wm *WorktreeManager) executeHooksForDecision(hookCommands []config.HookCommand, workDir string) []hooks.HookExecutionResult {
 	results := make([]hooks.HookExecutionResult, 0, len(hookCommands))
 
 	for i, hookCommand := range hookCommands {
-		managerResult := wm.executeHookCommand(hookCommand, workDir, i+1)
+		managerResult := wm.executeHookCommand(hookCommand, workDir, "claude_pre_tool_use", i+1)
 		// Convert to hooks.HookExecutionResult
 		result := hooks.HookExecutionResult{
 			Index:    managerResult.Index,
@@ -0,0 +1,256 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/agoodway/workie/errcode"
+	"github.com/agoodway/workie/table"
+)
+
+// WorktreeStatus describes a worktree for machine-readable output
+type WorktreeStatus struct {
+	Path   string `json:"path"`
+	Branch string `json:"branch"`
+	Commit string `json:"commit"`
+	IsMain bool   `json:"is_main"` // The main repository rather than a linked worktree
+	Dirty  bool   `json:"dirty"`   // Has uncommitted changes
+
+	Issue *IssueLink `json:"issue,omitempty"` // Issue recorded in the worktree's metadata, if any
+}
+
+// GetWorktreeStatuses returns all worktrees with their main/dirty state
+func (wm *WorktreeManager) GetWorktreeStatuses() ([]WorktreeStatus, error) {
+	worktrees, err := wm.GetWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]WorktreeStatus, 0, len(worktrees))
+	for i, wt := range worktrees {
+		statuses = append(statuses, WorktreeStatus{
+			Path:   wt.Path,
+			Branch: wt.Branch,
+			Commit: wt.Commit,
+			IsMain: i == 0, // git always lists the main worktree first
+			Dirty:  hasUncommittedChanges(wt.Path),
+		})
+		if meta, err := ReadWorktreeMetadata(wt.Path); err == nil {
+			statuses[i].Issue = meta.Issue
+		}
+	}
+	return statuses, nil
+}
+
+// WriteWorktreesJSON writes all worktrees as a JSON array
+func (wm *WorktreeManager) WriteWorktreesJSON(w io.Writer) error {
+	statuses, err := wm.GetWorktreeStatuses()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(statuses)
+}
+
+// writeWorktreeTable writes worktrees as a table of branch, commit, state and path,
+// with an issue column when any worktree was begun from an issue
+func writeWorktreeTable(w io.Writer, statuses []WorktreeStatus) error {
+	showIssues := false
+	for _, status := range statuses {
+		if status.Issue != nil {
+			showIssues = true
+		}
+	}
+
+	headers := []string{"BRANCH", "COMMIT", "STATE", "PATH"}
+	if showIssues {
+		headers = []string{"BRANCH", "COMMIT", "STATE", "ISSUE", "PATH"}
+	}
+	tbl := table.New(headers...)
+	for _, status := range statuses {
+		branch := status.Branch
+		if branch == "" {
+			branch = "(detached)"
+		}
+
+		var state []string
+		if status.IsMain {
+			state = append(state, "main")
+		}
+		if status.Dirty {
+			state = append(state, "dirty")
+		}
+
+		commit := status.Commit
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+
+		if !showIssues {
+			tbl.AddRow(branch, commit, strings.Join(state, ","), status.Path)
+			continue
+		}
+		issue := ""
+		if status.Issue != nil {
+			issue = status.Issue.String()
+		}
+		tbl.AddRow(branch, commit, strings.Join(state, ","), issue, status.Path)
+	}
+	return tbl.Render(w)
+}
+
+// WriteWorktreesTSV writes one line per worktree with the branch, path and commit
+// separated by tabs and no other decoration, for fuzzy finders such as fzf
+func (wm *WorktreeManager) WriteWorktreesTSV(w io.Writer) error {
+	worktrees, err := wm.GetWorktrees()
+	if err != nil {
+		return err
+	}
+
+	for _, wt := range worktrees {
+		branch := wt.Branch
+		if branch == "" {
+			branch = "(detached)"
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", branch, wt.Path, wt.Commit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WorktreeSize is the disk usage of a linked worktree
+type WorktreeSize struct {
+	Path   string `json:"path"`
+	Branch string `json:"branch"`
+	Bytes  int64  `json:"bytes"` // Total size of the files in the worktree
+}
+
+// GetWorktreeSizes returns the disk usage of every linked worktree, largest
+// first. The main repository and worktrees whose directory is gone are left out.
+func (wm *WorktreeManager) GetWorktreeSizes() ([]WorktreeSize, error) {
+	worktrees, err := wm.GetWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	var sizes []WorktreeSize
+	for i, wt := range worktrees {
+		if i == 0 || wt.Prunable { // git always lists the main worktree first
+			continue
+		}
+		used, err := directorySize(wt.Path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure %s: %w", wt.Path, err)
+		}
+		sizes = append(sizes, WorktreeSize{Path: wt.Path, Branch: wt.Branch, Bytes: used})
+	}
+
+	sort.SliceStable(sizes, func(i, j int) bool { return sizes[i].Bytes > sizes[j].Bytes })
+	return sizes, nil
+}
+
+// WriteWorktreeSizesJSON writes the disk usage of every linked worktree as a JSON array
+func (wm *WorktreeManager) WriteWorktreeSizesJSON(w io.Writer) error {
+	sizes, err := wm.GetWorktreeSizes()
+	if err != nil {
+		return err
+	}
+	if sizes == nil {
+		sizes = []WorktreeSize{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sizes)
+}
+
+// ListWorktreeSizes prints the disk usage of every linked worktree, largest
+// first, followed by the total
+func (wm *WorktreeManager) ListWorktreeSizes() error {
+	sizes, err := wm.GetWorktreeSizes()
+	if err != nil {
+		return fmt.Errorf("cannot measure worktrees: %w\n\nTo fix this:\n  • Ensure you're in a valid git repository\n  • Check that the worktree directories are readable", err)
+	}
+
+	if len(sizes) == 0 {
+		wm.printf("\n📋 No worktrees found\n")
+		return nil
+	}
+
+	wm.printf("\n💾 Worktree disk usage:\n")
+	return writeWorktreeSizeTable(os.Stdout, sizes)
+}
+
+// writeWorktreeSizeTable writes worktree sizes as a table followed by a total row
+func writeWorktreeSizeTable(w io.Writer, sizes []WorktreeSize) error {
+	tbl := table.New("SIZE", "BRANCH", "PATH")
+	var total int64
+	for _, size := range sizes {
+		branch := size.Branch
+		if branch == "" {
+			branch = "(detached)"
+		}
+		tbl.AddRow(formatBytes(size.Bytes), branch, size.Path)
+		total += size.Bytes
+	}
+	tbl.AddRow(formatBytes(total), fmt.Sprintf("total (%d worktrees)", len(sizes)))
+	return tbl.Render(w)
+}
+
+// FindWorktree returns the path of the worktree that has branchName checked out,
+// falling back to the expected path under WorktreesDir
+func (wm *WorktreeManager) FindWorktree(branchName string) (string, error) {
+	worktrees, err := wm.GetWorktrees()
+	if err != nil {
+		return "", err
+	}
+
+	for _, wt := range worktrees {
+		if wt.Branch == branchName {
+			return wt.Path, nil
+		}
+	}
+
+	if wm.WorktreesDir != "" {
+		path := wm.WorktreePath(branchName)
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path, nil
+		}
+	}
+
+	var available []string
+	for _, wt := range worktrees {
+		if wt.Branch != "" {
+			available = append(available, fmt.Sprintf("    %s  %s", wt.Branch, wt.Path))
+		}
+	}
+	if len(available) == 0 {
+		return "", errcode.Errorf(errcode.WorktreeNotFound, "no worktree found for branch '%s'\n\nTo fix this:\n  • Create one with: workie begin %s", branchName, branchName)
+	}
+	return "", errcode.Errorf(errcode.WorktreeNotFound, "no worktree found for branch '%s'\n\nAvailable worktrees:\n%s\n\nTo fix this:\n  • Use one of the branches listed above\n  • Or create a worktree with: workie begin %s", branchName, strings.Join(available, "\n"), branchName)
+}
+
+// hasUncommittedChanges reports whether the worktree at path has uncommitted changes.
+// Worktrees whose directory is missing are reported as clean.
+func hasUncommittedChanges(path string) bool {
+	if _, err := os.Stat(path); err != nil {
+		return false
+	}
+
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(output)) != ""
+}
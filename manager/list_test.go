@@ -0,0 +1,217 @@
+package manager
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteWorktreesJSON(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	worktree := filepath.Join(root, "repo-worktrees", "feature")
+	runGit(t, repo, "worktree", "add", "-q", "-b", "feature", worktree)
+	if err := os.WriteFile(filepath.Join(worktree, "new.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wm := New()
+	wm.RepoPath = repo
+
+	var buf bytes.Buffer
+	if err := wm.WriteWorktreesJSON(&buf); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var statuses []WorktreeStatus
+	if err := json.Unmarshal(buf.Bytes(), &statuses); err != nil {
+		t.Fatalf("Expected valid JSON, got %q: %v", buf.String(), err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("Expected 2 worktrees, got %d", len(statuses))
+	}
+
+	main, feature := statuses[0], statuses[1]
+	if !main.IsMain || main.Branch != "main" || main.Dirty {
+		t.Errorf("Unexpected main worktree status: %+v", main)
+	}
+	if feature.IsMain || feature.Branch != "feature" || !feature.Dirty || feature.Commit == "" {
+		t.Errorf("Unexpected feature worktree status: %+v", feature)
+	}
+}
+
+func TestWriteWorktreeTable(t *testing.T) {
+	statuses := []WorktreeStatus{
+		{Path: "/src/repo", Branch: "main", Commit: "64c2f92a1b", IsMain: true},
+		{Path: "/src/repo-worktrees/feature/login", Branch: "feature/login", Commit: "a5dd1ea9f0", Dirty: true},
+		{Path: "/src/repo-worktrees/detached", Commit: "0123456789"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeWorktreeTable(&buf, statuses); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected a header, separator and 3 rows, got:\n%s", buf.String())
+	}
+	if !strings.Contains(lines[0], "BRANCH") || !strings.Contains(lines[0], "PATH") {
+		t.Errorf("Unexpected header: %q", lines[0])
+	}
+	if got := strings.Fields(lines[2]); strings.Join(got, " ") != "main 64c2f92 main /src/repo" {
+		t.Errorf("Unexpected main row: %q", lines[2])
+	}
+	if got := strings.Fields(lines[3]); strings.Join(got, " ") != "feature/login a5dd1ea dirty /src/repo-worktrees/feature/login" {
+		t.Errorf("Unexpected feature row: %q", lines[3])
+	}
+	if !strings.HasPrefix(lines[4], "(detached)") {
+		t.Errorf("Expected a detached worktree to be labelled, got %q", lines[4])
+	}
+
+	// Paths start in the same column on every row
+	column := strings.Index(lines[0], "PATH")
+	for _, line := range lines[2:] {
+		if !strings.HasPrefix(line[column:], "/src/") {
+			t.Errorf("Expected path to start at column %d in %q", column, line)
+		}
+	}
+}
+
+func TestFindWorktree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	worktree := filepath.Join(root, "elsewhere", "feature")
+	runGit(t, repo, "worktree", "add", "-q", "-b", "feature/x", worktree)
+
+	wm := New()
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+
+	for branch, want := range map[string]string{"feature/x": worktree, "main": repo} {
+		got, err := wm.FindWorktree(branch)
+		if err != nil {
+			t.Fatalf("Expected no error for %s, got: %v", branch, err)
+		}
+		if resolved, _ := filepath.EvalSymlinks(got); resolved != want && got != want {
+			t.Errorf("Expected %s to resolve to %s, got %s", branch, want, got)
+		}
+	}
+
+	_, err := wm.FindWorktree("missing")
+	if err == nil {
+		t.Fatal("Expected error for a branch without a worktree")
+	}
+	if !strings.Contains(err.Error(), "feature/x") {
+		t.Errorf("Expected error to list available worktrees, got: %v", err)
+	}
+}
+
+func TestGetWorktreeSizes(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	small := filepath.Join(root, "repo-worktrees", "small")
+	large := filepath.Join(root, "repo-worktrees", "large")
+	runGit(t, repo, "worktree", "add", "-q", "-b", "small", small)
+	runGit(t, repo, "worktree", "add", "-q", "-b", "large", large)
+	if err := os.WriteFile(filepath.Join(large, "data.bin"), make([]byte, 4096), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wm := New()
+	wm.RepoPath = repo
+
+	sizes, err := wm.GetWorktreeSizes()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(sizes) != 2 {
+		t.Fatalf("Expected the 2 linked worktrees, got %+v", sizes)
+	}
+	if sizes[0].Branch != "large" || sizes[1].Branch != "small" {
+		t.Errorf("Expected worktrees sorted largest first, got %+v", sizes)
+	}
+	if sizes[0].Bytes < 4096 || sizes[1].Bytes >= 4096 {
+		t.Errorf("Unexpected sizes: %+v", sizes)
+	}
+
+	var buf bytes.Buffer
+	if err := writeWorktreeSizeTable(&buf, sizes); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 || !strings.Contains(lines[4], "total (2 worktrees)") {
+		t.Errorf("Expected a row per worktree and a total, got:\n%s", buf.String())
+	}
+}
+
+func TestWriteWorktreesTSV(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	worktree := filepath.Join(root, "repo-worktrees", "feature", "x")
+	runGit(t, repo, "worktree", "add", "-q", "-b", "feature/x", worktree)
+
+	wm := New()
+	wm.RepoPath = repo
+
+	var buf bytes.Buffer
+	if err := wm.WriteWorktreesTSV(&buf); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one line per worktree, got %q", buf.String())
+	}
+	fields := strings.Split(lines[1], "\t")
+	if len(fields) != 3 || fields[0] != "feature/x" || fields[1] != worktree || len(fields[2]) != 40 {
+		t.Errorf("Expected branch, path and commit separated by tabs, got %q", lines[1])
+	}
+}
@@ -2,15 +2,24 @@ package manager
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/agoodway/workie/config"
+	"github.com/agoodway/workie/errcode"
+	"github.com/agoodway/workie/ignore"
 )
 
 // Options holds configuration options for the WorktreeManager
@@ -19,6 +28,17 @@ type Options struct {
 	Verbose          bool   // Enable verbose output
 	Quiet            bool   // Enable quiet mode
 	ShowInitMessages bool   // Show initialization messages (git repo detection, config loading)
+	Model            string // Overrides ai.model.name for this invocation
+	RequireAI        bool   // Treat AI failures as errors (overrides ai.require)
+	NoAI             bool   // Turn AI off for this invocation, whatever the config says
+	DryRun           bool   // Print what begin would do without changing anything
+	Checkout         bool   // Check out an existing local or remote branch instead of creating one
+	Open             bool   // The new worktree is opened in an editor, so the cd and next-steps hints are skipped
+	MoveChanges      bool   // Move the repository's uncommitted changes into the new worktree
+	NoCopy           bool   // Leave the worktree's files alone instead of copying files_to_copy (adopt --no-copy)
+	AutoSuffix       bool   // Append -2, -3, ... to a new branch's name while it is taken, instead of failing
+	From             string // Ref a new branch starts at instead of the current HEAD (begin --from)
+	FromFork         bool   // The worktree checks out a pull request from a fork, so its own .workie.local.yaml hooks are never run
 }
 
 // WorktreeManager handles git worktree operations
@@ -28,6 +48,48 @@ type WorktreeManager struct {
 	WorktreesDir string
 	Config       *config.Config
 	Options      Options
+	SeedFiles    map[string][]byte // Files written into new worktrees after copying, keyed by worktree-relative path
+	HookTarget   HookTarget        // Worktree the running hooks act on, exposed to them as environment variables
+	Metadata     WorktreeMetadata  // Issue and notes recorded for new worktrees when worktree_metadata is enabled
+
+	progress *copyProgress    // Active directory copy progress, if any
+	timings  *workflowTimings // Phase timings collected during Run
+	ignored  *ignore.Matcher  // Paths excluded by .workieignore
+
+	writeTreeOnce      sync.Once // Guards the git version check for merge-tree --write-tree
+	writeTreeSupported bool
+
+	repo repoCache // What DetectGitRepository and GetMainBranch found, reused until RepoPath changes
+}
+
+// repoCache remembers the detected repository and its main branch, so commands
+// that need them several times, and the watch loop, don't run git each time.
+// Entries only hold while RepoPath is the path they were detected for.
+type repoCache struct {
+	mu sync.Mutex
+
+	dir          string // Working directory the repository was detected from
+	repoPath     string
+	repoName     string
+	worktreesDir string
+
+	mainBranch     string
+	mainBranchRepo string // RepoPath mainBranch was detected for
+}
+
+// ClearRepoCache forgets the detected repository and main branch, so the next
+// DetectGitRepository and GetMainBranch ask git again
+func (wm *WorktreeManager) ClearRepoCache() {
+	wm.repo.mu.Lock()
+	defer wm.repo.mu.Unlock()
+	wm.repo.dir, wm.repo.repoPath, wm.repo.repoName, wm.repo.worktreesDir = "", "", "", ""
+	wm.repo.mainBranch, wm.repo.mainBranchRepo = "", ""
+}
+
+// HookTarget is the branch and worktree that hooks are run for
+type HookTarget struct {
+	Branch       string
+	WorktreePath string
 }
 
 // New creates a new WorktreeManager instance with default options
@@ -42,8 +104,22 @@ func NewWithOptions(opts Options) *WorktreeManager {
 	}
 }
 
-// DetectGitRepository detects the current git repository and sets up paths
+// DetectGitRepository detects the current git repository and sets up paths.
+// Later calls from the same directory reuse the result, unless RepoPath has
+// been changed since.
 func (wm *WorktreeManager) DetectGitRepository() error {
+	cwd, _ := os.Getwd()
+	wm.repo.mu.Lock()
+	cached := wm.repo.dir != "" && wm.repo.dir == cwd && wm.repo.repoPath == wm.RepoPath
+	if cached {
+		wm.RepoName, wm.WorktreesDir = wm.repo.repoName, wm.repo.worktreesDir
+	}
+	wm.repo.mu.Unlock()
+	if cached {
+		wm.printDetected()
+		return nil
+	}
+
 	// First check if git is available
 	if _, err := exec.LookPath("git"); err != nil {
 		return fmt.Errorf("git command not found: Please install git and ensure it's in your PATH")
@@ -56,7 +132,7 @@ func (wm *WorktreeManager) DetectGitRepository() error {
 			// More specific error message based on git output
 			stderr := string(exitErr.Stderr)
 			if strings.Contains(stderr, "not a git repository") {
-				return fmt.Errorf("not in a git repository: Please run this command from within a git repository")
+				return errcode.Errorf(errcode.NotARepo, "not in a git repository: Please run this command from within a git repository")
 			}
 			return fmt.Errorf("git command failed: %s", stderr)
 		}
@@ -87,6 +163,18 @@ func (wm *WorktreeManager) DetectGitRepository() error {
 	parentDir := filepath.Dir(wm.RepoPath)
 	wm.WorktreesDir = filepath.Join(parentDir, fmt.Sprintf("%s-worktrees", wm.RepoName))
 
+	if cwd != "" {
+		wm.repo.mu.Lock()
+		wm.repo.dir, wm.repo.repoPath, wm.repo.repoName, wm.repo.worktreesDir = cwd, wm.RepoPath, wm.RepoName, wm.WorktreesDir
+		wm.repo.mu.Unlock()
+	}
+
+	wm.printDetected()
+	return nil
+}
+
+// printDetected reports the detected repository with the init messages
+func (wm *WorktreeManager) printDetected() {
 	if wm.Options.ShowInitMessages {
 		wm.printf("✓ Detected git repository: %s\n", wm.RepoPath)
 		if wm.Options.Verbose {
@@ -95,8 +183,6 @@ func (wm *WorktreeManager) DetectGitRepository() error {
 		}
 		wm.printf("✓ Worktrees directory: %s\n", wm.WorktreesDir)
 	}
-
-	return nil
 }
 
 // LoadConfig loads the YAML configuration file
@@ -106,15 +192,15 @@ func (wm *WorktreeManager) LoadConfig() error {
 	if err != nil {
 		// Provide more specific error messages based on the error type
 		if strings.Contains(err.Error(), "custom config file not found") {
-			return fmt.Errorf("configuration file error: %w\n\nTo fix this:\n  • Check that the file path is correct\n  • Use --config flag with a valid YAML file\n  • Or remove the --config flag to use default configuration", err)
+			return errcode.Errorf(errcode.ConfigNotFound, "configuration file error: %w\n\nTo fix this:\n  • Check that the file path is correct\n  • Use --config flag with a valid YAML file\n  • Or remove the --config flag to use default configuration", err)
 		}
 		if strings.Contains(err.Error(), "failed to parse YAML") {
-			return fmt.Errorf("configuration file syntax error: %w\n\nTo fix this:\n  • Check YAML syntax and indentation\n  • Ensure the file uses proper YAML format\n  • Example valid config:\n    files_to_copy:\n      - .env.example\n      - config/\n      - scripts/setup.sh", err)
+			return errcode.Errorf(errcode.ConfigInvalid, "configuration file syntax error: %w\n\nTo fix this:\n  • Check YAML syntax and indentation\n  • Ensure the file uses proper YAML format\n  • Example valid config:\n    files_to_copy:\n      - .env.example\n      - config/\n      - scripts/setup.sh", err)
 		}
 		if strings.Contains(err.Error(), "failed to read config file") {
-			return fmt.Errorf("configuration file access error: %w\n\nTo fix this:\n  • Check file permissions (should be readable)\n  • Ensure the file is not corrupted\n  • Verify the file path is accessible", err)
+			return errcode.Errorf(errcode.ConfigInvalid, "configuration file access error: %w\n\nTo fix this:\n  • Check file permissions (should be readable)\n  • Ensure the file is not corrupted\n  • Verify the file path is accessible", err)
 		}
-		return fmt.Errorf("configuration error: %w", err)
+		return errcode.Errorf(errcode.ConfigInvalid, "configuration error: %w", err)
 	}
 
 	// Validate configuration content
@@ -122,10 +208,28 @@ func (wm *WorktreeManager) LoadConfig() error {
 		return fmt.Errorf("configuration loading failed: received nil configuration")
 	}
 
+	// Unknown settings are ignored, which usually means a typo or a misplaced key
+	for _, warning := range wm.Config.Warnings {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", warning)
+	}
+
+	if wm.Options.Model != "" {
+		wm.Config.AI.Model.Name = wm.Options.Model
+	}
+	if wm.Options.RequireAI {
+		wm.Config.AI.Require = true
+	}
+	if wm.Options.NoAI {
+		wm.Config.AIDisabled = true
+	}
+
 	// Print config loading info based on output mode
 	if wm.Options.ShowInitMessages {
 		if wm.Config.LoadedFrom != "" && !wm.Options.Quiet {
 			wm.printf("✓ Loaded configuration from: %s\n", wm.Config.LoadedFrom)
+			if len(wm.Config.LoadedFiles) > 1 {
+				wm.printf("✓ Merged local overrides from: %s\n", wm.Config.LoadedFiles[1])
+			}
 			if len(wm.Config.FilesToCopy) > 0 {
 				wm.printf("✓ Files to copy: %d entries\n", len(wm.Config.FilesToCopy))
 			}
@@ -175,19 +279,99 @@ func (wm *WorktreeManager) GenerateBranchName() string {
 
 // BranchExists checks if a branch already exists locally or remotely
 func (wm *WorktreeManager) BranchExists(branchName string) bool {
-	// Check local branches
+	return wm.localBranchExists(branchName) || wm.remoteBranchExists(branchName)
+}
+
+// maxBranchNameLength bounds the names UniqueBranchName makes; it matches the
+// limit providers apply to the branch names they generate
+const maxBranchNameLength = 63
+
+// UniqueBranchName returns branchName, or if a branch of that name already
+// exists locally or on origin, the first of branchName-2, branchName-3, ...
+// that is free. The name is truncated to make room for the suffix.
+func (wm *WorktreeManager) UniqueBranchName(branchName string) string {
+	if !wm.BranchExists(branchName) {
+		return branchName
+	}
+	for n := 2; ; n++ {
+		candidate := suffixedBranchName(branchName, n)
+		if !wm.BranchExists(candidate) {
+			return candidate
+		}
+	}
+}
+
+// suffixedBranchName appends "-n" to branchName, truncating branchName so the
+// result is at most maxBranchNameLength bytes. Truncation never splits a
+// character and drops separators it leaves at the end.
+func suffixedBranchName(branchName string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	if keep := maxBranchNameLength - len(suffix); len(branchName) > keep {
+		for keep > 0 && !utf8.RuneStart(branchName[keep]) {
+			keep--
+		}
+		branchName = strings.TrimRight(branchName[:keep], "-_./")
+	}
+	return branchName + suffix
+}
+
+// localBranchExists checks if a branch exists in refs/heads
+func (wm *WorktreeManager) localBranchExists(branchName string) bool {
 	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", fmt.Sprintf("refs/heads/%s", branchName))
 	cmd.Dir = wm.RepoPath
-	if cmd.Run() == nil {
-		return true
-	}
+	return cmd.Run() == nil
+}
 
-	// Check remote branches
-	cmd = exec.Command("git", "show-ref", "--verify", "--quiet", fmt.Sprintf("refs/remotes/origin/%s", branchName))
+// remoteBranchExists checks if a branch exists on origin
+func (wm *WorktreeManager) remoteBranchExists(branchName string) bool {
+	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", fmt.Sprintf("refs/remotes/origin/%s", branchName))
 	cmd.Dir = wm.RepoPath
 	return cmd.Run() == nil
 }
 
+// worktreeAddArgs returns the git arguments that add a worktree for branchName.
+// A new branch is created with -b, starting at Options.From if it is set and at
+// the current HEAD otherwise. With Options.Checkout an existing local branch
+// is checked out as-is, and a branch that only exists on origin is created locally
+// tracking it.
+func (wm *WorktreeManager) worktreeAddArgs(branchName, worktreePath string) ([]string, error) {
+	if !wm.Options.Checkout {
+		if wm.BranchExists(branchName) {
+			return nil, errcode.Errorf(errcode.BranchExists, "branch '%s' already exists\n\nTo fix this:\n  • Check it out into a new worktree with: workie begin %s --checkout\n  • Or use a different branch name, or let --auto-suffix pick %s-2\n  • Or delete the existing branch if no longer needed: git branch -D %s", branchName, branchName, branchName, branchName)
+		}
+		args := []string{"worktree", "add", "-b", branchName, worktreePath}
+		if wm.Options.From != "" {
+			if err := wm.verifyStartPoint(wm.Options.From); err != nil {
+				return nil, err
+			}
+			args = append(args, wm.Options.From)
+		}
+		return args, nil
+	}
+
+	if wm.localBranchExists(branchName) {
+		return []string{"worktree", "add", worktreePath, branchName}, nil
+	}
+	if wm.remoteBranchExists(branchName) {
+		return []string{"worktree", "add", "--track", "-b", branchName, worktreePath, "origin/" + branchName}, nil
+	}
+	return nil, errcode.Errorf(errcode.BranchNotFound, "branch '%s' does not exist locally or on origin\n\nTo fix this:\n  • Fetch the latest branches: git fetch origin\n  • Check the branch name: git branch -a\n  • Or drop --checkout to create a new branch", branchName)
+}
+
+// verifyStartPoint checks that ref names a commit a new branch can start at
+func (wm *WorktreeManager) verifyStartPoint(ref string) error {
+	const hint = "\n\nTo fix this:\n  • For a remote branch, fetch it first: git fetch origin\n  • Check the name: git branch -a, or git tag for tags\n  • Or drop --from to start the branch at the current HEAD"
+	if strings.HasPrefix(ref, "-") {
+		return errcode.Errorf(errcode.Usage, "invalid start point '%s'%s", ref, hint)
+	}
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	cmd.Dir = wm.RepoPath
+	if err := cmd.Run(); err != nil {
+		return errcode.Errorf(errcode.Usage, "start point '%s' is not a commit in this repository%s", ref, hint)
+	}
+	return nil
+}
+
 // copyFile copies a file from src to dst with comprehensive error handling
 func (wm *WorktreeManager) copyFile(src, dst string) error {
 	// Open source file
@@ -222,12 +406,26 @@ func (wm *WorktreeManager) copyFile(src, dst string) error {
 	}
 	defer destFile.Close()
 
-	// Copy file content
-	_, err = io.Copy(destFile, sourceFile)
+	// Copy file content, reporting bytes written when a directory copy is tracking progress
+	var writer io.Writer = destFile
+	if wm.progress != nil {
+		writer = &countingWriter{w: destFile, onWrite: wm.progress.add}
+	}
+	_, err = io.Copy(writer, sourceFile)
 	if err != nil {
 		return fmt.Errorf("failed to copy content from %s to %s: %w", src, dst, err)
 	}
 
+	// Keep the source permissions (e.g. the executable bit on scripts); os.Create
+	// only sets them for new files, and subject to the umask
+	srcInfo, err := sourceFile.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat source file %s: %w", src, err)
+	}
+	if err := os.Chmod(dst, srcInfo.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to set permissions on %s: %w", dst, err)
+	}
+
 	return nil
 }
 
@@ -243,7 +441,27 @@ func (wm *WorktreeManager) copyDirectory(src, dst string) error {
 		return fmt.Errorf("source path is not a directory: %s", src)
 	}
 
-	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+	return wm.copyTree(src, dst, make(map[string]bool))
+}
+
+// copyTree walks src and copies its contents into dst. Symlinks are recreated as-is
+// unless follow_symlinks is enabled, in which case their targets are copied.
+// ancestors holds the resolved paths of directories currently being copied and is
+// used to detect symlink cycles.
+func (wm *WorktreeManager) copyTree(src, dst string, ancestors map[string]bool) error {
+	if realSrc, err := filepath.EvalSymlinks(src); err == nil {
+		ancestors[realSrc] = true
+		defer delete(ancestors, realSrc)
+	}
+
+	// Directories and symlinks are created while walking; regular files are placed
+	// concurrently, so every directory exists before its files are copied into it
+	files := newParallelCopier(wm.Config.GetCopyConcurrency(), wm.placeFile)
+
+	walkErr := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err := files.err(); err != nil {
+			return err
+		}
 		if err != nil {
 			if os.IsPermission(err) {
 				return fmt.Errorf("permission denied accessing: %s", path)
@@ -259,6 +477,21 @@ func (wm *WorktreeManager) copyDirectory(src, dst string) error {
 
 		dstPath := filepath.Join(dst, relPath)
 
+		// Honor .workieignore, which is relative to the repository root
+		if repoRel, err := filepath.Rel(wm.RepoPath, path); err == nil && wm.ignored.Match(repoRel, info.IsDir()) {
+			if wm.Options.Verbose {
+				wm.printf("     Skipping %s (excluded by %s)\n", repoRel, ignore.FileName)
+			}
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			return wm.copySymlink(path, dstPath, ancestors)
+		}
+
 		if info.IsDir() {
 			if err := os.MkdirAll(dstPath, info.Mode()); err != nil {
 				if os.IsPermission(err) {
@@ -269,81 +502,182 @@ func (wm *WorktreeManager) copyDirectory(src, dst string) error {
 			return nil
 		}
 
-		if err := wm.copyFile(path, dstPath); err != nil {
-			return fmt.Errorf("failed to copy file %s to %s: %w", path, dstPath, err)
-		}
+		files.copy(path, dstPath)
 		return nil
 	})
+
+	copyErr := files.wait()
+	if walkErr != nil {
+		return walkErr
+	}
+	return copyErr
+}
+
+// writeSeedFiles writes SeedFiles into the worktree, refusing paths that escape it
+func (wm *WorktreeManager) writeSeedFiles(worktreePath string) error {
+	for rel, content := range wm.SeedFiles {
+		clean := filepath.Clean(rel)
+		if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("seed file path must be relative to the worktree: %s", rel)
+		}
+
+		dst := filepath.Join(worktreePath, clean)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", rel, err)
+		}
+		if err := os.WriteFile(dst, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+		wm.printf("📝 Wrote %s\n", clean)
+	}
+	return nil
 }
 
 // copyConfiguredFiles copies files/directories specified in the configuration
 func (wm *WorktreeManager) copyConfiguredFiles(worktreePath string) error {
 	if !wm.Config.HasFilesToCopy() {
-		wm.printf("📂 No files configured to copy\n")
+		if wm.Options.DryRun {
+			fmt.Printf("📂 No files configured to copy\n")
+		} else {
+			wm.printf("📂 No files configured to copy\n")
+		}
 		return nil
 	}
 
-	wm.printf("📂 Copying configured files to worktree...\n")
+	if wm.Options.DryRun {
+		fmt.Printf("📂 Files that would be copied:\n")
+	} else {
+		wm.printf("📂 Copying configured files to worktree...\n")
+	}
+	symlinks := wm.Config.GetCopyMode() == "symlink"
+	if symlinks {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: copy_mode is symlink, so the files are shared with %s; changing them in the worktree changes them there too\n", wm.RepoPath)
+	}
+
+	ignored, err := ignore.Load(wm.RepoPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
+	}
+	wm.ignored = ignored
 
-	var copyErrors []string
+	copyStart := time.Now()
+	var itemTimings []phaseTiming
+	entries, copyErrors := wm.expandCopyEntries()
+	unmatchedCount := len(copyErrors)
 	successCount := 0
+	ignoredCount := 0
+	plannedCount := 0
 
-	for _, item := range wm.Config.FilesToCopy {
+	for _, entry := range entries {
 		// Validate item name
-		if strings.TrimSpace(item) == "" {
-			fmt.Printf("⚠️  Warning: Skipping empty file/directory name in configuration\n")
+		if strings.TrimSpace(entry.Src) == "" {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Skipping empty file/directory name in configuration\n")
+			continue
+		}
+		if err := entry.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Skipping %s: %v\n", entry, err)
+			copyErrors = append(copyErrors, err.Error())
 			continue
 		}
 
+		item := entry.Src
 		srcPath := filepath.Join(wm.RepoPath, item)
-		dstPath := filepath.Join(worktreePath, item)
+		dstPath := filepath.Join(worktreePath, entry.Destination())
 
 		// Check if source exists
 		srcInfo, err := os.Stat(srcPath)
 		if err != nil {
 			if os.IsNotExist(err) {
 				errorMsg := fmt.Sprintf("Source file/directory not found: %s → Expected at: %s", item, srcPath)
-				fmt.Printf("⚠️  Warning: %s\n", errorMsg)
+				fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", errorMsg)
 				copyErrors = append(copyErrors, errorMsg)
 			} else {
 				errorMsg := fmt.Sprintf("Cannot access source %s at %s: %v", item, srcPath, err)
-				fmt.Printf("⚠️  Warning: %s\n", errorMsg)
+				fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", errorMsg)
 				copyErrors = append(copyErrors, errorMsg)
 			}
 			continue
 		}
 
-		if srcInfo.IsDir() {
-			wm.printf("   📁 Copying directory: %s\n", item)
+		if wm.ignored.Match(item, srcInfo.IsDir()) {
+			wm.printf("   🚫 Skipping %s (excluded by %s)\n", item, ignore.FileName)
+			ignoredCount++
+			continue
+		}
+
+		if wm.Options.DryRun && symlinks && srcInfo.IsDir() {
+			fmt.Printf("   🔗 %s → %s (directory link)\n", item, entry.Destination())
+			plannedCount++
+			continue
+		}
+		if wm.Options.DryRun {
+			copies, err := wm.planCopy(item, entry.Destination(), srcInfo.IsDir())
+			if err != nil {
+				errorMsg := fmt.Sprintf("Cannot list %s: %v", item, err)
+				fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", errorMsg)
+				copyErrors = append(copyErrors, errorMsg)
+				continue
+			}
+			for _, c := range copies {
+				fmt.Printf("   📄 %s → %s\n", c.Src, c.Dst)
+			}
+			plannedCount += len(copies)
+			continue
+		}
+
+		itemStart := time.Now()
+		if symlinks && srcInfo.IsDir() {
+			// The whole directory is linked, so .workieignore only applies to the item itself
+			wm.printf("   🔗 Linking directory: %s\n", entry)
 			if wm.Options.Verbose {
 				wm.printf("     From → To: %s → %s\n", srcPath, dstPath)
 			}
-			if err := wm.copyDirectory(srcPath, dstPath); err != nil {
+			if err := symlinkPath(srcPath, dstPath); err != nil {
+				errorMsg := fmt.Sprintf("Failed to link directory %s from %s to %s: %v", item, srcPath, dstPath, err)
+				fmt.Fprintf(os.Stderr, "❌ Error: %s\n", errorMsg)
+				copyErrors = append(copyErrors, errorMsg)
+			} else {
+				successCount++
+				wm.printf("     ✓ Directory linked successfully\n")
+			}
+		} else if srcInfo.IsDir() {
+			wm.printf("   📁 Copying directory: %s\n", entry)
+			if wm.Options.Verbose {
+				wm.printf("     From → To: %s → %s\n", srcPath, dstPath)
+			}
+			if err := wm.copyDirectoryWithBackend(srcPath, dstPath); err != nil {
 				errorMsg := fmt.Sprintf("Failed to copy directory %s from %s to %s: %v", item, srcPath, dstPath, err)
-				fmt.Printf("❌ Error: %s\n", errorMsg)
+				fmt.Fprintf(os.Stderr, "❌ Error: %s\n", errorMsg)
 				copyErrors = append(copyErrors, errorMsg)
 			} else {
 				successCount++
 				wm.printf("     ✓ Directory copied successfully\n")
 			}
 		} else {
-			wm.printf("   📄 Copying file: %s\n", item)
+			wm.printf("   📄 Copying file: %s\n", entry)
 			if wm.Options.Verbose {
 				wm.printf("     From → To: %s → %s\n", srcPath, dstPath)
 			}
-			if err := wm.copyFile(srcPath, dstPath); err != nil {
+			if err := wm.placeFile(srcPath, dstPath); err != nil {
 				errorMsg := fmt.Sprintf("Failed to copy file %s from %s to %s: %v", item, srcPath, dstPath, err)
-				fmt.Printf("❌ Error: %s\n", errorMsg)
+				fmt.Fprintf(os.Stderr, "❌ Error: %s\n", errorMsg)
 				copyErrors = append(copyErrors, errorMsg)
 			} else {
 				successCount++
 				wm.printf("     ✓ File copied successfully\n")
 			}
 		}
+		itemTimings = append(itemTimings, phaseTiming{Name: item, Duration: time.Since(itemStart)})
+	}
+	wm.timings.record("copy files", copyStart, itemTimings...)
+
+	if wm.Options.DryRun {
+		fmt.Printf("   %d file(s) would be copied\n", plannedCount)
+		return nil
 	}
 
 	// Show summary
-	totalItems := len(wm.Config.FilesToCopy)
+	totalItems := len(entries) + unmatchedCount - ignoredCount
 	if successCount == totalItems {
 		wm.printf("✓ Successfully copied all %d configured items\n", successCount)
 	} else if successCount > 0 {
@@ -354,49 +688,179 @@ func (wm *WorktreeManager) copyConfiguredFiles(worktreePath string) error {
 
 	// If there were copy errors, provide helpful information
 	if len(copyErrors) > 0 && wm.Options.Verbose {
-		fmt.Printf("\nCopy error summary:\n")
+		fmt.Fprintf(os.Stderr, "\nCopy error summary:\n")
 		for i, err := range copyErrors {
-			fmt.Printf("  %d. %s\n", i+1, err)
+			fmt.Fprintf(os.Stderr, "  %d. %s\n", i+1, err)
 		}
-		fmt.Printf("\nTo fix copy issues:\n")
-		fmt.Printf("  • Verify files/directories exist in the source repository\n")
-		fmt.Printf("  • Check file permissions\n")
-		fmt.Printf("  • Update your configuration file if paths have changed\n")
+		fmt.Fprintf(os.Stderr, "\nTo fix copy issues:\n")
+		fmt.Fprintf(os.Stderr, "  • Verify files/directories exist in the source repository\n")
+		fmt.Fprintf(os.Stderr, "  • Check file permissions\n")
+		fmt.Fprintf(os.Stderr, "  • Update your configuration file if paths have changed\n")
 	}
 
 	return nil
 }
 
+// expandCopyEntries expands glob patterns in files_to_copy into one entry per match,
+// preserving each match's path relative to the repository. A glob entry with a dst
+// copies its matches into that directory. Patterns that match nothing are reported
+// like missing files.
+func (wm *WorktreeManager) expandCopyEntries() ([]config.CopyEntry, []string) {
+	var entries []config.CopyEntry
+	var copyErrors []string
+
+	for _, entry := range wm.Config.FilesToCopy {
+		if !entry.IsGlob() {
+			entries = append(entries, entry)
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(wm.RepoPath, entry.Src))
+		if err != nil {
+			errorMsg := fmt.Sprintf("Invalid pattern %s: %v", entry.Src, err)
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", errorMsg)
+			copyErrors = append(copyErrors, errorMsg)
+			continue
+		}
+		if len(matches) == 0 {
+			errorMsg := fmt.Sprintf("No files match pattern: %s → Searched in: %s", entry.Src, wm.RepoPath)
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", errorMsg)
+			copyErrors = append(copyErrors, errorMsg)
+			continue
+		}
+
+		for _, match := range matches {
+			rel, err := filepath.Rel(wm.RepoPath, match)
+			if err != nil {
+				continue
+			}
+			expanded := config.CopyEntry{Src: rel}
+			if entry.Dst != "" {
+				expanded.Dst = strings.TrimSuffix(entry.Dst, "/") + "/"
+			}
+			entries = append(entries, expanded)
+		}
+	}
+
+	return entries, copyErrors
+}
+
+// WorktreePath returns the directory a branch's worktree is created in. With
+// worktree_dir_naming: flat, slashes in the branch name become dashes so every
+// worktree is directly in WorktreesDir.
+func (wm *WorktreeManager) WorktreePath(branchName string) string {
+	if wm.Config.GetWorktreeDirNaming() == "flat" {
+		return filepath.Join(wm.WorktreesDir, strings.ReplaceAll(branchName, "/", "-"))
+	}
+	return filepath.Join(wm.WorktreesDir, branchName)
+}
+
+// nestedPathCollision describes what is in the way of a nested worktree path:
+// a directory between WorktreesDir and the path that is itself a worktree, e.g.
+// that of feature/foo when creating feature/foo/bar, or a file. It returns ""
+// if nothing is.
+func (wm *WorktreeManager) nestedPathCollision(worktreePath string) string {
+	rel, err := filepath.Rel(wm.WorktreesDir, worktreePath)
+	if err != nil {
+		return ""
+	}
+	dir := wm.WorktreesDir
+	parts := strings.Split(rel, string(filepath.Separator))
+	for _, part := range parts[:len(parts)-1] {
+		dir = filepath.Join(dir, part)
+		info, err := os.Stat(dir)
+		if err != nil {
+			return ""
+		}
+		if !info.IsDir() {
+			return "the file " + dir
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "the worktree " + dir
+		}
+	}
+	return ""
+}
+
 // CreateWorktreeBranch creates a new worktree with the specified branch name
 func (wm *WorktreeManager) CreateWorktreeBranch(branchName string) error {
 	// Validate branch name
 	if strings.TrimSpace(branchName) == "" {
-		return fmt.Errorf("branch name cannot be empty")
+		return errcode.Errorf(errcode.Usage, "branch name cannot be empty")
 	}
 
 	// Check for invalid characters in branch name
 	if strings.ContainsAny(branchName, " \t\n\r~^:?*[\\@{}") {
-		return fmt.Errorf("invalid branch name '%s': contains invalid characters\n\nBranch names cannot contain: spaces, ~, ^, :, ?, *, [, \\, @, {, }\nTry using: feature/my-branch, bugfix/issue-123, etc.", branchName)
+		return errcode.Errorf(errcode.Usage, "invalid branch name '%s': contains invalid characters\n\nBranch names cannot contain: spaces, ~, ^, :, ?, *, [, \\, @, {, }\nTry using: feature/my-branch, bugfix/issue-123, etc.", branchName)
 	}
 
-	if wm.BranchExists(branchName) {
-		return fmt.Errorf("branch '%s' already exists\n\nTo fix this:\n  • Use a different branch name\n  • Or delete the existing branch if no longer needed\n  • Use: git branch -D %s (to delete locally)\n  • Use: git push origin --delete %s (to delete remotely)", branchName, branchName, branchName)
+	if wm.Options.AutoSuffix && !wm.Options.Checkout {
+		if unique := wm.UniqueBranchName(branchName); unique != branchName {
+			wm.printf("🔀 Branch '%s' already exists; using '%s'\n", branchName, unique)
+			branchName = unique
+		}
 	}
 
-	worktreePath := filepath.Join(wm.WorktreesDir, branchName)
+	worktreePath := wm.WorktreePath(branchName)
+	if collision := wm.nestedPathCollision(worktreePath); collision != "" {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %s collides with %s; set worktree_dir_naming: flat to keep worktrees one level deep\n", worktreePath, collision)
+	}
+
+	addArgs, err := wm.worktreeAddArgs(branchName, worktreePath)
+	if err != nil {
+		return err
+	}
 
 	// Check if worktree path already exists
 	if _, err := os.Stat(worktreePath); err == nil {
-		return fmt.Errorf("worktree directory already exists: %s\n\nTo fix this:\n  • Choose a different branch name\n  • Remove the existing directory: rm -rf %s\n  • Or use: git worktree remove %s", worktreePath, worktreePath, worktreePath)
+		return errcode.Errorf(errcode.WorktreeExists, "worktree directory already exists: %s\n\nTo fix this:\n  • Choose a different branch name\n  • Remove the existing directory: rm -rf %s\n  • Or use: git worktree remove %s", worktreePath, worktreePath, worktreePath)
 	}
 
-	// Create new worktree with new branch
+	// Refuse before anything is created rather than seeding gigabytes into the worktree
+	if err := wm.checkCopyBudget(); err != nil {
+		return err
+	}
+
+	if wm.Options.DryRun {
+		return wm.printCreatePlan(branchName, worktreePath, addArgs)
+	}
+
+	wm.HookTarget = HookTarget{Branch: branchName, WorktreePath: worktreePath}
+
+	// pre_create hooks can veto the worktree, so unlike post_create a failure aborts
+	if wm.HasPreCreateHooks() {
+		hooksStart := time.Now()
+		if err := wm.ExecuteRequiredHooks(wm.Config.Hooks.PreCreate, wm.RepoPath, "pre_create"); err != nil {
+			return errcode.Errorf(errcode.HookFailed, "worktree creation aborted: %w\n\nTo fix this:\n  • Fix the failing pre_create hook and try again\n  • Or remove it from the hooks section of your configuration", err)
+		}
+		wm.timings.record("pre_create hooks", hooksStart)
+	}
+
+	// Move uncommitted changes aside; they are applied in the new worktree, or
+	// put back if it cannot be created
+	var stash string
+	created := false
+	if wm.Options.MoveChanges {
+		if stash, err = wm.stashChanges(branchName); err != nil {
+			return err
+		}
+		defer func() {
+			if stash != "" && !created {
+				if err := wm.restoreStash(stash); err != nil {
+					fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
+				}
+			}
+		}()
+	}
+
+	// Create the worktree, with a new branch unless an existing one is checked out
 	wm.printf("📝 Creating worktree for branch '%s'...\n", branchName)
 	if wm.Options.Verbose {
-		wm.printf("Executing: git worktree add -b %s %s\n", branchName, worktreePath)
+		wm.printf("Executing: git %s\n", strings.Join(addArgs, " "))
 	}
 
-	cmd := exec.Command("git", "worktree", "add", "-b", branchName, worktreePath)
+	gitStart := time.Now()
+	cmd := exec.Command("git", addArgs...)
 	cmd.Dir = wm.RepoPath
 
 	// Capture both stdout and stderr for better error reporting
@@ -408,40 +872,40 @@ func (wm *WorktreeManager) CreateWorktreeBranch(branchName string) error {
 		if _, ok := err.(*exec.ExitError); ok {
 			// Parse specific git worktree errors
 			if strings.Contains(stderrStr, "already exists") {
-				return fmt.Errorf("git worktree creation failed: path already exists\n\nError details: %s\n\nTo fix this:\n  • Remove the existing directory\n  • Use a different branch name\n  • Clean up with: git worktree prune", stderrStr)
+				return errcode.Errorf(errcode.WorktreeExists, "git worktree creation failed: path already exists\n\nError details: %s\n\nTo fix this:\n  • Remove the existing directory\n  • Use a different branch name\n  • Clean up with: git worktree prune", stderrStr)
 			}
-			if strings.Contains(stderrStr, "is already checked out") {
-				return fmt.Errorf("git worktree creation failed: branch already checked out\n\nError details: %s\n\nTo fix this:\n  • Use a different branch name\n  • Switch to a different branch in existing worktree\n  • Remove the existing worktree first", stderrStr)
+			if strings.Contains(stderrStr, "is already checked out") || strings.Contains(stderrStr, "is already used by worktree") {
+				return errcode.Errorf(errcode.BranchCheckedOut, "git worktree creation failed: branch already checked out\n\nError details: %s\n\nTo fix this:\n  • Change into the existing worktree: cd \"$(workie switch %s)\"\n  • Switch to a different branch in the existing worktree\n  • Or remove the existing worktree first", stderrStr, branchName)
 			}
 			if strings.Contains(stderrStr, "not a valid object name") {
 				return fmt.Errorf("git worktree creation failed: invalid reference\n\nError details: %s\n\nTo fix this:\n  • Ensure you're in a valid git repository\n  • Check that HEAD points to a valid commit\n  • Try: git status to check repository state", stderrStr)
 			}
 			return fmt.Errorf("git worktree creation failed\n\nError details: %s\n\nTo fix this:\n  • Check git repository status: git status\n  • Ensure working directory is clean\n  • Verify branch name is valid\n  • Check available disk space", stderrStr)
 		}
-		return fmt.Errorf("failed to create worktree: %w\n\nCommand: git worktree add -b %s %s\nWorking directory: %s", err, branchName, worktreePath, wm.RepoPath)
+		return fmt.Errorf("failed to create worktree: %w\n\nCommand: git %s\nWorking directory: %s", err, strings.Join(addArgs, " "), wm.RepoPath)
 	}
 
+	wm.timings.record("git worktree add", gitStart)
 	wm.printf("✓ Git worktree created successfully\n")
+	created = true
 
-	// Copy configured files to the new worktree
-	if err := wm.copyConfiguredFiles(worktreePath); err != nil {
-		return fmt.Errorf("failed to copy configured files: %w", err)
+	// Moved changes are applied before copying, so copied files can't block them
+	if stash != "" {
+		if err := wm.applyStash(stash, worktreePath); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
+		}
 	}
 
-	// Execute post_create hooks if configured
-	if wm.HasPostCreateHooks() {
-		if err := wm.ExecuteHooks(wm.Config.Hooks.PostCreate, worktreePath, "post_create"); err != nil {
-			// Don't fail the entire operation for hook errors, just warn
-			fmt.Printf("⚠️  Warning: Some post_create hooks failed, but worktree was created successfully\n")
-			if wm.Options.Verbose {
-				fmt.Printf("Hook execution details: %v\n", err)
-			}
-		}
-	} else {
-		wm.printf("🪝 No post_create hooks configured\n")
+	if err := wm.setupWorktree(branchName, worktreePath); err != nil {
+		return err
+	}
+
+	// In quiet mode the worktree path is the only output, so it can be captured
+	if wm.Options.Quiet {
+		fmt.Println(worktreePath)
+		return nil
 	}
 
-	// Always show success and path info, even in quiet mode (essential info)
 	fmt.Printf("✅ Successfully created worktree:\n")
 	fmt.Printf("   Branch: %s\n", branchName)
 	fmt.Printf("   Path: %s\n", worktreePath)
@@ -457,50 +921,160 @@ func (wm *WorktreeManager) CreateWorktreeBranch(branchName string) error {
 		fmt.Printf("   Files copied to worktree: None (no files configured)\n")
 	}
 
-	// Show next steps in non-quiet mode
-	if !wm.Options.Quiet {
-		fmt.Printf("\n🚀 To start working:\n")
-		fmt.Printf("   cd %s\n", worktreePath)
-		fmt.Printf("\nNext steps:\n")
-		fmt.Printf("   • Make your changes\n")
-		fmt.Printf("   • Commit your work: git add . && git commit -m 'Your message'\n")
-		fmt.Printf("   • Push when ready: git push -u origin %s\n", branchName)
+	if wm.Options.Verbose {
+		wm.printTimingSummary()
 	}
 
-	// For quiet mode, just output the worktree path
-	if wm.Options.Quiet {
-		fmt.Println(worktreePath)
+	// Show next steps, unless the worktree is about to be opened in an editor
+	if wm.Options.Open {
+		return nil
 	}
+	fmt.Printf("\n🚀 To start working:\n")
+	fmt.Printf("   cd %s\n", worktreePath)
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("   • Make your changes\n")
+	fmt.Printf("   • Commit your work: git add . && git commit -m 'Your message'\n")
+	fmt.Printf("   • Push when ready: git push -u origin %s\n", branchName)
 
 	return nil
 }
 
-// ListWorktrees lists all existing worktrees
-func (wm *WorktreeManager) ListWorktrees() error {
-	cmd := exec.Command("git", "worktree", "list")
-	cmd.Dir = wm.RepoPath
+// setupWorktree prepares a worktree that git has checked out: it runs the
+// copy hooks, copies the configured files, writes seed files and metadata, and
+// runs post_create hooks. Hook failures only warn, as the worktree exists.
+func (wm *WorktreeManager) setupWorktree(branchName, worktreePath string) error {
+	// pre_copy hooks can generate files (e.g. decrypted secrets) in the repository
+	// so they exist when copying starts
+	if wm.HasPreCopyHooks() {
+		hooksStart := time.Now()
+		if err := wm.ExecuteHooks(wm.Config.Hooks.PreCopy, wm.RepoPath, "pre_copy"); err != nil {
+			// The worktree exists already, so warn and copy whatever is there
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Some pre_copy hooks failed; files they generate may be missing from the worktree\n")
+			if wm.Options.Verbose {
+				fmt.Fprintf(os.Stderr, "Hook execution details: %v\n", err)
+			}
+		}
+		wm.timings.record("pre_copy hooks", hooksStart)
+	}
 
-	var stderr strings.Builder
-	cmd.Stderr = &stderr
+	// Copy configured files to the new worktree
+	if wm.Options.NoCopy {
+		wm.printf("📂 Not copying configured files (--no-copy)\n")
+	} else if err := wm.copyConfiguredFiles(worktreePath); err != nil {
+		return fmt.Errorf("failed to copy configured files: %w", err)
+	}
 
-	output, err := cmd.Output()
-	if err != nil {
-		stderrStr := stderr.String()
-		if _, ok := err.(*exec.ExitError); ok {
-			if strings.Contains(stderrStr, "not a git repository") {
-				return fmt.Errorf("cannot list worktrees: not in a git repository\n\nTo fix this:\n  • Navigate to a git repository\n  • Initialize a git repository: git init")
+	// Write seed files (e.g. a rendered issue template) so hooks can use them
+	if err := wm.writeSeedFiles(worktreePath); err != nil {
+		return fmt.Errorf("failed to write seed files: %w", err)
+	}
+
+	// post_copy hooks fix up the copied files (permissions, line endings)
+	// before post_create sets up the environment
+	if wm.HasPostCopyHooks() {
+		hooksStart := time.Now()
+		if err := wm.ExecuteHooks(wm.Config.Hooks.PostCopy, worktreePath, "post_copy"); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Some post_copy hooks failed, but worktree was created successfully\n")
+			if wm.Options.Verbose {
+				fmt.Fprintf(os.Stderr, "Hook execution details: %v\n", err)
+			}
+		}
+		wm.timings.record("post_copy hooks", hooksStart)
+	}
+
+	// Record where the worktree came from, for 'workie info' and 'workie list'
+	if wm.MetadataEnabled() || wm.Metadata.AdoptedFrom != "" {
+		if err := wm.writeWorktreeMetadata(branchName, worktreePath); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Failed to record worktree metadata: %v\n", err)
+		}
+	}
+
+	// A .workie.local.yaml copied, seeded or checked out into the worktree can
+	// add setup of its own
+	wm.UseWorktreeHooks(worktreePath)
+
+	// Execute post_create hooks if configured
+	if wm.HasPostCreateHooks() {
+		hooksStart := time.Now()
+		if err := wm.ExecuteHooks(wm.Config.Hooks.PostCreate, worktreePath, "post_create"); err != nil {
+			// Don't fail the entire operation for hook errors, just warn
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Some post_create hooks failed, but worktree was created successfully\n")
+			if wm.Options.Verbose {
+				fmt.Fprintf(os.Stderr, "Hook execution details: %v\n", err)
 			}
-			return fmt.Errorf("git worktree list failed\n\nError details: %s\n\nTo fix this:\n  • Ensure you're in a valid git repository\n  • Check git installation: git --version\n  • Verify repository status: git status", stderrStr)
 		}
-		return fmt.Errorf("failed to list worktrees: %w", err)
+		wm.timings.record("post_create hooks", hooksStart)
+	} else {
+		wm.printf("🪝 No post_create hooks configured\n")
 	}
 
+	return nil
+}
+
+// printCreatePlan describes what CreateWorktreeBranch would do for branchName
+// without running hooks, creating the worktree or copying anything
+func (wm *WorktreeManager) printCreatePlan(branchName, worktreePath string, addArgs []string) error {
+	fmt.Printf("🔍 Dry run: no changes will be made\n")
+	fmt.Printf("   Branch: %s\n", branchName)
+	fmt.Printf("   Path: %s\n", worktreePath)
+
+	printPlannedHooks := func(hookType string, hooks []config.HookCommand, workDir string) {
+		if len(hooks) == 0 {
+			fmt.Printf("🪝 No %s hooks configured\n", hookType)
+			return
+		}
+		fmt.Printf("🪝 %s hooks that would run in %s:\n", hookType, workDir)
+		for _, hook := range hooks {
+			fmt.Printf("   • %s\n", hook)
+		}
+	}
+
+	var hooks config.Hooks
+	if wm.Config != nil && wm.Config.Hooks != nil {
+		hooks = *wm.Config.Hooks
+	}
+
+	printPlannedHooks("pre_create", hooks.PreCreate, wm.RepoPath)
+	fmt.Printf("📝 Would run: git %s\n", strings.Join(addArgs, " "))
+	if wm.Options.MoveChanges {
+		changes, err := wm.uncommittedChanges()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📦 Would move %d uncommitted change(s) into the worktree\n", changes)
+	}
+	printPlannedHooks("pre_copy", hooks.PreCopy, wm.RepoPath)
+
+	if err := wm.copyConfiguredFiles(worktreePath); err != nil {
+		return fmt.Errorf("failed to list configured files: %w", err)
+	}
+
+	seeds := make([]string, 0, len(wm.SeedFiles))
+	for rel := range wm.SeedFiles {
+		seeds = append(seeds, filepath.Clean(rel))
+	}
+	sort.Strings(seeds)
+	for _, rel := range seeds {
+		fmt.Printf("📝 Would write %s\n", rel)
+	}
+
+	printPlannedHooks("post_copy", hooks.PostCopy, worktreePath)
+	printPlannedHooks("post_create", hooks.PostCreate, worktreePath)
+	return nil
+}
+
+// ListWorktrees lists all existing worktrees
+func (wm *WorktreeManager) ListWorktrees() error {
 	if wm.Options.Verbose {
-		wm.printf("Executing: git worktree list\n")
+		wm.printf("Executing: git worktree list --porcelain\n")
 	}
 
-	outputStr := strings.TrimSpace(string(output))
-	if outputStr == "" {
+	statuses, err := wm.GetWorktreeStatuses()
+	if err != nil {
+		return fmt.Errorf("cannot list worktrees: %w\n\nTo fix this:\n  • Ensure you're in a valid git repository\n  • Check git installation: git --version\n  • Verify repository status: git status", err)
+	}
+
+	if len(statuses) == 0 {
 		wm.printf("\n📋 No worktrees found\n")
 		if !wm.Options.Quiet {
 			fmt.Printf("Only the main repository is currently available.\n")
@@ -510,29 +1084,13 @@ func (wm *WorktreeManager) ListWorktrees() error {
 	}
 
 	wm.printf("\n📋 Existing worktrees:\n")
-	if !wm.Options.Quiet {
-		fmt.Printf("%s\n", outputStr)
-
-		// Count worktrees for additional info
-		lines := strings.Split(outputStr, "\n")
-		worktreeCount := 0
-		mainRepo := ""
-
-		for _, line := range lines {
-			if strings.TrimSpace(line) != "" {
-				worktreeCount++
-				if strings.Contains(line, "(bare)") || strings.Contains(line, "["+wm.RepoName+"]") {
-					mainRepo = strings.Fields(line)[0]
-				}
-			}
-		}
+	if err := writeWorktreeTable(os.Stdout, statuses); err != nil {
+		return err
+	}
 
-		if wm.Options.Verbose {
-			fmt.Printf("\nSummary: Found %d worktree(s)\n", worktreeCount)
-			if mainRepo != "" {
-				fmt.Printf("Main repository: %s\n", mainRepo)
-			}
-		}
+	if wm.Options.Verbose {
+		fmt.Printf("\nSummary: Found %d worktree(s)\n", len(statuses))
+		fmt.Printf("Main repository: %s\n", statuses[0].Path)
 	}
 
 	return nil
@@ -565,7 +1123,7 @@ type HookSummary struct {
 
 // ExecuteHooks executes a slice of command strings in sequence within the specified working directory
 // It provides comprehensive error handling, progress indication, and detailed feedback
-func (wm *WorktreeManager) ExecuteHooks(hooks []string, workDir string, hookType string) error {
+func (wm *WorktreeManager) ExecuteHooks(hooks []config.HookCommand, workDir string, hookType string) error {
 	if len(hooks) == 0 {
 		wm.printf("🪝 No %s hooks configured\n", hookType)
 		return nil
@@ -602,26 +1160,26 @@ func (wm *WorktreeManager) ExecuteHooks(hooks []string, workDir string, hookType
 
 	overallStart := time.Now()
 
-	for i, hookCommand := range hooks {
+	for i, hook := range hooks {
 		// Validate hook command
-		hookCommand = strings.TrimSpace(hookCommand)
-		if hookCommand == "" {
+		hook.Command = strings.TrimSpace(hook.Command)
+		if hook.Command == "" {
 			wm.printf("   ⚠️  Warning: Skipping empty hook command at position %d\n", i+1)
 			summary.SkippedCount++
 			continue
 		}
 
 		// Show current progress
-		wm.printf("\n   [%d/%d] 🔄 Running: %s\n", i+1, len(hooks), hookCommand)
+		wm.printf("\n   [%d/%d] 🔄 Running: %s\n", i+1, len(hooks), hook.Command)
 
 		// In verbose mode, show exact command being executed
 		if wm.Options.Verbose {
 			wm.printf("      Directory: %s\n", workDir)
-			wm.printf("      Timeout: %v\n", wm.getHookTimeout())
+			wm.printf("      Timeout: %v\n", wm.hookTimeout(hook))
 		}
 
 		// Execute the hook with comprehensive error handling
-		result := wm.executeHookCommand(hookCommand, workDir, i+1)
+		result := wm.executeHookCommand(hook, workDir, hookType, i+1)
 		summary.Results = append(summary.Results, result)
 
 		// Update counters
@@ -653,6 +1211,41 @@ func (wm *WorktreeManager) ExecuteHooks(hooks []string, workDir string, hookType
 	return nil
 }
 
+// ExecuteRequiredHooks executes hooks in sequence like ExecuteHooks, but stops at the
+// first failure and returns an error. Use it for checks that must pass to continue.
+func (wm *WorktreeManager) ExecuteRequiredHooks(hooks []config.HookCommand, workDir string, hookType string) error {
+	if len(hooks) == 0 {
+		wm.printf("🪝 No %s hooks configured\n", hookType)
+		return nil
+	}
+
+	if _, err := os.Stat(workDir); err != nil {
+		return fmt.Errorf("hook execution failed: cannot access working directory %s: %w", workDir, err)
+	}
+
+	wm.printf("🪝 Executing %s hooks (%d commands)...\n", hookType, len(hooks))
+
+	for i, hook := range hooks {
+		hook.Command = strings.TrimSpace(hook.Command)
+		if hook.Command == "" {
+			continue
+		}
+
+		wm.printf("\n   [%d/%d] 🔄 Running: %s\n", i+1, len(hooks), hook.Command)
+		if wm.Options.Verbose {
+			wm.printf("      Timeout: %v\n", wm.hookTimeout(hook))
+		}
+		result := wm.executeHookCommand(hook, workDir, hookType, i+1)
+		wm.displayHookResult(result)
+
+		if !result.Success {
+			return fmt.Errorf("%s hook failed: %s", hookType, hook.Command)
+		}
+	}
+
+	return nil
+}
+
 // printf is a helper function that considers the verbose and quiet flags
 func (wm *WorktreeManager) printf(format string, a ...interface{}) {
 	if !wm.Options.Quiet {
@@ -664,56 +1257,92 @@ func (wm *WorktreeManager) printf(format string, a ...interface{}) {
 	}
 }
 
+// HasPreCreateHooks checks if pre_create hooks are configured
+func (wm *WorktreeManager) HasPreCreateHooks() bool {
+	return wm.Config != nil && wm.Config.Hooks != nil && len(wm.Config.Hooks.PreCreate) > 0
+}
+
+// HasPostCopyHooks checks if post_copy hooks are configured
+func (wm *WorktreeManager) HasPostCopyHooks() bool {
+	return wm.Config != nil && wm.Config.Hooks != nil && len(wm.Config.Hooks.PostCopy) > 0
+}
+
+// HasPreCopyHooks checks if pre_copy hooks are configured
+func (wm *WorktreeManager) HasPreCopyHooks() bool {
+	return wm.Config != nil && wm.Config.Hooks != nil && len(wm.Config.Hooks.PreCopy) > 0
+}
+
 // HasPostCreateHooks checks if post_create hooks are configured
 func (wm *WorktreeManager) HasPostCreateHooks() bool {
 	return wm.Config != nil && wm.Config.Hooks != nil && len(wm.Config.Hooks.PostCreate) > 0
 }
 
+// HasPreFinishHooks checks if pre_finish hooks are configured
+func (wm *WorktreeManager) HasPreFinishHooks() bool {
+	return wm.Config != nil && wm.Config.Hooks != nil && len(wm.Config.Hooks.PreFinish) > 0
+}
+
 // HasPreRemoveHooks checks if pre_remove hooks are configured
 func (wm *WorktreeManager) HasPreRemoveHooks() bool {
 	return wm.Config != nil && wm.Config.Hooks != nil && len(wm.Config.Hooks.PreRemove) > 0
 }
 
-// parseCommand splits command strings into executable parts
-// It handles shell-style commands with pipes, redirects, etc.
-func parseCommand(command string) ([]*exec.Cmd, error) {
+// HasPostRemoveHooks checks if post_remove hooks are configured
+func (wm *WorktreeManager) HasPostRemoveHooks() bool {
+	return wm.Config != nil && wm.Config.Hooks != nil && len(wm.Config.Hooks.PostRemove) > 0
+}
+
+// UseWorktreeHooks merges the hooks of a .workie.local.yaml in worktreePath
+// over the configured ones, for the rest of the commands run for that worktree.
+// A file that can't be used is reported and the configured hooks kept. Nothing
+// is merged for a pull request from a fork (Options.FromFork), whose author
+// could otherwise have its hooks run.
+func (wm *WorktreeManager) UseWorktreeHooks(worktreePath string) {
+	if wm.Config == nil || wm.Options.FromFork {
+		return
+	}
+	hooks, path, err := config.MergeWorktreeHooks(wm.Config.Hooks, worktreePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: Ignoring the worktree's hooks: %v\n", err)
+		return
+	}
+	if path != "" {
+		wm.Config.Hooks = hooks
+		wm.printf("✓ Merged worktree hooks from: %s\n", path)
+	}
+}
+
+// parseCommand turns a hook command into the commands to run: one for each
+// stage of a pipeline such as "git log --oneline | head -5", connected by
+// runPipeline. Commands using other shell features (redirects, &&, variables,
+// globs, subshells) run as a single command of shell, or of the platform's
+// shell when it is empty (see shellCommand).
+func parseCommand(ctx context.Context, command, shell string) ([]*exec.Cmd, error) {
 	command = strings.TrimSpace(command)
 	if command == "" {
 		return nil, fmt.Errorf("empty command")
 	}
 
-	// Check if command contains shell operators that require shell execution
-	needsShell := strings.ContainsAny(command, "|&;<>()$`{}*?[]~")
-	needsShell = needsShell || strings.Contains(command, ">>")
-	needsShell = needsShell || strings.Contains(command, "<<")
-	needsShell = needsShell || strings.Contains(command, "&&")
-	needsShell = needsShell || strings.Contains(command, "||")
-
-	var cmds []*exec.Cmd
-
-	if needsShell {
-		// Use shell for complex commands
-		cmd := exec.Command("sh", "-c", command)
-		cmds = append(cmds, cmd)
-	} else {
-		// Simple command - split by whitespace
-		cmdParts := strings.Fields(command)
-		if len(cmdParts) == 0 {
-			return nil, fmt.Errorf("no command parts found")
-		}
-
-		var cmd *exec.Cmd
-		if len(cmdParts) == 1 {
-			cmd = exec.Command(cmdParts[0])
-		} else {
-			cmd = exec.Command(cmdParts[0], cmdParts[1:]...)
-		}
-		cmds = append(cmds, cmd)
+	stages, ok := splitPipeline(command)
+	if !ok {
+		return []*exec.Cmd{shellCommand(ctx, shell, runtime.GOOS, command)}, nil
 	}
 
+	cmds := make([]*exec.Cmd, len(stages))
+	for i, stage := range stages {
+		cmds[i] = exec.CommandContext(ctx, stage[0], stage[1:]...)
+	}
 	return cmds, nil
 }
 
+// hookShell returns the configured hooks.shell, or "" for the platform's shell
+func (wm *WorktreeManager) hookShell() string {
+	if wm.Config == nil || wm.Config.Hooks == nil {
+		return ""
+	}
+	return strings.TrimSpace(wm.Config.Hooks.Shell)
+}
+
 // getHookTimeout returns the configured timeout for hook execution
 func (wm *WorktreeManager) getHookTimeout() time.Duration {
 	// Use configured timeout if available
@@ -724,6 +1353,66 @@ func (wm *WorktreeManager) getHookTimeout() time.Duration {
 	return 5 * time.Minute
 }
 
+// hookEnv returns the WORKIE_* variables describing the hook and the worktree
+// it runs for. They are only set on the hook's command, never in this process.
+func (wm *WorktreeManager) hookEnv(hookType string) []string {
+	env := []string{"WORKIE_HOOK_TYPE=" + hookType}
+	if wm.RepoPath != "" {
+		repoName := wm.RepoName
+		if repoName == "" {
+			repoName = filepath.Base(wm.RepoPath)
+		}
+		env = append(env, "WORKIE_REPO_PATH="+wm.RepoPath, "WORKIE_REPO_NAME="+repoName)
+	}
+	if wm.HookTarget.Branch != "" {
+		env = append(env, "WORKIE_BRANCH="+wm.HookTarget.Branch)
+	}
+	if wm.HookTarget.WorktreePath != "" {
+		env = append(env, "WORKIE_WORKTREE_PATH="+wm.HookTarget.WorktreePath)
+	}
+	return env
+}
+
+// hookBaseEnv returns the environment hook commands start from: workie's own,
+// or with hooks.clean_env only the variables in cleanEnvVars, followed by
+// hooks.env
+func (wm *WorktreeManager) hookBaseEnv() []string {
+	if wm.Config == nil || wm.Config.Hooks == nil {
+		return os.Environ()
+	}
+	hooks := wm.Config.Hooks
+
+	var env []string
+	if hooks.CleanEnv {
+		for _, name := range cleanEnvVars {
+			if value, ok := os.LookupEnv(name); ok {
+				env = append(env, name+"="+value)
+			}
+		}
+	} else {
+		env = os.Environ()
+	}
+
+	names := make([]string, 0, len(hooks.Env))
+	for name := range hooks.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, name+"="+hooks.Env[name])
+	}
+	return env
+}
+
+// hookTimeout returns the hook's own timeout, or the configured hook timeout
+// when it has none
+func (wm *WorktreeManager) hookTimeout(hook config.HookCommand) time.Duration {
+	if hook.Timeout > 0 {
+		return hook.Timeout
+	}
+	return wm.getHookTimeout()
+}
+
 // showProgressIndicator shows a spinning progress indicator
 func (wm *WorktreeManager) showProgressIndicator(message string) {
 	if wm.Options.Quiet {
@@ -738,71 +1427,60 @@ func (wm *WorktreeManager) updateProgress(current, total int) {
 		return
 	}
 	percent := (current * 100) / total
-	fmt.Printf("\r   Progress: [")
+	fmt.Printf("\r   Progress: [%s] %d%% (%d/%d)", progressBar(percent), percent, current, total)
+	if current == total {
+		fmt.Printf("\n")
+	}
+}
+
+// progressBar renders a 20 character bar for the given percentage
+func progressBar(percent int) string {
 	bars := percent / 5 // Each bar represents 5%
+	var b strings.Builder
 	for i := 0; i < 20; i++ {
 		if i < bars {
-			fmt.Printf("█")
+			b.WriteString("█")
 		} else {
-			fmt.Printf("░")
+			b.WriteString("░")
 		}
 	}
-	fmt.Printf("] %d%% (%d/%d)", percent, current, total)
-	if current == total {
-		fmt.Printf("\n")
-	}
+	return b.String()
 }
 
 // executeHookCommand executes a single hook command with timeout and comprehensive error handling
-func (wm *WorktreeManager) executeHookCommand(command, workDir string, index int) HookExecutionResult {
+func (wm *WorktreeManager) executeHookCommand(hook config.HookCommand, workDir, hookType string, index int) HookExecutionResult {
 	result := HookExecutionResult{
 		Index:   index,
-		Command: command,
+		Command: hook.Command,
 		Success: false,
 	}
 
+	// The pipeline is killed when the timeout expires
+	timeout := wm.hookTimeout(hook)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
 	// Parse command using helper method that handles shell operators
-	cmds, err := parseCommand(command)
+	cmds, err := parseCommand(ctx, hook.Command, wm.hookShell())
 	if err != nil {
 		result.Error = fmt.Errorf("command parsing failed: %w", err)
 		return result
 	}
 
-	// For now, we'll only execute the first command in the parsed list
-	cmd := cmds[0]
-	cmd.Dir = workDir
+	env := append(wm.hookBaseEnv(), wm.hookEnv(hookType)...)
+	for _, cmd := range cmds {
+		cmd.Dir = workDir
+		cmd.Env = env
+	}
 
 	// Capture output for verbose mode or error reporting
 	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
 
-	// Set up command execution with timeout
+	// runPipeline only returns once the commands are done with the buffers
 	start := time.Now()
-	timeout := wm.getHookTimeout()
-
-	// Create a channel to signal completion
-	done := make(chan error, 1)
-	go func() {
-		done <- cmd.Run()
-	}()
-
-	// Wait for either completion or timeout
-	var execErr error
-	select {
-	case execErr = <-done:
-		// Command completed within timeout
-		result.Duration = time.Since(start)
-	case <-time.After(timeout):
-		// Command timed out
-		if cmd.Process != nil {
-			if err := cmd.Process.Kill(); err != nil {
-				// Log but don't fail on kill error
-				if wm.Options.Verbose {
-					fmt.Fprintf(os.Stderr, "Warning: failed to kill timed-out process: %v\n", err)
-				}
-			}
-		}
+	execErr := runPipeline(ctx, cmds, &stdout, &syncWriter{w: &stderr})
+	result.Duration = time.Since(start)
+	if errors.Is(execErr, context.DeadlineExceeded) {
 		result.TimedOut = true
 		result.Duration = timeout
 		execErr = fmt.Errorf("command timed out after %v", timeout)
@@ -998,7 +1676,10 @@ func (wm *WorktreeManager) Run(branchName string) error {
 		wm.printf("==============================================\n")
 	}
 
+	wm.startTimings()
+
 	// Step 1: Detect git repository
+	setupStart := time.Now()
 	if err := wm.DetectGitRepository(); err != nil {
 		return err
 	}
@@ -1007,10 +1688,13 @@ func (wm *WorktreeManager) Run(branchName string) error {
 	if err := wm.LoadConfig(); err != nil {
 		return err
 	}
+	wm.timings.record("detect repository and config", setupStart)
 
-	// Step 3: Create worktrees directory
-	if err := wm.CreateWorktreesDirectory(); err != nil {
-		return err
+	// Step 3: Create worktrees directory (a dry run must not touch the filesystem)
+	if !wm.Options.DryRun {
+		if err := wm.CreateWorktreesDirectory(); err != nil {
+			return err
+		}
 	}
 
 	// Step 4: Generate branch name if not provided
@@ -1023,6 +1707,9 @@ func (wm *WorktreeManager) Run(branchName string) error {
 	if err := wm.CreateWorktreeBranch(branchName); err != nil {
 		return err
 	}
+	if wm.Options.DryRun || wm.Options.Quiet {
+		return nil
+	}
 
 	// Step 6: List all worktrees
 	return wm.ListWorktrees()
@@ -0,0 +1,196 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agoodway/workie/config"
+	"github.com/agoodway/workie/errcode"
+)
+
+func TestWorktreePath(t *testing.T) {
+	wm := New()
+	wm.WorktreesDir = "repo-worktrees"
+
+	if got, want := wm.WorktreePath("feature/foo/bar"), filepath.Join("repo-worktrees", "feature", "foo", "bar"); got != want {
+		t.Errorf("Expected nested naming by default, got %s, want %s", got, want)
+	}
+
+	wm.Config = &config.Config{WorktreeDirNaming: "flat"}
+	if got, want := wm.WorktreePath("feature/foo/bar"), filepath.Join("repo-worktrees", "feature-foo-bar"); got != want {
+		t.Errorf("Expected flat naming, got %s, want %s", got, want)
+	}
+}
+
+func TestNestedPathCollision(t *testing.T) {
+	wm := New()
+	wm.WorktreesDir = t.TempDir()
+
+	// feature/foo is a worktree, release is a file
+	if err := os.MkdirAll(filepath.Join(wm.WorktreesDir, "feature", "foo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wm.WorktreesDir, "feature", "foo", ".git"), []byte("gitdir: x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wm.WorktreesDir, "release"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"feature/bar", ""},
+		{"feature/foo", ""},
+		{"feature/foo/bar", "the worktree " + filepath.Join(wm.WorktreesDir, "feature", "foo")},
+		{"release/1.0", "the file " + filepath.Join(wm.WorktreesDir, "release")},
+		{"new/branch", ""},
+	}
+	for _, tt := range tests {
+		if got := wm.nestedPathCollision(wm.WorktreePath(tt.branch)); got != tt.want {
+			t.Errorf("nestedPathCollision(%s) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestSuffixedBranchName(t *testing.T) {
+	long := "feat/" + strings.Repeat("a", 58) // 63 bytes, the limit
+
+	tests := []struct {
+		name   string
+		branch string
+		n      int
+		want   string
+	}{
+		{"short name", "feat/42-login", 2, "feat/42-login-2"},
+		{"fits exactly", strings.Repeat("a", 61), 2, strings.Repeat("a", 61) + "-2"},
+		{"truncated for the suffix", long, 2, long[:61] + "-2"},
+		{"longer suffix truncates more", long, 10, long[:60] + "-10"},
+		{"trailing separator dropped", "feat/" + strings.Repeat("a", 55) + "-bc", 2, "feat/" + strings.Repeat("a", 55) + "-2"},
+		{"multibyte character not split", strings.Repeat("a", 60) + "é", 2, strings.Repeat("a", 60) + "-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := suffixedBranchName(tt.branch, tt.n)
+			if got != tt.want {
+				t.Errorf("suffixedBranchName(%q, %d) = %q, want %q", tt.branch, tt.n, got, tt.want)
+			}
+			if len(got) > maxBranchNameLength {
+				t.Errorf("Expected at most %d bytes, got %d", maxBranchNameLength, len(got))
+			}
+		})
+	}
+}
+
+func TestUniqueBranchName(t *testing.T) {
+	wm := newStashTestRepo(t)
+
+	if got := wm.UniqueBranchName("feat/login"); got != "feat/login" {
+		t.Errorf("Expected a free name to be kept, got %s", got)
+	}
+
+	runGit(t, wm.RepoPath, "branch", "feat/login")
+	runGit(t, wm.RepoPath, "branch", "feat/login-2")
+	if got := wm.UniqueBranchName("feat/login"); got != "feat/login-3" {
+		t.Errorf("Expected the first free suffix, got %s", got)
+	}
+}
+
+func TestCreateWorktreeBranchAutoSuffix(t *testing.T) {
+	wm := newStashTestRepo(t)
+	wm.Options.MoveChanges = false
+	runGit(t, wm.RepoPath, "branch", "feat/login")
+
+	if err := wm.CreateWorktreeBranch("feat/login"); errcode.Of(err) != errcode.BranchExists {
+		t.Fatalf("Expected branch-exists without --auto-suffix, got: %v", err)
+	}
+
+	wm.Options.AutoSuffix = true
+	if err := wm.CreateWorktreeBranch("feat/login"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if wm.HookTarget.Branch != "feat/login-2" || !wm.localBranchExists("feat/login-2") {
+		t.Errorf("Expected feat/login-2 to be created, got %+v", wm.HookTarget)
+	}
+}
+
+func TestCreateWorktreeBranchFrom(t *testing.T) {
+	wm := newStashTestRepo(t)
+	wm.Options.MoveChanges = false
+	runGit(t, wm.RepoPath, "tag", "v1")
+	runGit(t, wm.RepoPath, "commit", "-q", "--allow-empty", "-m", "after v1")
+
+	wm.Options.From = "v2"
+	err := wm.CreateWorktreeBranch("feat/old")
+	if errcode.Of(err) != errcode.Usage || !strings.Contains(err.Error(), "start point 'v2'") {
+		t.Fatalf("Expected an unknown start point to be refused, got: %v", err)
+	}
+	if wm.localBranchExists("feat/old") {
+		t.Error("Expected no branch to be created")
+	}
+
+	wm.Options.From = "v1"
+	if err := wm.CreateWorktreeBranch("feat/old"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := gitOutput(t, wm.RepoPath, "rev-parse", "v1")
+	if got := gitOutput(t, wm.RepoPath, "rev-parse", "feat/old"); got != want {
+		t.Errorf("Expected feat/old to start at v1 (%s), got %s", want, got)
+	}
+
+	wm.Options.From = ""
+	if err := wm.CreateWorktreeBranch("feat/new"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want = gitOutput(t, wm.RepoPath, "rev-parse", "HEAD")
+	if got := gitOutput(t, wm.RepoPath, "rev-parse", "feat/new"); got != want {
+		t.Errorf("Expected feat/new to start at HEAD (%s), got %s", want, got)
+	}
+}
+
+func TestRepoCache(t *testing.T) {
+	repo := newStashTestRepo(t).RepoPath
+	other := newStashTestRepo(t).RepoPath
+	runGit(t, other, "branch", "-m", "main", "master")
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+
+	wm := New()
+	wm.Options.Quiet = true
+	if err := wm.DetectGitRepository(); err != nil {
+		t.Fatal(err)
+	}
+	if branch, err := wm.GetMainBranch(); err != nil || branch != "main" {
+		t.Fatalf("Expected main, got %q, %v", branch, err)
+	}
+
+	// Without git on the PATH, only cached results can be returned
+	path := os.Getenv("PATH")
+	t.Setenv("PATH", "")
+	if err := wm.DetectGitRepository(); err != nil {
+		t.Errorf("Expected the repository to be cached, got: %v", err)
+	}
+	if branch, err := wm.GetMainBranch(); err != nil || branch != "main" {
+		t.Errorf("Expected the main branch to be cached, got %q, %v", branch, err)
+	}
+
+	// Pointing the manager at another repository invalidates the cache
+	t.Setenv("PATH", path)
+	wm.RepoPath = other
+	if branch, err := wm.GetMainBranch(); err != nil || branch != "master" {
+		t.Errorf("Expected master after RepoPath changed, got %q, %v", branch, err)
+	}
+	if err := wm.DetectGitRepository(); err != nil || canonicalPath(wm.RepoPath) != canonicalPath(repo) {
+		t.Errorf("Expected the repository to be detected again, got %s, %v", wm.RepoPath, err)
+	}
+}
@@ -0,0 +1,225 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// MetadataFile is where a worktree's metadata is written, relative to the worktree
+	MetadataFile = ".workie/meta.json"
+
+	// MetadataIndexFile is the index of all worktree metadata, relative to WorktreesDir
+	MetadataIndexFile = ".workie-index.json"
+)
+
+// WorktreeMetadata is the context workie records about a worktree it created
+type WorktreeMetadata struct {
+	Branch    string     `json:"branch"`
+	Path      string     `json:"path"`
+	CreatedAt time.Time  `json:"created_at"`
+	Config    string     `json:"config,omitempty"` // Configuration file the worktree was created with
+	Issue     *IssueLink `json:"issue,omitempty"`  // Issue the worktree was begun from
+	Notes     []string   `json:"notes,omitempty"`
+
+	// AdoptedFrom is where a worktree created outside workie was when 'workie adopt'
+	// took it over
+	AdoptedFrom string `json:"adopted_from,omitempty"`
+}
+
+// IssueLink identifies the issue a worktree was created from
+type IssueLink struct {
+	Provider string `json:"provider"`
+	ID       string `json:"id"`
+	Title    string `json:"title,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// String returns the issue reference, e.g. github:123
+func (l *IssueLink) String() string {
+	return l.Provider + ":" + l.ID
+}
+
+// MetadataEnabled reports whether worktree_metadata is turned on
+func (wm *WorktreeManager) MetadataEnabled() bool {
+	return wm.Config != nil && wm.Config.WorktreeMetadata
+}
+
+// MetadataIndexPath returns the path of the central metadata index
+func (wm *WorktreeManager) MetadataIndexPath() string {
+	return filepath.Join(wm.WorktreesDir, MetadataIndexFile)
+}
+
+// writeWorktreeMetadata records the metadata of a newly created worktree in the
+// worktree itself and in the central index
+func (wm *WorktreeManager) writeWorktreeMetadata(branchName, worktreePath string) error {
+	meta := wm.Metadata
+	meta.Branch = branchName
+	meta.Path = worktreePath
+	meta.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	if wm.Config != nil {
+		meta.Config = wm.Config.LoadedFrom
+	}
+
+	// Keep the metadata file out of git status so it doesn't block 'workie finish'
+	if err := wm.excludeMetadataFile(); err != nil {
+		return err
+	}
+	return wm.saveWorktreeMetadata(&meta)
+}
+
+// saveWorktreeMetadata writes meta into its worktree and updates the index entry
+func (wm *WorktreeManager) saveWorktreeMetadata(meta *WorktreeMetadata) error {
+	if err := writeJSONFile(filepath.Join(meta.Path, MetadataFile), meta); err != nil {
+		return err
+	}
+
+	index, err := wm.LoadMetadataIndex()
+	if err != nil {
+		// A corrupt index is rebuilt from the worktrees that are written from now on
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %v; starting a new index\n", err)
+	}
+	index[meta.Branch] = *meta
+	return writeJSONFile(wm.MetadataIndexPath(), index)
+}
+
+// excludeMetadataFile adds the metadata file to the repository's info/exclude,
+// which git reads for every worktree
+func (wm *WorktreeManager) excludeMetadataFile() error {
+	pattern := "/" + MetadataFile
+	excludePath := filepath.Join(wm.gitCommonDir(), "info", "exclude")
+
+	data, err := os.ReadFile(excludePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", excludePath, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return nil
+		}
+	}
+
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		data = append(data, '\n')
+	}
+	data = append(data, []byte("# Worktree metadata written by workie\n"+pattern+"\n")...)
+
+	if err := os.MkdirAll(filepath.Dir(excludePath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(excludePath), err)
+	}
+	if err := os.WriteFile(excludePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to update %s: %w", excludePath, err)
+	}
+	return nil
+}
+
+// LoadMetadataIndex reads the central metadata index, keyed by branch. A missing
+// index is empty; a corrupt one yields an empty index along with the error.
+func (wm *WorktreeManager) LoadMetadataIndex() (map[string]WorktreeMetadata, error) {
+	index := make(map[string]WorktreeMetadata)
+
+	data, err := os.ReadFile(wm.MetadataIndexPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, nil
+		}
+		return index, fmt.Errorf("failed to read worktree index %s: %w", wm.MetadataIndexPath(), err)
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return make(map[string]WorktreeMetadata), fmt.Errorf("worktree index %s is corrupt: %w", wm.MetadataIndexPath(), err)
+	}
+	return index, nil
+}
+
+// ReadWorktreeMetadata reads the metadata file of the worktree at worktreePath
+func ReadWorktreeMetadata(worktreePath string) (*WorktreeMetadata, error) {
+	data, err := os.ReadFile(filepath.Join(worktreePath, MetadataFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var meta WorktreeMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("%s in %s is corrupt: %w", MetadataFile, worktreePath, err)
+	}
+	return &meta, nil
+}
+
+// GetWorktreeMetadata returns the metadata recorded for branchName, read from
+// its worktree or, when the worktree has none, from the central index
+func (wm *WorktreeManager) GetWorktreeMetadata(branchName string) (*WorktreeMetadata, error) {
+	if path, err := wm.FindWorktree(branchName); err == nil {
+		meta, err := ReadWorktreeMetadata(path)
+		if err == nil {
+			return meta, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	index, err := wm.LoadMetadataIndex()
+	if err != nil {
+		return nil, err
+	}
+	if meta, ok := index[branchName]; ok {
+		return &meta, nil
+	}
+
+	return nil, fmt.Errorf("no metadata recorded for branch '%s'\n\nTo fix this:\n  • Enable it in your .workie.yaml with: worktree_metadata: true\n  • Metadata is only recorded for worktrees created by workie begin after that\n  • List worktrees with: workie list", branchName)
+}
+
+// AddWorktreeNote appends a note to the metadata of branchName
+func (wm *WorktreeManager) AddWorktreeNote(branchName, note string) (*WorktreeMetadata, error) {
+	meta, err := wm.GetWorktreeMetadata(branchName)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.Notes = append(meta.Notes, note)
+	if err := wm.saveWorktreeMetadata(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// RemoveWorktreeMetadata drops branchName from the central index once its
+// worktree is removed
+func (wm *WorktreeManager) RemoveWorktreeMetadata(branchName string) error {
+	index, err := wm.LoadMetadataIndex()
+	if err != nil {
+		return err
+	}
+	if _, ok := index[branchName]; !ok {
+		return nil
+	}
+
+	delete(index, branchName)
+	return writeJSONFile(wm.MetadataIndexPath(), index)
+}
+
+// writeJSONFile writes v as indented JSON, replacing path atomically
+func writeJSONFile(path string, v interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/agoodway/workie/config"
+)
+
+func TestWorktreeMetadata(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+	wm.Config = &config.Config{WorktreeMetadata: true, LoadedFrom: filepath.Join(repo, ".workie.yaml")}
+	wm.Metadata = WorktreeMetadata{
+		Issue: &IssueLink{Provider: "github", ID: "42", Title: "Fix login"},
+		Notes: []string{"started on call"},
+	}
+
+	if err := wm.CreateWorktreeBranch("feature/login"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	worktreePath := filepath.Join(wm.WorktreesDir, "feature", "login")
+
+	meta, err := ReadWorktreeMetadata(worktreePath)
+	if err != nil {
+		t.Fatalf("Expected metadata in the worktree: %v", err)
+	}
+	if meta.Branch != "feature/login" || meta.Path != worktreePath || meta.Config != wm.Config.LoadedFrom || meta.CreatedAt.IsZero() {
+		t.Errorf("Unexpected metadata: %+v", meta)
+	}
+	if meta.Issue == nil || meta.Issue.String() != "github:42" {
+		t.Errorf("Expected the issue to be recorded, got %+v", meta.Issue)
+	}
+
+	// The metadata file must not make the worktree dirty, or finish would refuse it
+	if hasUncommittedChanges(worktreePath) {
+		t.Error("Expected the metadata file to be excluded from git status")
+	}
+
+	statuses, err := wm.GetWorktreeStatuses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || statuses[1].Issue == nil || statuses[1].Issue.ID != "42" {
+		t.Errorf("Expected list to show the linked issue, got %+v", statuses)
+	}
+
+	meta, err = wm.AddWorktreeNote("feature/login", "needs review")
+	if err != nil {
+		t.Fatalf("Expected no error adding a note, got: %v", err)
+	}
+	if len(meta.Notes) != 2 || meta.Notes[1] != "needs review" {
+		t.Errorf("Unexpected notes: %v", meta.Notes)
+	}
+
+	index, err := wm.LoadMetadataIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index["feature/login"].Notes) != 2 {
+		t.Errorf("Expected the index to be updated, got %+v", index)
+	}
+
+	// Metadata stays available from the index once the worktree directory is gone
+	if err := os.RemoveAll(worktreePath); err != nil {
+		t.Fatal(err)
+	}
+	if meta, err := wm.GetWorktreeMetadata("feature/login"); err != nil || meta.Issue == nil {
+		t.Errorf("Expected metadata from the index, got %+v, %v", meta, err)
+	}
+
+	if err := wm.RemoveWorktreeMetadata("feature/login"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wm.GetWorktreeMetadata("feature/login"); err == nil {
+		t.Error("Expected no metadata after removal")
+	}
+}
+
+func TestWorktreeMetadataDisabled(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+	wm.Config = &config.Config{}
+
+	if err := wm.CreateWorktreeBranch("feature/plain"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wm.WorktreesDir, "feature", "plain", MetadataFile)); !os.IsNotExist(err) {
+		t.Errorf("Expected no metadata file when disabled, got err=%v", err)
+	}
+	if _, err := os.Stat(wm.MetadataIndexPath()); !os.IsNotExist(err) {
+		t.Errorf("Expected no index when disabled, got err=%v", err)
+	}
+}
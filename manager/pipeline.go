@@ -0,0 +1,191 @@
+package manager
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// shellOnlyChars are characters that need a shell when they appear outside quotes:
+// redirects, command lists, subshells, expansions, globs and escapes, including
+// cmd.exe's %VAR% and ^ escapes
+const shellOnlyChars = "&;<>()$`{}*?[]~\\%^"
+
+// pipelineWaitDelay bounds how long a cancelled pipeline waits for its output
+// to be closed, e.g. by a background process a killed shell left running
+const pipelineWaitDelay = time.Second
+
+// shellCommand returns the command that runs command in shell, which is a
+// name or path such as sh, bash, cmd or pwsh. An empty shell uses the
+// platform's: cmd on Windows (goos "windows"), sh elsewhere. The command is
+// killed when ctx is done.
+func shellCommand(ctx context.Context, shell, goos, command string) *exec.Cmd {
+	if shell == "" {
+		shell = "sh"
+		if goos == "windows" {
+			shell = "cmd"
+		}
+	}
+
+	name := strings.ToLower(shell[strings.LastIndexAny(shell, `/\`)+1:])
+	switch strings.TrimSuffix(name, ".exe") {
+	case "cmd":
+		cmd := exec.CommandContext(ctx, shell, "/C", command)
+		setCmdLine(cmd, shell+" /C "+command)
+		return cmd
+	case "powershell", "pwsh":
+		return exec.CommandContext(ctx, shell, "-NoProfile", "-Command", command)
+	}
+	return exec.CommandContext(ctx, shell, "-c", command)
+}
+
+// splitPipeline splits a command into the words of each stage of a pipeline,
+// honoring single and double quotes. It reports false when the command uses
+// anything besides plain words and "|", which only a shell can run.
+func splitPipeline(command string) ([][]string, bool) {
+	var (
+		stages [][]string
+		words  []string
+		word   strings.Builder
+		inWord bool
+		quote  rune
+	)
+	endWord := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+
+	for _, r := range command {
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else if r == '$' || r == '`' || r == '\\' {
+				return nil, false // Expanded by the shell even within double quotes
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			endWord()
+		case r == '|':
+			endWord()
+			if len(words) == 0 {
+				return nil, false // "||" or an empty stage
+			}
+			stages = append(stages, words)
+			words = nil
+		case r == '#' && !inWord:
+			return nil, false // A comment
+		case strings.ContainsRune(shellOnlyChars, r):
+			return nil, false
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	endWord()
+
+	if quote != 0 || len(words) == 0 {
+		return nil, false
+	}
+	stages = append(stages, words)
+
+	// A leading NAME=value sets a variable for the command, which needs a shell
+	for _, stage := range stages {
+		if strings.Contains(stage[0], "=") {
+			return nil, false
+		}
+	}
+	return stages, true
+}
+
+// runPipeline runs commands as a pipeline, connecting the standard output of
+// each to the standard input of the next. The last command's output goes to
+// stdout and every command's errors to stderr. As in sh, the pipeline's result
+// is the result of its last command.
+//
+// The commands must have been created with ctx, which stops them: when it is
+// done every stage is killed, and runPipeline returns once they have exited and
+// stdout and stderr are no longer written to.
+func runPipeline(ctx context.Context, cmds []*exec.Cmd, stdout, stderr io.Writer) error {
+	for _, cmd := range cmds {
+		cmd.Stderr = stderr
+		cmd.WaitDelay = pipelineWaitDelay
+	}
+	cmds[len(cmds)-1].Stdout = stdout
+
+	var pipes []*os.File
+	closePipes := func() {
+		for _, f := range pipes {
+			f.Close()
+		}
+	}
+	for i := 0; i < len(cmds)-1; i++ {
+		r, w, err := os.Pipe()
+		if err != nil {
+			closePipes()
+			return err
+		}
+		cmds[i].Stdout = w
+		cmds[i+1].Stdin = r
+		pipes = append(pipes, r, w)
+	}
+
+	var started []*exec.Cmd
+	var startErr error
+	for _, cmd := range cmds {
+		if startErr = cmd.Start(); startErr != nil {
+			break
+		}
+		started = append(started, cmd)
+	}
+
+	// The commands have their own copies of the pipe ends; closing ours lets
+	// each stage see the end of its input when the previous one exits
+	closePipes()
+
+	if startErr != nil {
+		for _, cmd := range started {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+		}
+		return startErr
+	}
+
+	var err error
+	for _, cmd := range cmds {
+		err = cmd.Wait()
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// syncWriter serializes writes from the commands of a pipeline sharing a writer
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
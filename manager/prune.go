@@ -0,0 +1,136 @@
+package manager
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// StaleWorktrees lists what 'workie prune' would clean up
+type StaleWorktrees struct {
+	Prunable []WorktreeInfo // Registered worktrees whose directory no longer exists
+	Orphaned []string       // Directories in the worktrees directory git doesn't know about
+}
+
+// Count returns the number of stale entries found
+func (s *StaleWorktrees) Count() int {
+	return len(s.Prunable) + len(s.Orphaned)
+}
+
+// PruneSummary reports the outcome of pruning
+type PruneSummary struct {
+	Pruned  int // Stale worktree records removed by git worktree prune
+	Removed int // Orphaned directories deleted
+	Failed  int // Orphaned directories that could not be deleted
+}
+
+// FindStaleWorktrees finds worktree records whose directory is gone and
+// directories under WorktreesDir that no longer belong to a registered worktree
+func (wm *WorktreeManager) FindStaleWorktrees() (*StaleWorktrees, error) {
+	worktrees, err := wm.GetWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	stale := &StaleWorktrees{}
+	known := make(map[string]bool, len(worktrees))
+	for _, wt := range worktrees {
+		if wt.Prunable {
+			stale.Prunable = append(stale.Prunable, wt)
+			continue
+		}
+		known[canonicalPath(wt.Path)] = true
+	}
+
+	orphaned, err := wm.findOrphanedDirectories(known)
+	if err != nil {
+		return nil, err
+	}
+	stale.Orphaned = orphaned
+	return stale, nil
+}
+
+// findOrphanedDirectories walks WorktreesDir for directories that are neither a
+// known worktree nor a parent of one (branches with slashes nest worktrees)
+func (wm *WorktreeManager) findOrphanedDirectories(known map[string]bool) ([]string, error) {
+	root := canonicalPath(wm.WorktreesDir)
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return nil, nil
+	}
+
+	var orphaned []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root || !d.IsDir() {
+			return nil
+		}
+		if known[path] {
+			return filepath.SkipDir
+		}
+		if !containsWorktree(path, known) {
+			orphaned = append(orphaned, path)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan worktrees directory %s: %w", root, err)
+	}
+
+	sort.Strings(orphaned)
+	return orphaned, nil
+}
+
+// containsWorktree reports whether dir is a parent of any known worktree
+func containsWorktree(dir string, known map[string]bool) bool {
+	prefix := dir + string(filepath.Separator)
+	for path := range known {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalPath resolves symlinks so paths reported by git compare equal to
+// paths found on disk (e.g. /tmp and /private/tmp on macOS)
+func canonicalPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+// PruneWorktrees runs git worktree prune and, when removeOrphans is set,
+// deletes the orphaned directories found by FindStaleWorktrees
+func (wm *WorktreeManager) PruneWorktrees(stale *StaleWorktrees, removeOrphans bool) (PruneSummary, error) {
+	var summary PruneSummary
+
+	cmd := exec.Command("git", "worktree", "prune")
+	cmd.Dir = wm.RepoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return summary, fmt.Errorf("git worktree prune failed: %s\n\nTo fix this:\n  • Check repository status: git status\n  • Run it manually: git worktree prune --verbose", strings.TrimSpace(string(output)))
+	}
+	summary.Pruned = len(stale.Prunable)
+
+	if !removeOrphans {
+		return summary, nil
+	}
+
+	for _, dir := range stale.Orphaned {
+		if err := os.RemoveAll(dir); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Failed to remove %s: %v\n", dir, err)
+			summary.Failed++
+			continue
+		}
+		summary.Removed++
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,75 @@
+package manager
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestPruneWorktrees(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+
+	worktreesDir := filepath.Join(root, "repo-worktrees")
+	kept := filepath.Join(worktreesDir, "feature", "kept")
+	deleted := filepath.Join(worktreesDir, "feature", "deleted")
+	orphan := filepath.Join(worktreesDir, "old-experiment")
+	runGit(t, repo, "worktree", "add", "-q", "-b", "feature/kept", kept)
+	runGit(t, repo, "worktree", "add", "-q", "-b", "feature/deleted", deleted)
+	if err := os.RemoveAll(deleted); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(orphan, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	wm := New()
+	wm.RepoPath = repo
+	wm.WorktreesDir = worktreesDir
+
+	stale, err := wm.FindStaleWorktrees()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(stale.Prunable) != 1 || stale.Prunable[0].Branch != "feature/deleted" {
+		t.Errorf("Expected feature/deleted to be prunable, got %+v", stale.Prunable)
+	}
+	if len(stale.Orphaned) != 1 || stale.Orphaned[0] != orphan {
+		t.Errorf("Expected only %s to be orphaned, got %v", orphan, stale.Orphaned)
+	}
+
+	summary, err := wm.PruneWorktrees(stale, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if summary.Pruned != 1 || summary.Removed != 1 || summary.Failed != 0 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+	if _, err := os.Stat(orphan); !os.IsNotExist(err) {
+		t.Errorf("Expected orphaned directory to be removed, got err=%v", err)
+	}
+	if _, err := os.Stat(kept); err != nil {
+		t.Errorf("Expected registered worktree to be kept: %v", err)
+	}
+
+	stale, err = wm.FindStaleWorktrees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stale.Count() != 0 {
+		t.Errorf("Expected nothing left to prune, got %+v", stale)
+	}
+}
@@ -0,0 +1,55 @@
+package manager
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// PullRequestBranch returns the local branch a pull request is checked out on:
+// its head branch when that is in the repository, or pr/<number> for a pull
+// request from a fork, whose head branch name may clash with the repository's
+func PullRequestBranch(number, headRef string, fromFork bool) string {
+	if fromFork || headRef == "" {
+		return "pr/" + number
+	}
+	return headRef
+}
+
+// IsForkPullRequestBranch reports whether branch is named like the pr/<number>
+// branch a pull request from a fork is checked out on
+func IsForkPullRequestBranch(branch string) bool {
+	number, ok := strings.CutPrefix(branch, "pr/")
+	if !ok || number == "" {
+		return false
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FetchPullRequest fetches a pull request's changes from origin, as gh pr
+// checkout does, and returns the branch to check them out on. A head branch in
+// the repository updates origin/<branch>, which a new worktree then tracks; a
+// pull request from a fork is fetched from refs/pull/<number>/head into a local
+// pr/<number> branch.
+func (wm *WorktreeManager) FetchPullRequest(number, headRef string, fromFork bool) (string, error) {
+	branch := PullRequestBranch(number, headRef, fromFork)
+
+	refspec := fmt.Sprintf("+refs/heads/%s:refs/remotes/origin/%s", headRef, headRef)
+	if branch != headRef {
+		refspec = fmt.Sprintf("refs/pull/%s/head:refs/heads/%s", number, branch)
+	}
+
+	wm.printf("🔄 Fetching pull request #%s from origin...\n", number)
+	cmd := exec.Command("git", "fetch", "origin", refspec)
+	cmd.Dir = wm.RepoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to fetch pull request #%s: %s\n\nTo fix this:\n  • Check that origin is the repository the pull request was opened on: git remote -v\n  • If %s has commits of your own, rename it first: git branch -m %s <new name>", number, strings.TrimSpace(string(output)), branch, branch)
+	}
+	wm.printf("✓ Fetched pull request #%s into %s\n", number, branch)
+	return branch, nil
+}
@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newPullRequestTestRepo gives the test repository an origin with a branch
+// feat/login and a fork pull request under refs/pull/7/head
+func newPullRequestTestRepo(t *testing.T) *WorktreeManager {
+	t.Helper()
+	wm := newStashTestRepo(t)
+	wm.Options.MoveChanges = false
+
+	origin := filepath.Join(filepath.Dir(wm.RepoPath), "origin.git")
+	runGit(t, wm.RepoPath, "clone", "-q", "--bare", wm.RepoPath, origin)
+	runGit(t, wm.RepoPath, "remote", "add", "origin", origin)
+
+	runGit(t, wm.RepoPath, "switch", "-q", "-c", "feat/login")
+	if err := os.WriteFile(filepath.Join(wm.RepoPath, "login.txt"), []byte("login\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, wm.RepoPath, "add", "login.txt")
+	runGit(t, wm.RepoPath, "commit", "-q", "-m", "login")
+	runGit(t, wm.RepoPath, "push", "-q", "origin", "feat/login", "feat/login:refs/pull/7/head")
+	runGit(t, wm.RepoPath, "switch", "-q", "main")
+	runGit(t, wm.RepoPath, "branch", "-q", "-D", "feat/login")
+	return wm
+}
+
+func TestPullRequestBranch(t *testing.T) {
+	if got := PullRequestBranch("7", "feat/login", false); got != "feat/login" {
+		t.Errorf("Expected the head branch, got %s", got)
+	}
+	if got := PullRequestBranch("7", "main", true); got != "pr/7" {
+		t.Errorf("Expected pr/7 for a fork, got %s", got)
+	}
+	for branch, want := range map[string]bool{"pr/7": true, "pr/": false, "pr/login": false, "feat/pr/7": false} {
+		if got := IsForkPullRequestBranch(branch); got != want {
+			t.Errorf("IsForkPullRequestBranch(%q) = %v, want %v", branch, got, want)
+		}
+	}
+}
+
+func TestFetchPullRequest(t *testing.T) {
+	wm := newPullRequestTestRepo(t)
+
+	branch, err := wm.FetchPullRequest("7", "feat/login", false)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if branch != "feat/login" || !wm.remoteBranchExists("feat/login") {
+		t.Errorf("Expected origin/feat/login to be fetched, got branch %s", branch)
+	}
+
+	branch, err = wm.FetchPullRequest("7", "feat/login", true)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if branch != "pr/7" || !wm.localBranchExists("pr/7") {
+		t.Errorf("Expected a local pr/7 branch, got branch %s", branch)
+	}
+
+	_, err = wm.FetchPullRequest("8", "feat/gone", true)
+	if err == nil || !strings.Contains(err.Error(), "failed to fetch pull request #8") {
+		t.Errorf("Expected a fetch error, got: %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build darwin
+
+package manager
+
+import "golang.org/x/sys/unix"
+
+// reflinkFile creates a copy-on-write clone of src at dst using clonefile(2).
+// Supported on APFS volumes.
+func reflinkFile(src, dst string) error {
+	if err := prepareDestination(dst); err != nil {
+		return err
+	}
+	return unix.Clonefile(src, dst, unix.CLONE_NOFOLLOW)
+}
@@ -0,0 +1,41 @@
+//go:build linux
+
+package manager
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// reflinkFile creates a copy-on-write clone of src at dst using the FICLONE ioctl.
+// Supported on filesystems such as Btrfs and XFS.
+func reflinkFile(src, dst string) error {
+	if err := prepareDestination(dst); err != nil {
+		return err
+	}
+
+	sourceFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	info, err := sourceFile.Stat()
+	if err != nil {
+		return err
+	}
+
+	destFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	if err := unix.IoctlFileClone(int(destFile.Fd()), int(sourceFile.Fd())); err != nil {
+		destFile.Close()
+		os.Remove(dst)
+		return err
+	}
+
+	return destFile.Close()
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package manager
+
+import "errors"
+
+// reflinkFile is not supported on this platform; callers fall back to a regular copy
+func reflinkFile(src, dst string) error {
+	return errors.New("reflinks are not supported on this platform")
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package manager
+
+import "os/exec"
+
+// cleanEnvVars are the variables hooks keep from workie's environment with
+// hooks.clean_env
+var cleanEnvVars = []string{"PATH", "HOME"}
+
+// setCmdLine is only needed for cmd.exe on Windows; elsewhere arguments are
+// passed to the program as they are
+func setCmdLine(cmd *exec.Cmd, line string) {}
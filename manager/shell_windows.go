@@ -0,0 +1,19 @@
+//go:build windows
+
+package manager
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// cleanEnvVars are the variables hooks keep from workie's environment with
+// hooks.clean_env. Besides PATH and HOME, Windows programs need the system
+// directories, the shell and the temporary directory to start.
+var cleanEnvVars = []string{"PATH", "HOME", "USERPROFILE", "SYSTEMROOT", "COMSPEC", "PATHEXT", "TEMP", "TMP"}
+
+// setCmdLine passes line to the program unchanged. cmd.exe does not parse its
+// arguments the way Go quotes them, so /C commands are handed over verbatim.
+func setCmdLine(cmd *exec.Cmd, line string) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: line}
+}
@@ -0,0 +1,112 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// stashChanges stashes the repository's uncommitted changes, including untracked
+// files, so begin --move-changes can apply them in the new worktree. It returns
+// the stash commit, or "" when there are no changes to move.
+func (wm *WorktreeManager) stashChanges(branchName string) (string, error) {
+	changes, err := wm.uncommittedChanges()
+	if err != nil {
+		return "", err
+	}
+	if changes == 0 {
+		wm.printf("📦 No uncommitted changes to move\n")
+		return "", nil
+	}
+
+	message := fmt.Sprintf("workie: moving changes to %s", branchName)
+	cmd := exec.Command("git", "stash", "push", "--include-untracked", "-m", message)
+	cmd.Dir = wm.RepoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to stash uncommitted changes: %s\n\nTo fix this:\n  • Check the repository state: git status\n  • Or commit or stash the changes yourself and drop --move-changes", strings.TrimSpace(string(output)))
+	}
+
+	cmd = exec.Command("git", "rev-parse", "--verify", "refs/stash")
+	cmd.Dir = wm.RepoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the stash of uncommitted changes: %w\n\nTo fix this:\n  • Your changes are in the latest stash: git stash list", err)
+	}
+
+	wm.printf("📦 Stashed %d uncommitted change(s) to move them to '%s'\n", changes, branchName)
+	return strings.TrimSpace(string(output)), nil
+}
+
+// uncommittedChanges counts the modified, staged and untracked paths in the repository
+func (wm *WorktreeManager) uncommittedChanges() (int, error) {
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = wm.RepoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check for uncommitted changes: %w", err)
+	}
+	lines := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return 0, nil
+	}
+	return len(lines), nil
+}
+
+// applyStash applies the stash to the worktree at path and drops it. If it does
+// not apply cleanly, e.g. because of conflicts, the stash is kept so no changes
+// are lost.
+func (wm *WorktreeManager) applyStash(stash, path string) error {
+	ref := stashRef(wm.RepoPath, stash)
+
+	cmd := exec.Command("git", "stash", "apply", stash)
+	cmd.Dir = path
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to apply the moved changes to the worktree; they are kept in %s\n\nError details: %s\n\nTo fix this:\n  • Resolve any conflicts in %s, then drop the stash: git stash drop %s\n  • Or apply them elsewhere later: git stash apply %s", ref, strings.TrimSpace(string(output)), path, ref, ref)
+	}
+
+	wm.dropStash(ref)
+	wm.printf("✓ Moved uncommitted changes into the worktree\n")
+	return nil
+}
+
+// restoreStash puts stashed changes back into the repository after the worktree
+// could not be created
+func (wm *WorktreeManager) restoreStash(stash string) error {
+	ref := stashRef(wm.RepoPath, stash)
+
+	cmd := exec.Command("git", "stash", "apply", "--index", stash)
+	cmd.Dir = wm.RepoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to restore your uncommitted changes; they are kept in %s\n\nError details: %s\n\nTo fix this:\n  • Restore them yourself: git stash pop %s", ref, strings.TrimSpace(string(output)), ref)
+	}
+
+	wm.dropStash(ref)
+	return nil
+}
+
+// dropStash deletes a stash entry whose changes have been applied
+func (wm *WorktreeManager) dropStash(ref string) {
+	cmd := exec.Command("git", "stash", "drop", ref)
+	cmd.Dir = wm.RepoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: The changes were applied but %s could not be dropped: %s\n", ref, strings.TrimSpace(string(output)))
+	}
+}
+
+// stashRef returns the stash@{n} name of a stash commit, or the commit itself
+// if it is no longer in the stash list
+func stashRef(repoPath, stash string) string {
+	cmd := exec.Command("git", "stash", "list", "--format=%H")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return stash
+	}
+	for i, commit := range strings.Fields(string(output)) {
+		if commit == stash {
+			return fmt.Sprintf("stash@{%d}", i)
+		}
+	}
+	return stash
+}
@@ -0,0 +1,135 @@
+package manager
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agoodway/workie/config"
+)
+
+// newStashTestRepo creates a repository with a committed notes.txt and returns
+// a manager for it
+func newStashTestRepo(t *testing.T) *WorktreeManager {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(repo, "notes.txt"), []byte("one\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", "notes.txt")
+	runGit(t, repo, "commit", "-q", "-m", "init")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.Options.MoveChanges = true
+	wm.RepoPath = repo
+	wm.WorktreesDir = filepath.Join(root, "repo-worktrees")
+	wm.Config = &config.Config{}
+	return wm
+}
+
+// gitOutput returns the trimmed output of a git command run in dir
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %s failed: %v", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestMoveChanges(t *testing.T) {
+	wm := newStashTestRepo(t)
+	if err := os.WriteFile(filepath.Join(wm.RepoPath, "notes.txt"), []byte("one\ntwo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wm.RepoPath, "new.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := wm.CreateWorktreeBranch("feature/moved"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if status := gitOutput(t, wm.RepoPath, "status", "--porcelain"); status != "" {
+		t.Errorf("Expected the repository to be left clean, got:\n%s", status)
+	}
+	worktree := filepath.Join(wm.WorktreesDir, "feature", "moved")
+	if data, err := os.ReadFile(filepath.Join(worktree, "notes.txt")); err != nil || string(data) != "one\ntwo\n" {
+		t.Errorf("Expected the modified file in the worktree, got %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(worktree, "new.txt")); err != nil {
+		t.Errorf("Expected the untracked file in the worktree: %v", err)
+	}
+	if stashes := gitOutput(t, wm.RepoPath, "stash", "list"); stashes != "" {
+		t.Errorf("Expected the stash to be dropped, got:\n%s", stashes)
+	}
+}
+
+func TestMoveChangesKeepsStashOnConflict(t *testing.T) {
+	wm := newStashTestRepo(t)
+
+	// An existing branch whose notes.txt conflicts with the change being moved
+	runGit(t, wm.RepoPath, "checkout", "-q", "-b", "feature/other")
+	if err := os.WriteFile(filepath.Join(wm.RepoPath, "notes.txt"), []byte("uno\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, wm.RepoPath, "commit", "-q", "-am", "other")
+	runGit(t, wm.RepoPath, "checkout", "-q", "main")
+	if err := os.WriteFile(filepath.Join(wm.RepoPath, "notes.txt"), []byte("one\ntwo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wm.Options.Checkout = true
+	if err := wm.CreateWorktreeBranch("feature/other"); err != nil {
+		t.Fatalf("Expected the worktree to be created despite the conflict, got: %v", err)
+	}
+
+	stashes := gitOutput(t, wm.RepoPath, "stash", "list")
+	if !strings.Contains(stashes, "workie: moving changes to feature/other") {
+		t.Errorf("Expected the stash to be kept, got:\n%q", stashes)
+	}
+}
+
+func TestMoveChangesRestoresOnFailure(t *testing.T) {
+	wm := newStashTestRepo(t)
+	if err := os.WriteFile(filepath.Join(wm.RepoPath, "notes.txt"), []byte("one\ntwo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, wm.RepoPath, "add", "notes.txt")
+
+	// A file where the worktree's parent directory should be makes git worktree add fail
+	if err := os.MkdirAll(wm.WorktreesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wm.WorktreesDir, "feature"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := wm.CreateWorktreeBranch("feature/blocked"); err == nil {
+		t.Fatal("Expected creating the worktree to fail")
+	}
+	if status := gitOutput(t, wm.RepoPath, "status", "--porcelain"); status != "M  notes.txt" {
+		t.Errorf("Expected the staged change to be restored, got %q", status)
+	}
+	if stashes := gitOutput(t, wm.RepoPath, "stash", "list"); stashes != "" {
+		t.Errorf("Expected the stash to be dropped, got:\n%s", stashes)
+	}
+}
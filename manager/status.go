@@ -0,0 +1,198 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/agoodway/workie/table"
+)
+
+// WorktreeGitStatus is the git state of a worktree: its uncommitted changes and
+// how far it is ahead of or behind its upstream branch
+type WorktreeGitStatus struct {
+	Path       string `json:"path"`
+	Branch     string `json:"branch"`
+	IsMain     bool   `json:"is_main"`            // The main repository rather than a linked worktree
+	Missing    bool   `json:"missing,omitempty"`  // The directory is gone; git worktree prune will drop it
+	Dirty      bool   `json:"dirty"`              // Has uncommitted changes
+	Staged     int    `json:"staged"`             // Files with staged changes
+	Modified   int    `json:"modified"`           // Files with unstaged changes
+	Untracked  int    `json:"untracked"`          // Untracked files
+	Conflicted int    `json:"conflicted"`         // Files with merge conflicts
+	Upstream   string `json:"upstream,omitempty"` // Upstream branch, e.g. origin/feature/login
+	Ahead      int    `json:"ahead"`              // Commits on HEAD that the upstream lacks
+	Behind     int    `json:"behind"`             // Commits on the upstream that HEAD lacks
+}
+
+// GetWorktreeGitStatuses returns the git state of every worktree, the main
+// repository first
+func (wm *WorktreeManager) GetWorktreeGitStatuses() ([]WorktreeGitStatus, error) {
+	worktrees, err := wm.GetWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]WorktreeGitStatus, 0, len(worktrees))
+	for i, wt := range worktrees {
+		status := WorktreeGitStatus{
+			Path:   wt.Path,
+			Branch: wt.Branch,
+			IsMain: i == 0, // git always lists the main worktree first
+		}
+		if _, err := os.Stat(wt.Path); err != nil {
+			status.Missing = true
+			statuses = append(statuses, status)
+			continue
+		}
+
+		if err := readWorkingTreeStatus(wt.Path, &status); err != nil {
+			return nil, fmt.Errorf("failed to read the status of %s: %w", wt.Path, err)
+		}
+		status.Dirty = status.Staged+status.Modified+status.Untracked+status.Conflicted > 0
+		readUpstreamStatus(wt.Path, &status)
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// readWorkingTreeStatus counts the uncommitted changes reported by git status
+func readWorkingTreeStatus(path string, status *WorktreeGitStatus) error {
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = path
+	output, err := cmd.Output()
+	if err != nil {
+		return err
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		x, y := line[0], line[1]
+		switch {
+		case x == '?' && y == '?':
+			status.Untracked++
+		case x == 'U' || y == 'U' || (x == 'A' && y == 'A') || (x == 'D' && y == 'D'):
+			status.Conflicted++
+		default:
+			if x != ' ' {
+				status.Staged++
+			}
+			if y != ' ' {
+				status.Modified++
+			}
+		}
+	}
+	return nil
+}
+
+// readUpstreamStatus fills in the upstream branch and the ahead/behind counts.
+// Branches without an upstream, and detached worktrees, are left without one.
+func readUpstreamStatus(path string, status *WorktreeGitStatus) {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	cmd.Dir = path
+	output, err := cmd.Output()
+	if err != nil {
+		return
+	}
+	status.Upstream = strings.TrimSpace(string(output))
+
+	cmd = exec.Command("git", "rev-list", "--left-right", "--count", "@{upstream}...HEAD")
+	cmd.Dir = path
+	if output, err = cmd.Output(); err != nil {
+		return
+	}
+	if counts := strings.Fields(string(output)); len(counts) == 2 {
+		status.Behind, _ = strconv.Atoi(counts[0])
+		status.Ahead, _ = strconv.Atoi(counts[1])
+	}
+}
+
+// WriteWorktreeGitStatusesJSON writes the git state of every worktree as a JSON array
+func (wm *WorktreeManager) WriteWorktreeGitStatusesJSON(w io.Writer) error {
+	statuses, err := wm.GetWorktreeGitStatuses()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(statuses)
+}
+
+// ShowStatus prints the git state of every worktree as a table
+func (wm *WorktreeManager) ShowStatus() error {
+	statuses, err := wm.GetWorktreeGitStatuses()
+	if err != nil {
+		return fmt.Errorf("cannot read worktree status: %w\n\nTo fix this:\n  • Ensure you're in a valid git repository\n  • Run 'git worktree prune' if worktrees were deleted manually", err)
+	}
+
+	wm.printf("\n📊 Worktree status:\n")
+	if err := writeStatusTable(os.Stdout, statuses); err != nil {
+		return err
+	}
+
+	dirty := 0
+	for _, status := range statuses {
+		if status.Dirty {
+			dirty++
+		}
+	}
+	wm.printf("\n%d worktree(s), %d with uncommitted changes\n", len(statuses), dirty)
+	return nil
+}
+
+// writeStatusTable writes worktree git states as a table of branch, changes,
+// upstream, ahead/behind and path. The main repository is marked "(main repo)".
+func writeStatusTable(w io.Writer, statuses []WorktreeGitStatus) error {
+	tbl := table.New("BRANCH", "CHANGES", "UPSTREAM", "AHEAD/BEHIND", "PATH")
+	for _, status := range statuses {
+		branch := status.Branch
+		if branch == "" {
+			branch = "(detached)"
+		}
+		if status.IsMain {
+			branch += " (main repo)"
+		}
+
+		upstream, aheadBehind := "-", "-"
+		if status.Upstream != "" {
+			upstream = status.Upstream
+			aheadBehind = fmt.Sprintf("↑%d ↓%d", status.Ahead, status.Behind)
+		}
+
+		tbl.AddRow(branch, describeChanges(status), upstream, aheadBehind, status.Path)
+	}
+	return tbl.Render(w)
+}
+
+// describeChanges summarizes uncommitted changes, e.g. "2 staged, 1 untracked"
+func describeChanges(status WorktreeGitStatus) string {
+	if status.Missing {
+		return "missing"
+	}
+
+	var parts []string
+	for _, count := range []struct {
+		n     int
+		label string
+	}{
+		{status.Conflicted, "conflicted"},
+		{status.Staged, "staged"},
+		{status.Modified, "modified"},
+		{status.Untracked, "untracked"},
+	} {
+		if count.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count.n, count.label))
+		}
+	}
+	if len(parts) == 0 {
+		return "clean"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,87 @@
+package manager
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetWorktreeGitStatuses(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+	if err := os.MkdirAll(origin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, origin, "init", "-q", "-b", "main")
+	runGit(t, origin, "commit", "-q", "--allow-empty", "-m", "init")
+	runGit(t, origin, "branch", "feature")
+
+	repo := filepath.Join(root, "repo")
+	runGit(t, root, "clone", "-q", origin, repo)
+
+	worktree := filepath.Join(root, "repo-worktrees", "feature")
+	runGit(t, repo, "worktree", "add", "-q", worktree, "feature")
+	runGit(t, worktree, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "local 1")
+	runGit(t, worktree, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "local 2")
+	runGit(t, origin, "checkout", "-q", "feature")
+	runGit(t, origin, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "remote")
+	runGit(t, repo, "fetch", "-q")
+
+	for name, content := range map[string]string{"staged.txt": "s", "new.txt": "n"} {
+		if err := os.WriteFile(filepath.Join(worktree, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, worktree, "add", "staged.txt")
+
+	detached := filepath.Join(root, "repo-worktrees", "scratch")
+	runGit(t, repo, "worktree", "add", "-q", "--detach", detached)
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+
+	statuses, err := wm.GetWorktreeGitStatuses()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(statuses) != 3 {
+		t.Fatalf("Expected 3 worktrees, got %+v", statuses)
+	}
+
+	main, feature, detachedStatus := statuses[0], statuses[1], statuses[2]
+	if !main.IsMain || main.Dirty || main.Upstream != "origin/main" || main.Ahead != 0 || main.Behind != 0 {
+		t.Errorf("Unexpected main status: %+v", main)
+	}
+	if feature.Upstream != "origin/feature" || feature.Ahead != 2 || feature.Behind != 1 {
+		t.Errorf("Expected feature to be 2 ahead and 1 behind origin/feature, got %+v", feature)
+	}
+	if !feature.Dirty || feature.Staged != 1 || feature.Untracked != 1 || feature.Modified != 0 {
+		t.Errorf("Expected 1 staged and 1 untracked file, got %+v", feature)
+	}
+	if detachedStatus.Upstream != "" || detachedStatus.Dirty {
+		t.Errorf("Expected a clean detached worktree without upstream, got %+v", detachedStatus)
+	}
+
+	var buf bytes.Buffer
+	if err := writeStatusTable(&buf, statuses); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if got := strings.Join(strings.Fields(lines[2]), " "); got != "main (main repo) clean origin/main ↑0 ↓0 "+repo {
+		t.Errorf("Unexpected main row: %q", got)
+	}
+	if got := strings.Join(strings.Fields(lines[3]), " "); got != "feature 1 staged, 1 untracked origin/feature ↑2 ↓1 "+worktree {
+		t.Errorf("Unexpected feature row: %q", got)
+	}
+	if got := strings.Join(strings.Fields(lines[4]), " "); got != "(detached) clean - - "+detached {
+		t.Errorf("Unexpected detached row: %q", got)
+	}
+}
@@ -0,0 +1,60 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+)
+
+// phaseTiming records how long one step of the worktree workflow took
+type phaseTiming struct {
+	Name     string
+	Duration time.Duration
+	Children []phaseTiming // Optional per-item breakdown (e.g. each copied file)
+}
+
+// workflowTimings collects phase durations for the verbose timing summary
+type workflowTimings struct {
+	start  time.Time
+	phases []phaseTiming
+}
+
+// startTimings begins collecting phase timings for the current workflow
+func (wm *WorktreeManager) startTimings() {
+	wm.timings = &workflowTimings{start: time.Now()}
+}
+
+// record adds a completed phase. It is a no-op when timings are not being collected.
+func (t *workflowTimings) record(name string, start time.Time, children ...phaseTiming) {
+	if t == nil {
+		return
+	}
+	t.phases = append(t.phases, phaseTiming{Name: name, Duration: time.Since(start), Children: children})
+}
+
+// printTimingSummary prints the per-phase timing breakdown and total
+func (wm *WorktreeManager) printTimingSummary() {
+	if wm.timings == nil || len(wm.timings.phases) == 0 {
+		return
+	}
+
+	fmt.Printf("   Timing breakdown:\n")
+	for _, phase := range wm.timings.phases {
+		fmt.Printf("     %-28s %10s\n", phase.Name, formatDuration(phase.Duration))
+		for _, child := range phase.Children {
+			fmt.Printf("       %-26s %10s\n", child.Name, formatDuration(child.Duration))
+		}
+	}
+	fmt.Printf("     %-28s %10s\n", "total", formatDuration(time.Since(wm.timings.start)))
+}
+
+// formatDuration rounds a duration to a readable precision
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(100 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
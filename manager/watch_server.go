@@ -1,12 +1,18 @@
 package manager
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
-	"path/filepath"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/fatih/color"
@@ -15,9 +21,22 @@ import (
 // WatchServerOptions contains configuration for the watch server
 type WatchServerOptions struct {
 	Port         int
+	BindAddress  string // Interface to listen on (default: 127.0.0.1)
+	AuthToken    string // Bearer token required by all endpoints; empty disables auth
 	Interval     time.Duration
 	NotifyMethod string
 	Quiet        bool
+	DryRun       bool // Print notifications instead of sending them
+
+	// StatePath is where conflict state and notification times are persisted so a
+	// restart doesn't re-notify known conflicts. Empty disables persistence.
+	StatePath string
+
+	// NotifyCooldown is the minimum time between notifications for the same branch
+	NotifyCooldown time.Duration
+
+	// Checks selects which branches are checked for conflicts
+	Checks ConflictCheckOptions
 }
 
 // WatchServer monitors worktrees for conflicts
@@ -32,6 +51,7 @@ type WatchServer struct {
 	lastConflicts    []ConflictInfo
 	currentConflicts []ConflictInfo
 	checkCount       int
+	lastNotified     map[string]time.Time // Branch → time of the last notification
 }
 
 // WatchStatus represents the current status of the watch server
@@ -47,34 +67,76 @@ type WatchStatus struct {
 // NewWatchServer creates a new watch server instance
 func NewWatchServer(wm *WorktreeManager, options WatchServerOptions) *WatchServer {
 	return &WatchServer{
-		wm:      wm,
-		options: options,
+		wm:           wm,
+		options:      options,
+		lastNotified: make(map[string]time.Time),
+	}
+}
+
+// loadState restores conflicts and notification times from the state file, starting
+// fresh if it is missing or corrupt
+func (ws *WatchServer) loadState() {
+	if ws.options.StatePath == "" {
+		return
+	}
+
+	state, err := loadWatchState(ws.options.StatePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %v; starting with empty state\n", err)
+	}
+
+	ws.mu.Lock()
+	ws.currentConflicts = state.LastConflicts
+	ws.lastNotified = state.LastNotified
+	ws.mu.Unlock()
+}
+
+// saveState persists the current conflicts and notification times. Dry runs never
+// write state so they can't suppress real notifications later.
+func (ws *WatchServer) saveState() {
+	if ws.options.StatePath == "" || ws.options.DryRun {
+		return
+	}
+
+	ws.mu.RLock()
+	state := &watchState{
+		LastConflicts: ws.currentConflicts,
+		LastNotified:  make(map[string]time.Time, len(ws.lastNotified)),
+	}
+	for branch, t := range ws.lastNotified {
+		state.LastNotified[branch] = t
+	}
+	ws.mu.RUnlock()
+
+	if err := state.save(ws.options.StatePath); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
 	}
 }
 
 // Start starts the watch server
 func (ws *WatchServer) Start(ctx context.Context) error {
 	// Set up HTTP routes
-	mux := http.NewServeMux()
-	mux.HandleFunc("/status", ws.handleStatus)
-	mux.HandleFunc("/worktrees", ws.handleWorktrees)
-	mux.HandleFunc("/conflicts", ws.handleConflicts)
-	mux.HandleFunc("/check", ws.handleCheck)
-
 	ws.server = &http.Server{
-		Addr:    fmt.Sprintf(":%d", ws.options.Port),
-		Handler: mux,
+		Addr:    ws.Addr(),
+		Handler: ws.handler(),
+	}
+
+	// Listen before anything else so a port in use fails the command
+	listener, err := net.Listen("tcp", ws.server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w\n\nTo fix this:\n  • Stop whatever is using the port, or choose another with --port or watch.port", ws.server.Addr, err)
 	}
 
+	// Restore state from a previous run before the first check
+	ws.loadState()
+
 	// Start the periodic checker
 	go ws.runPeriodicCheck(ctx)
 
 	// Start the HTTP server
 	go func() {
-		if err := ws.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			if !ws.options.Quiet {
-				fmt.Printf("❌ HTTP server error: %v\n", err)
-			}
+		if err := ws.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			fmt.Fprintf(os.Stderr, "❌ HTTP server error: %v\n", err)
 		}
 	}()
 
@@ -88,6 +150,69 @@ func (ws *WatchServer) Start(ctx context.Context) error {
 	return ws.server.Shutdown(shutdownCtx)
 }
 
+// Addr returns the address the server listens on
+func (ws *WatchServer) Addr() string {
+	host := ws.options.BindAddress
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, strconv.Itoa(ws.options.Port))
+}
+
+// ValidateBindAddress checks that addr is an IP address or "localhost". Ports are
+// configured separately, so host:port values are rejected.
+func ValidateBindAddress(addr string) error {
+	if addr == "localhost" || net.ParseIP(addr) != nil {
+		return nil
+	}
+	return fmt.Errorf("invalid bind address '%s'\n\nTo fix this:\n  • Use an IP address such as 127.0.0.1 (local only) or 0.0.0.0 (all interfaces)\n  • Set the port separately with --port or watch.port", addr)
+}
+
+// WatchEndpoint describes a route of the watch server's HTTP API
+type WatchEndpoint struct {
+	Method      string
+	Path        string
+	Description string
+}
+
+// WatchEndpoints lists the routes served by the watch server
+var WatchEndpoints = []WatchEndpoint{
+	{http.MethodGet, "/status", "Server status, last check and current conflicts"},
+	{http.MethodGet, "/worktrees", "Worktrees being monitored"},
+	{http.MethodGet, "/conflicts", "Branches that would conflict rebasing on main"},
+	{http.MethodPost, "/check", "Run a conflict check now"},
+}
+
+// NotifyMethods are the accepted values of WatchServerOptions.NotifyMethod
+var NotifyMethods = []string{"system", "webhook", "both"}
+
+// handler returns the HTTP routes, wrapped in token auth when configured
+func (ws *WatchServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", ws.handleStatus)
+	mux.HandleFunc("/worktrees", ws.handleWorktrees)
+	mux.HandleFunc("/conflicts", ws.handleConflicts)
+	mux.HandleFunc("/check", ws.handleCheck)
+	return ws.requireAuth(mux)
+}
+
+// requireAuth rejects requests without the configured bearer token
+func (ws *WatchServer) requireAuth(next http.Handler) http.Handler {
+	if ws.options.AuthToken == "" {
+		return next
+	}
+
+	expected := []byte("Bearer " + ws.options.AuthToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="workie"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // runPeriodicCheck runs the conflict check periodically
 func (ws *WatchServer) runPeriodicCheck(ctx context.Context) {
 	// Run initial check
@@ -117,11 +242,9 @@ func (ws *WatchServer) performCheck() {
 		fmt.Printf("\n🔍 Running conflict check #%d at %s\n", checkNum, time.Now().Format("15:04:05"))
 	}
 
-	conflicts, err := ws.wm.CheckRebaseConflicts()
+	conflicts, err := ws.wm.CheckRebaseConflictsWithOptions(ws.options.Checks)
 	if err != nil {
-		if !ws.options.Quiet {
-			fmt.Printf("❌ Error checking conflicts: %v\n", err)
-		}
+		fmt.Fprintf(os.Stderr, "❌ Error checking conflicts: %v\n", err)
 		return
 	}
 
@@ -135,6 +258,7 @@ func (ws *WatchServer) performCheck() {
 	if HasNewConflicts(ws.lastConflicts, conflicts) {
 		ws.notifyConflicts(conflicts)
 	}
+	ws.saveState()
 
 	if !ws.options.Quiet {
 		if len(conflicts) == 0 {
@@ -154,6 +278,9 @@ func (ws *WatchServer) performCheck() {
 func (ws *WatchServer) notifyConflicts(conflicts []ConflictInfo) {
 	// Check if notifications are enabled in config
 	if ws.wm.Config != nil && ws.wm.Config.Watch != nil && !ws.wm.Config.Watch.NotifyOnConflicts {
+		if ws.options.DryRun {
+			fmt.Printf("🧪 [dry-run] Notifications disabled (watch.notify_on_conflicts is false); nothing would be sent\n")
+		}
 		return
 	}
 
@@ -164,26 +291,42 @@ func (ws *WatchServer) notifyConflicts(conflicts []ConflictInfo) {
 
 		// Check if branch should be ignored
 		if ws.shouldIgnoreBranch(conflict.Branch) {
+			if ws.options.DryRun {
+				fmt.Printf("🧪 [dry-run] Skipping %s (matches watch.branches_to_ignore)\n", conflict.Branch)
+			}
+			continue
+		}
+
+		// Respect the per-branch cooldown
+		if remaining := ws.cooldownRemaining(conflict.Branch); remaining > 0 {
+			if ws.options.DryRun {
+				fmt.Printf("🧪 [dry-run] Skipping %s (notified recently, cooldown ends in %s)\n", conflict.Branch, remaining.Round(time.Second))
+			}
 			continue
 		}
 
 		// Build notification message
-		message := fmt.Sprintf("⚠️ Workie: Branch '%s' would conflict rebasing on main", conflict.Branch)
-		if len(conflict.ConflictFiles) > 0 {
-			message += fmt.Sprintf(" (%d files)", len(conflict.ConflictFiles))
+		message := ws.conflictMessage(conflict)
+
+		if ws.options.DryRun {
+			ws.printDryRunNotification(message)
+			continue
 		}
 
-		// Send notification based on method
+		// Send notification based on method. Only a notification that was sent
+		// starts the cooldown.
 		if ws.options.NotifyMethod == "system" || ws.options.NotifyMethod == "both" {
+			ws.mu.Lock()
+			ws.lastNotified[conflict.Branch] = time.Now()
+			ws.mu.Unlock()
+
 			input := &NotificationInput{
 				Message:       message,
 				HookEventName: "workie_watch_conflict",
 			}
 
 			if err := ws.wm.SendSystemNotification(input); err != nil {
-				if !ws.options.Quiet {
-					fmt.Printf("❌ Failed to send notification: %v\n", err)
-				}
+				fmt.Fprintf(os.Stderr, "❌ Failed to send notification: %v\n", err)
 			}
 		}
 
@@ -191,20 +334,96 @@ func (ws *WatchServer) notifyConflicts(conflicts []ConflictInfo) {
 	}
 }
 
-// shouldIgnoreBranch checks if a branch should be ignored based on config patterns
-func (ws *WatchServer) shouldIgnoreBranch(branch string) bool {
-	if ws.wm.Config == nil || ws.wm.Config.Watch == nil {
-		return false
+// cooldownRemaining returns how long until the branch may be notified again
+func (ws *WatchServer) cooldownRemaining(branch string) time.Duration {
+	if ws.options.NotifyCooldown <= 0 {
+		return 0
+	}
+
+	ws.mu.RLock()
+	last, ok := ws.lastNotified[branch]
+	ws.mu.RUnlock()
+	if !ok {
+		return 0
+	}
+	return ws.options.NotifyCooldown - time.Since(last)
+}
+
+// printDryRunNotification shows the notification that would be sent by each backend
+func (ws *WatchServer) printDryRunNotification(message string) {
+	recipients := map[string]string{
+		"system":  "desktop notification on this machine",
+		"webhook": "not yet supported, nothing would be sent",
 	}
 
-	for _, pattern := range ws.wm.Config.Watch.BranchesToIgnore {
-		// Simple glob pattern matching
-		if matched, _ := filepath.Match(pattern, branch); matched {
-			return true
+	fmt.Printf("🧪 [dry-run] Would notify: %s\n", message)
+	for _, backend := range notifyBackends(ws.options.NotifyMethod) {
+		fmt.Printf("     backend: %s (%s)\n", backend, recipients[backend])
+	}
+}
+
+// notifyBackends returns the notification backends selected by a notify method
+func notifyBackends(method string) []string {
+	switch method {
+	case "both":
+		return []string{"system", "webhook"}
+	case "system", "webhook":
+		return []string{method}
+	default:
+		return nil
+	}
+}
+
+// DefaultConflictMessageTemplate is used when watch.conflict_message_template is not set
+const DefaultConflictMessageTemplate = "⚠️ Workie: Branch '{{.Branch}}' would conflict rebasing on main{{if .FileCount}} ({{.FileCount}} files){{end}}"
+
+// ConflictMessageData is the data available to conflict message templates
+type ConflictMessageData struct {
+	Branch    string   // Branch that would conflict
+	Files     []string // Conflicting files
+	FileCount int      // Number of conflicting files
+	Repo      string   // Repository name
+}
+
+// conflictMessage renders the notification text for a conflict, falling back to the
+// default template if the configured one is invalid
+func (ws *WatchServer) conflictMessage(conflict ConflictInfo) string {
+	data := ConflictMessageData{
+		Branch:    conflict.Branch,
+		Files:     conflict.ConflictFiles,
+		FileCount: len(conflict.ConflictFiles),
+		Repo:      ws.wm.RepoName,
+	}
+
+	if cfg := ws.wm.Config; cfg != nil && cfg.Watch != nil && cfg.Watch.ConflictMessageTemplate != "" {
+		message, err := RenderConflictMessage(cfg.Watch.ConflictMessageTemplate, data)
+		if err == nil {
+			return message
 		}
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: Invalid conflict_message_template (%v), using default message\n", err)
+	}
+
+	message, _ := RenderConflictMessage(DefaultConflictMessageTemplate, data)
+	return message
+}
+
+// RenderConflictMessage renders a conflict message template with the given data
+func RenderConflictMessage(text string, data ConflictMessageData) (string, error) {
+	tmpl, err := template.New("conflict_message_template").Funcs(template.FuncMap{"join": strings.Join}).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
 	}
+	return strings.TrimSpace(buf.String()), nil
+}
 
-	return false
+// shouldIgnoreBranch checks if a branch should be ignored based on config patterns
+func (ws *WatchServer) shouldIgnoreBranch(branch string) bool {
+	return ws.wm.IgnoresBranch(branch)
 }
 
 // HTTP Handlers
@@ -0,0 +1,83 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// watchState is the watch server state persisted between restarts
+type watchState struct {
+	LastConflicts []ConflictInfo       `json:"last_conflicts"`
+	LastNotified  map[string]time.Time `json:"last_notified"` // Branch → time of the last notification
+}
+
+// DefaultWatchStatePath returns the default watch state file, stored in the git
+// directory so it is shared by all worktrees and never committed
+func (wm *WorktreeManager) DefaultWatchStatePath() string {
+	return filepath.Join(wm.gitCommonDir(), "workie", "watch-state.json")
+}
+
+// gitCommonDir returns the repository's git directory, shared by all worktrees
+func (wm *WorktreeManager) gitCommonDir() string {
+	cmd := exec.Command("git", "rev-parse", "--git-common-dir")
+	cmd.Dir = wm.RepoPath
+
+	gitDir := ".git"
+	if output, err := cmd.Output(); err == nil && strings.TrimSpace(string(output)) != "" {
+		gitDir = strings.TrimSpace(string(output))
+	}
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(wm.RepoPath, gitDir)
+	}
+	return gitDir
+}
+
+// loadWatchState reads the state file. A missing file yields empty state; a corrupt
+// file yields empty state and an error so the caller can warn and start fresh.
+func loadWatchState(path string) (*watchState, error) {
+	state := &watchState{LastNotified: make(map[string]time.Time)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, fmt.Errorf("failed to read watch state %s: %w", path, err)
+	}
+
+	var loaded watchState
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return state, fmt.Errorf("corrupt watch state %s: %w", path, err)
+	}
+	if loaded.LastNotified == nil {
+		loaded.LastNotified = make(map[string]time.Time)
+	}
+	return &loaded, nil
+}
+
+// save writes the state atomically so a crash mid-write can't corrupt the file
+func (s *watchState) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create watch state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode watch state: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write watch state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write watch state: %w", err)
+	}
+	return nil
+}
@@ -1,8 +1,18 @@
 package manager
 
 import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/agoodway/workie/config"
+	"github.com/agoodway/workie/errcode"
 )
 
 func TestWatchServerOptions(t *testing.T) {
@@ -86,3 +96,460 @@ CONFLICT (rename): Merge conflict in old.txt`
 		}
 	}
 }
+
+func TestConflictMessage(t *testing.T) {
+	conflict := ConflictInfo{
+		Branch:        "feature/test",
+		ConflictFiles: []string{"a.go", "b.go"},
+	}
+
+	t.Run("default template", func(t *testing.T) {
+		wm := New()
+		ws := NewWatchServer(wm, WatchServerOptions{Quiet: true})
+
+		want := "⚠️ Workie: Branch 'feature/test' would conflict rebasing on main (2 files)"
+		if got := ws.conflictMessage(conflict); got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("custom template", func(t *testing.T) {
+		wm := New()
+		wm.RepoName = "workie"
+		wm.Config = &config.Config{Watch: &config.WatchConfig{
+			ConflictMessageTemplate: `[{{.Repo}}] {{.Branch}} conflicts in {{.FileCount}} files: {{join .Files ", "}}`,
+		}}
+		ws := NewWatchServer(wm, WatchServerOptions{Quiet: true})
+
+		want := "[workie] feature/test conflicts in 2 files: a.go, b.go"
+		if got := ws.conflictMessage(conflict); got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("invalid template falls back to default", func(t *testing.T) {
+		wm := New()
+		wm.Config = &config.Config{Watch: &config.WatchConfig{ConflictMessageTemplate: "{{.Nope}}"}}
+		ws := NewWatchServer(wm, WatchServerOptions{Quiet: true})
+
+		if got := ws.conflictMessage(conflict); !strings.HasPrefix(got, "⚠️ Workie: Branch 'feature/test'") {
+			t.Errorf("Expected default message, got %q", got)
+		}
+	})
+}
+
+func TestNotifyBackends(t *testing.T) {
+	tests := map[string][]string{
+		"system":  {"system"},
+		"webhook": {"webhook"},
+		"both":    {"system", "webhook"},
+		"bogus":   nil,
+	}
+	for method, want := range tests {
+		if got := notifyBackends(method); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("notifyBackends(%q) = %v, want %v", method, got, want)
+		}
+	}
+}
+
+func TestWatchStatePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workie", "watch-state.json")
+	conflicts := []ConflictInfo{{Branch: "feature/a", ConflictFiles: []string{"a.go"}}}
+
+	// Missing file yields empty state
+	state, err := loadWatchState(path)
+	if err != nil || len(state.LastConflicts) != 0 || state.LastNotified == nil {
+		t.Fatalf("Expected empty state for missing file, got %+v, err=%v", state, err)
+	}
+
+	ws := NewWatchServer(New(), WatchServerOptions{Quiet: true, StatePath: path, NotifyCooldown: time.Hour})
+	ws.currentConflicts = conflicts
+	ws.lastNotified["feature/a"] = time.Now()
+	ws.saveState()
+
+	// A restarted server knows the conflicts and respects the cooldown
+	restarted := NewWatchServer(New(), WatchServerOptions{Quiet: true, StatePath: path, NotifyCooldown: time.Hour})
+	restarted.loadState()
+	if HasNewConflicts(restarted.currentConflicts, conflicts) {
+		t.Error("Expected persisted conflicts not to count as new after restart")
+	}
+	if restarted.cooldownRemaining("feature/a") <= 0 {
+		t.Error("Expected cooldown to carry over after restart")
+	}
+	if restarted.cooldownRemaining("feature/b") != 0 {
+		t.Error("Expected no cooldown for a branch that was never notified")
+	}
+
+	// Dry runs never write state
+	dry := NewWatchServer(New(), WatchServerOptions{Quiet: true, StatePath: path, DryRun: true})
+	dry.saveState()
+	if state, _ := loadWatchState(path); len(state.LastConflicts) != 1 {
+		t.Error("Expected dry run to leave the state file untouched")
+	}
+
+	// Corrupt file yields empty state and an error
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	state, err = loadWatchState(path)
+	if err == nil || len(state.LastConflicts) != 0 {
+		t.Errorf("Expected error and empty state for corrupt file, got %+v, err=%v", state, err)
+	}
+}
+
+func TestWatchServerAuth(t *testing.T) {
+	ws := NewWatchServer(New(), WatchServerOptions{Quiet: true, AuthToken: "s3cret", Interval: time.Minute})
+	handler := ws.handler()
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"missing token", "", http.StatusUnauthorized},
+		{"wrong token", "Bearer nope", http.StatusUnauthorized},
+		{"valid token", "Bearer s3cret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/conflicts", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("Expected status %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+
+	t.Run("no token configured", func(t *testing.T) {
+		open := NewWatchServer(New(), WatchServerOptions{Quiet: true, Interval: time.Minute})
+		rec := httptest.NewRecorder()
+		open.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200 without auth configured, got %d", rec.Code)
+		}
+	})
+}
+
+func TestWatchEndpoints(t *testing.T) {
+	handler := NewWatchServer(New(), WatchServerOptions{Quiet: true, Interval: time.Minute}).handler()
+
+	// Every listed route exists: the wrong method is refused rather than not found
+	for _, endpoint := range WatchEndpoints {
+		method := http.MethodPost
+		if endpoint.Method == http.MethodPost {
+			method = http.MethodGet
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, endpoint.Path, nil))
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %s %s to be refused with 405, got %d", method, endpoint.Path, rec.Code)
+		}
+	}
+}
+
+func TestWatchServerPortInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	ws := NewWatchServer(New(), WatchServerOptions{Quiet: true, Port: listener.Addr().(*net.TCPAddr).Port, Interval: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ws.Start(ctx); err == nil || !strings.Contains(err.Error(), "failed to listen") {
+		t.Errorf("Expected Start to fail on a port in use, got: %v", err)
+	}
+}
+
+func TestWatchServerAddr(t *testing.T) {
+	if got := NewWatchServer(New(), WatchServerOptions{Port: 8080}).Addr(); got != "127.0.0.1:8080" {
+		t.Errorf("Expected default bind to 127.0.0.1:8080, got %s", got)
+	}
+	if got := NewWatchServer(New(), WatchServerOptions{Port: 9000, BindAddress: "::1"}).Addr(); got != "[::1]:9000" {
+		t.Errorf("Expected [::1]:9000, got %s", got)
+	}
+}
+
+func TestValidateBindAddress(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "0.0.0.0", "::1", "localhost", "192.168.1.10"} {
+		if err := ValidateBindAddress(addr); err != nil {
+			t.Errorf("Expected %q to be valid, got: %v", addr, err)
+		}
+	}
+	for _, addr := range []string{"", "127.0.0.1:8080", "example.com", "999.1.1.1"} {
+		if err := ValidateBindAddress(addr); err == nil {
+			t.Errorf("Expected %q to be rejected", addr)
+		}
+	}
+}
+
+func TestParseGitVersion(t *testing.T) {
+	tests := []struct {
+		output       string
+		major, minor int
+		ok           bool
+	}{
+		{"git version 2.39.5\n", 2, 39, true},
+		{"git version 2.37.1 (Apple Git-137.1)", 2, 37, true},
+		{"git version 2.40.0.windows.1", 2, 40, true},
+		{"not git", 0, 0, false},
+	}
+	for _, tt := range tests {
+		major, minor, ok := parseGitVersion(tt.output)
+		if major != tt.major || minor != tt.minor || ok != tt.ok {
+			t.Errorf("parseGitVersion(%q) = %d, %d, %v; want %d, %d, %v", tt.output, major, minor, ok, tt.major, tt.minor, tt.ok)
+		}
+	}
+}
+
+func TestParseLegacyMergeTree(t *testing.T) {
+	output := `changed in both
+  base   100644 de98044 f.txt
+  our    100644 af70335 f.txt
+  their  100644 ac05874 f.txt
+@@ -1,3 +1,7 @@
+ a
++<<<<<<< .our
+ MAIN
++=======
++FEAT
++>>>>>>> .their
+ c
+changed in both
+  base   100644 1111111 clean.txt
+  our    100644 2222222 clean.txt
+  their  100644 3333333 clean.txt
+@@ -1,2 +1,2 @@
+-one
++two
+added in both
+  our    100644 1a78173 dir/new file.txt
+  their  100644 975fbec dir/new file.txt
+@@ -1 +1,5 @@
++<<<<<<< .our
+ y2
++=======
++y
++>>>>>>> .their
+`
+	files := parseLegacyMergeTree(output)
+	want := []string{"f.txt", "dir/new file.txt"}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected conflicted files %v, got %v", want, files)
+	}
+}
+
+func TestMergeTreeConflicts(t *testing.T) {
+	repo := t.TempDir()
+	runGit(t, repo, "init", "--quiet", "--initial-branch=main")
+	if err := os.WriteFile(filepath.Join(repo, "f.txt"), []byte("a\nb\nc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "--quiet", "-m", "base")
+
+	runGit(t, repo, "checkout", "--quiet", "-b", "feature")
+	if err := os.WriteFile(filepath.Join(repo, "f.txt"), []byte("a\nfeature\nc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "commit", "--quiet", "-am", "feature")
+	runGit(t, repo, "branch", "clean", "main")
+
+	runGit(t, repo, "checkout", "--quiet", "main")
+	if err := os.WriteFile(filepath.Join(repo, "f.txt"), []byte("a\nmain\nc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "commit", "--quiet", "-am", "main")
+
+	checks := map[string]func(dir, base, branch string) ([]string, error){
+		"legacy": legacyMergeTreeConflicts,
+	}
+	wm := New()
+	wm.Options.Quiet = true
+	if wm.supportsWriteTree() {
+		checks["write-tree"] = mergeTreeConflicts
+	}
+
+	for name, check := range checks {
+		t.Run(name, func(t *testing.T) {
+			files, err := check(repo, "main", "feature")
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(files) != 1 || files[0] != "f.txt" {
+				t.Errorf("Expected f.txt to conflict, got %v", files)
+			}
+
+			files, err = check(repo, "main", "clean")
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(files) != 0 {
+				t.Errorf("Expected no conflicts, got %v", files)
+			}
+		})
+	}
+}
+
+func TestCheckRebaseConflictsWithOptions(t *testing.T) {
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin.git")
+	repo := filepath.Join(root, "repo")
+
+	writeFile := func(dir, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "f.txt"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runGit(t, root, "init", "--quiet", "--bare", "--initial-branch=main", origin)
+	runGit(t, root, "clone", "--quiet", origin, repo)
+	runGit(t, repo, "checkout", "--quiet", "-B", "main")
+	writeFile(repo, "base\n")
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "--quiet", "-m", "base")
+
+	for _, branch := range []string{"feature/a", "fix/b"} {
+		wtPath := filepath.Join(root, "worktrees", filepath.Base(branch))
+		runGit(t, repo, "worktree", "add", "--quiet", "-b", branch, wtPath)
+		writeFile(wtPath, branch+"\n")
+		runGit(t, wtPath, "commit", "--quiet", "-am", branch)
+	}
+
+	// origin/main gets one change while local main diverges with another
+	writeFile(repo, "upstream\n")
+	runGit(t, repo, "commit", "--quiet", "-am", "upstream")
+	runGit(t, repo, "push", "--quiet", "origin", "main")
+	runGit(t, repo, "reset", "--quiet", "--hard", "HEAD~1")
+	writeFile(repo, "local\n")
+	runGit(t, repo, "commit", "--quiet", "-am", "local")
+
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.Config = &config.Config{}
+
+	branches := func(conflicts []ConflictInfo) string {
+		var names []string
+		for _, c := range conflicts {
+			names = append(names, c.Branch)
+		}
+		return strings.Join(names, ",")
+	}
+
+	tests := []struct {
+		name string
+		opts ConflictCheckOptions
+		want string
+	}{
+		{"default skips main", ConflictCheckOptions{}, "feature/a,fix/b"},
+		{"include main", ConflictCheckOptions{IncludeMain: true}, "main,feature/a,fix/b"},
+		{"branch filter", ConflictCheckOptions{Branches: []string{"feature/*"}}, "feature/a"},
+		{"filter applies to main", ConflictCheckOptions{IncludeMain: true, Branches: []string{"fix/*"}}, "fix/b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts, err := wm.CheckRebaseConflictsWithOptions(tt.opts)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if got := branches(conflicts); got != tt.want {
+				t.Errorf("Expected conflicts on %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := wm.CheckRebaseConflictsWithOptions(ConflictCheckOptions{Branches: []string{"[bad"}}); err == nil {
+		t.Error("Expected error for an invalid branch pattern")
+	}
+}
+
+func TestGetMainBranchDevelop(t *testing.T) {
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin.git")
+	seed := filepath.Join(root, "seed")
+	repo := filepath.Join(root, "repo")
+
+	writeFile := func(dir, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "f.txt"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// origin's default branch is develop
+	runGit(t, root, "init", "--quiet", "--bare", "--initial-branch=develop", origin)
+	runGit(t, root, "clone", "--quiet", origin, seed)
+	runGit(t, seed, "checkout", "--quiet", "-B", "develop")
+	writeFile(seed, "base\n")
+	runGit(t, seed, "add", ".")
+	runGit(t, seed, "commit", "--quiet", "-m", "base")
+	runGit(t, seed, "push", "--quiet", "origin", "develop")
+
+	runGit(t, root, "clone", "--quiet", origin, repo)
+	wm := New()
+	wm.Options.Quiet = true
+	wm.RepoPath = repo
+	wm.Config = &config.Config{}
+
+	branch, err := wm.GetMainBranch()
+	if err != nil || branch != "develop" {
+		t.Fatalf("Expected develop from origin/HEAD, got %q, %v", branch, err)
+	}
+
+	// A branch that conflicts with a later change on origin/develop
+	wtPath := filepath.Join(root, "worktrees", "feature")
+	runGit(t, repo, "worktree", "add", "--quiet", "-b", "feature", wtPath)
+	writeFile(wtPath, "feature\n")
+	runGit(t, wtPath, "commit", "--quiet", "-am", "feature")
+	writeFile(seed, "upstream\n")
+	runGit(t, seed, "commit", "--quiet", "-am", "upstream")
+	runGit(t, seed, "push", "--quiet", "origin", "develop")
+
+	// Without origin/HEAD there is nothing to go by, once the detected branch is forgotten
+	runGit(t, repo, "remote", "set-head", "origin", "--delete")
+	wm.ClearRepoCache()
+	if branch, err := wm.GetMainBranch(); err == nil {
+		t.Fatalf("Expected an error without main, master or origin/HEAD, got %q", branch)
+	} else if errcode.Of(err) != errcode.BranchNotFound {
+		t.Errorf("Expected %s, got %s", errcode.BranchNotFound, errcode.Of(err))
+	}
+	if _, err := wm.CheckRebaseConflicts(); err == nil || !strings.Contains(err.Error(), "could not determine the main branch") {
+		t.Errorf("Expected the conflict check to report the missing main branch, got: %v", err)
+	}
+
+	wm.Config.Watch = &config.WatchConfig{MainBranch: "develop"}
+	conflicts, err := wm.CheckRebaseConflicts()
+	if err != nil {
+		t.Fatalf("Expected no error with watch.main_branch, got: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Branch != "feature" || conflicts[0].Error != "" {
+		t.Errorf("Expected feature to conflict with origin/develop, got %+v", conflicts)
+	}
+}
+
+func TestIgnoresBranch(t *testing.T) {
+	wm := New()
+	if wm.IgnoresBranch("wip/x") {
+		t.Error("Expected no branches to be ignored without configuration")
+	}
+
+	wm.Config = &config.Config{Watch: &config.WatchConfig{BranchesToIgnore: []string{"wip/*", "dependabot-*"}}}
+	for branch, want := range map[string]bool{
+		"wip/x":              true,
+		"dependabot-npm":     true,
+		"feature/wip":        false,
+		"wip/nested/feature": false, // * does not cross slashes
+	} {
+		if got := wm.IgnoresBranch(branch); got != want {
+			t.Errorf("IgnoresBranch(%s) = %v, want %v", branch, got, want)
+		}
+	}
+}
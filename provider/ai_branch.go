@@ -11,6 +11,14 @@ import (
 // AIBranchNameGenerator generates branch names using AI
 type AIBranchNameGenerator struct {
 	llm llms.Model
+
+	// Strict makes unusable model responses an error instead of falling back to
+	// the title-based branch name
+	Strict bool
+
+	// Options formats the issue ID and suffix as the provider's CreateBranchName
+	// does (branch_name settings)
+	Options BranchNameOptions
 }
 
 // NewAIBranchNameGenerator creates a new AI-powered branch name generator
@@ -32,21 +40,23 @@ func (g *AIBranchNameGenerator) GenerateBranchName(issue *Issue, branchPrefix st
 		return "", fmt.Errorf("AI model error: %w", err)
 	}
 
-	// Extract and clean the branch name from the response
-	branchName := strings.TrimSpace(response)
-	branchName = strings.Trim(branchName, "`\"'")
-
-	// Validate the generated branch name
-	if !strings.HasPrefix(branchName, branchPrefix) {
-		// If AI didn't include the prefix, add it
-		branchName = fmt.Sprintf("%s%s-%s", branchPrefix, strings.ToLower(issue.ID), branchName)
+	// Only the descriptive suffix is taken from the model; the prefix and issue ID
+	// are applied here exactly once so they can't be duplicated or sanitized away
+	suffix := extractBranchSuffix(response, branchPrefix, issue.ID, g.Options)
+	if suffix == "" {
+		if g.Strict {
+			return "", fmt.Errorf("AI model returned no usable branch name: %q", strings.TrimSpace(response))
+		}
+		return g.fallbackBranchName(issue, branchPrefix), nil
 	}
 
-	// Ensure it's properly sanitized
-	branchName = SanitizeBranchName(branchName)
+	branchName := joinBranchName(branchPrefix, issue.ID, suffix, g.Options)
 
 	// Final validation
 	if len(branchName) > 63 {
+		if g.Strict {
+			return "", fmt.Errorf("AI-generated branch name is longer than 63 characters: %s", branchName)
+		}
 		// Fallback to traditional method if AI generates too long name
 		return g.fallbackBranchName(issue, branchPrefix), nil
 	}
@@ -54,11 +64,42 @@ func (g *AIBranchNameGenerator) GenerateBranchName(issue *Issue, branchPrefix st
 	return branchName, nil
 }
 
+// extractBranchSuffix reduces a model response to a descriptive suffix sanitized
+// with opts. The model may answer with a bare suffix, the full branch name, or a
+// name using a different prefix, so any prefix and leading issue ID are stripped first.
+func extractBranchSuffix(response, branchPrefix, issueID string, opts BranchNameOptions) string {
+	name := strings.TrimSpace(response)
+	if i := strings.IndexByte(name, '\n'); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.TrimSpace(strings.Trim(name, "`\"'"))
+
+	// Drop the expected prefix, or whatever prefix the model chose instead
+	if branchPrefix != "" && strings.HasPrefix(name, branchPrefix) {
+		name = strings.TrimPrefix(name, branchPrefix)
+	} else if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	// Drop the issue ID so it isn't repeated after the prefix
+	name = strings.TrimPrefix(name, "#")
+	if id := strings.ToLower(issueID); id != "" && strings.HasPrefix(strings.ToLower(name), id) {
+		rest := name[len(id):]
+		if rest == "" || strings.HasPrefix(rest, "-") || strings.HasPrefix(rest, opts.separator()) {
+			name = rest
+		}
+	}
+
+	return SanitizeBranchNameWithOptions(name, opts.SanitizeOptions)
+}
+
 // buildPrompt creates the AI prompt for branch name generation
 func (g *AIBranchNameGenerator) buildPrompt(issue *Issue, branchPrefix string) string {
 	// Prepare issue context
-	issueContext := fmt.Sprintf("Issue ID: %s\nType: %s\nTitle: %s",
-		issue.ID, issue.Type, issue.Title)
+	issueContext := fmt.Sprintf("Type: %s\nTitle: %s", issue.Type, issue.Title)
+	if issue.ID != "" {
+		issueContext = fmt.Sprintf("Issue ID: %s\n%s", issue.ID, issueContext)
+	}
 
 	if issue.Description != "" {
 		// Limit description length
@@ -78,7 +119,7 @@ func (g *AIBranchNameGenerator) buildPrompt(issue *Issue, branchPrefix string) s
 %s
 
 Requirements:
-1. Format: %s%s-{descriptive-suffix}
+1. Format: %s{descriptive-suffix}
 2. The descriptive suffix should be 2-5 words that capture the essence of the work
 3. Use only lowercase letters and hyphens
 4. Make it concise but descriptive
@@ -91,30 +132,30 @@ Examples:
 - Issue: "Refactor database connection pooling for better performance" → task/789-refactor-db-pooling
 - Issue: "Update user documentation for API v2" → docs/101-api-v2-docs
 
-Generate ONLY the branch name, nothing else:`, issueContext, branchPrefix, strings.ToLower(issue.ID))
+Generate ONLY the branch name, nothing else:`, issueContext, joinBranchName(branchPrefix, issue.ID, "", g.Options))
 }
 
 // fallbackBranchName generates a branch name using the traditional method
 func (g *AIBranchNameGenerator) fallbackBranchName(issue *Issue, branchPrefix string) string {
-	suffix := SanitizeBranchName(issue.Title)
+	sep := g.Options.separator()
+	suffix := SanitizeBranchNameWithOptions(issue.Title, g.Options.SanitizeOptions)
 
 	// Truncate suffix to keep it concise
-	words := strings.Split(suffix, "-")
+	words := strings.Split(suffix, sep)
 	if len(words) > 5 {
 		words = words[:5]
 	}
-	suffix = strings.Join(words, "-")
+	suffix = strings.Join(words, sep)
 
-	branchName := fmt.Sprintf("%s%s-%s", branchPrefix, strings.ToLower(issue.ID), suffix)
+	branchName := joinBranchName(branchPrefix, issue.ID, suffix, g.Options)
 
 	// Ensure total length doesn't exceed 63 characters
 	if len(branchName) > 63 {
-		prefixLen := len(branchPrefix) + len(issue.ID) + 1
-		maxSuffixLen := 63 - prefixLen
+		maxSuffixLen := 63 - (len(branchName) - len(suffix))
 		if maxSuffixLen > 0 && len(suffix) > maxSuffixLen {
 			suffix = suffix[:maxSuffixLen]
-			suffix = strings.TrimSuffix(suffix, "-")
-			branchName = fmt.Sprintf("%s%s-%s", branchPrefix, strings.ToLower(issue.ID), suffix)
+			suffix = strings.TrimSuffix(suffix, sep)
+			branchName = joinBranchName(branchPrefix, issue.ID, suffix, g.Options)
 		}
 	}
 
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// fixedLLM always answers with the same response
+type fixedLLM struct {
+	response string
+}
+
+func (m *fixedLLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *fixedLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return m.response, nil
+}
+
+func TestAIBranchNameGenerator(t *testing.T) {
+	issue := &Issue{ID: "123", Title: "Users can't login with special characters"}
+
+	tests := []struct {
+		name     string
+		response string
+		want     string
+	}{
+		{"bare suffix", "password-special-chars", "fix/123-password-special-chars"},
+		{"full branch name", "fix/123-password-special-chars", "fix/123-password-special-chars"},
+		{"wrong prefix", "feat/123-password-special-chars", "fix/123-password-special-chars"},
+		{"wrong prefix without id", "bugfix/password-special-chars", "fix/123-password-special-chars"},
+		{"quoted with trailing explanation", "`fix/123-password-special-chars`\nThis name describes the fix.", "fix/123-password-special-chars"},
+		{"suffix starting with id digits", "1234-migration", "fix/123-1234-migration"},
+		{"empty response falls back to title", "  ", "fix/123-users-can-t-login-with"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewAIBranchNameGenerator(&fixedLLM{response: tt.response})
+			got, err := generator.GenerateBranchName(issue, "fix/")
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateBranchName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("alphanumeric issue ID", func(t *testing.T) {
+		generator := NewAIBranchNameGenerator(&fixedLLM{response: "feature/PROJ-456-dark-mode"})
+		got, err := generator.GenerateBranchName(&Issue{ID: "PROJ-456", Title: "Dark mode"}, "feat/")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if got != "feat/proj-456-dark-mode" {
+			t.Errorf("GenerateBranchName() = %q, want %q", got, "feat/proj-456-dark-mode")
+		}
+	})
+	t.Run("branch name options", func(t *testing.T) {
+		opts := BranchNameOptions{SanitizeOptions: SanitizeOptions{Separator: "_"}, PreserveIDCase: true}
+		for response, want := range map[string]string{
+			"feature/PROJ-456_dark_mode": "feat/PROJ-456_dark_mode",
+			"dark-mode":                  "feat/PROJ-456_dark_mode",
+			"  ":                         "feat/PROJ-456_dark_mode_toggle",
+		} {
+			generator := NewAIBranchNameGenerator(&fixedLLM{response: response})
+			generator.Options = opts
+			got, err := generator.GenerateBranchName(&Issue{ID: "PROJ-456", Title: "Dark mode toggle"}, "feat/")
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if got != want {
+				t.Errorf("GenerateBranchName(%q) = %q, want %q", response, got, want)
+			}
+		}
+	})
+	t.Run("issue without ID", func(t *testing.T) {
+		generator := NewAIBranchNameGenerator(&fixedLLM{response: "fix/password-special-chars"})
+		got, err := generator.GenerateBranchName(&Issue{Title: "Users can't login"}, "fix/")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if got != "fix/password-special-chars" {
+			t.Errorf("GenerateBranchName() = %q, want %q", got, "fix/password-special-chars")
+		}
+	})
+	t.Run("strict mode rejects unusable responses", func(t *testing.T) {
+		generator := NewAIBranchNameGenerator(&fixedLLM{response: "  "})
+		generator.Strict = true
+		if got, err := generator.GenerateBranchName(issue, "fix/"); err == nil {
+			t.Errorf("Expected an error instead of fallback name %q", got)
+		}
+	})
+}
@@ -0,0 +1,441 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agoodway/workie/errcode"
+	"github.com/agoodway/workie/provider"
+)
+
+// Provider implements the Provider interface for Bitbucket Cloud issues
+type Provider struct {
+	username     string
+	appPassword  string
+	workspace    string
+	repoSlug     string
+	baseURL      string
+	branchPrefix map[string]string
+	branchName   provider.BranchNameOptions
+	statusMap    provider.StatusMap
+}
+
+// NewProvider creates a new Bitbucket provider
+func NewProvider(config map[string]interface{}) (*Provider, error) {
+	p := &Provider{
+		baseURL: "https://api.bitbucket.org/2.0",
+		branchPrefix: map[string]string{
+			"bug":         "fix/",
+			"enhancement": "feat/",
+			"task":        "task/",
+			"default":     "issue/",
+		},
+	}
+
+	// Extract settings
+	if settings, ok := config["settings"].(map[string]interface{}); ok {
+		// Authentication with an app password
+		if usernameEnv, ok := settings["username_env"].(string); ok {
+			p.username = os.Getenv(usernameEnv)
+		}
+		if passwordEnv, ok := settings["app_password_env"].(string); ok {
+			p.appPassword = os.Getenv(passwordEnv)
+		}
+
+		// Repository information
+		if workspace, ok := settings["workspace"].(string); ok {
+			p.workspace = workspace
+		}
+		if repoSlug, ok := settings["repo_slug"].(string); ok {
+			p.repoSlug = repoSlug
+		}
+
+		// Custom API URL, e.g. for a proxy
+		if baseURL, ok := settings["base_url"].(string); ok {
+			p.baseURL = strings.TrimRight(baseURL, "/")
+		}
+	}
+
+	// Branch prefixes
+	if prefixes, ok := config["branch_prefix"].(map[string]interface{}); ok {
+		for key, value := range prefixes {
+			if prefix, ok := value.(string); ok {
+				p.branchPrefix[key] = prefix
+			}
+		}
+	}
+
+	// Branch name formatting
+	p.branchName = provider.BranchNameOptionsFromConfig(config)
+
+	// States to filter on for each status
+	p.statusMap = provider.StatusMapFromConfig(config)
+
+	return p, nil
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "bitbucket"
+}
+
+// ValidateConfig checks if the provider is properly configured
+func (p *Provider) ValidateConfig() error {
+	if p.username == "" {
+		return errcode.Errorf(errcode.ProviderNotConfigured, "Bitbucket username not configured (check username_env setting)")
+	}
+	if p.appPassword == "" {
+		return errcode.Errorf(errcode.ProviderNotConfigured, "Bitbucket app password not configured (check app_password_env setting)")
+	}
+	if p.workspace == "" {
+		return errcode.Errorf(errcode.ProviderNotConfigured, "Bitbucket workspace not configured")
+	}
+	if p.repoSlug == "" {
+		return errcode.Errorf(errcode.ProviderNotConfigured, "Bitbucket repository slug not configured")
+	}
+	return nil
+}
+
+// IsConfigured returns true if the provider has necessary configuration
+func (p *Provider) IsConfigured() bool {
+	return p.username != "" && p.appPassword != "" && p.workspace != "" && p.repoSlug != ""
+}
+
+// ListIssues returns a list of Bitbucket issues
+func (p *Provider) ListIssues(filter provider.ListFilter) (*provider.IssueList, error) {
+	if err := p.ValidateConfig(); err != nil {
+		return nil, err
+	}
+
+	// Build a Bitbucket query language (BBQL) filter
+	var conditions []string
+
+	// Status mapping
+	if condition := p.statusCondition(filter.Status); condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	// Assignee
+	if len(filter.Assignees) > 0 {
+		assignees := make([]string, len(filter.Assignees))
+		for i, assignee := range filter.Assignees {
+			if assignee == "me" {
+				accountID, err := p.currentAccountID()
+				if err != nil {
+					return nil, err
+				}
+				assignees[i] = fmt.Sprintf("assignee.account_id = %s", quoteBBQL(accountID))
+			} else {
+				assignees[i] = fmt.Sprintf("assignee.nickname = %s", quoteBBQL(assignee))
+			}
+		}
+		if len(assignees) == 1 {
+			conditions = append(conditions, assignees[0])
+		} else {
+			conditions = append(conditions, "("+strings.Join(assignees, " OR ")+")")
+		}
+	}
+
+	// Bitbucket issues have no labels, so labels match the issue's component
+	if len(filter.Labels) > 0 {
+		components := make([]string, len(filter.Labels))
+		for i, label := range filter.Labels {
+			components[i] = fmt.Sprintf("component.name = %s", quoteBBQL(label))
+		}
+		conditions = append(conditions, "("+strings.Join(components, " OR ")+")")
+	}
+
+	// Type filter
+	if filter.Type != "" {
+		conditions = append(conditions, fmt.Sprintf("kind = %s", quoteBBQL(strings.ToLower(filter.Type))))
+	}
+
+	// Free text search
+	if filter.Query != "" {
+		conditions = append(conditions, fmt.Sprintf("title ~ %s", quoteBBQL(filter.Query)))
+	}
+
+	// Limit (Bitbucket returns at most 50 issues per page)
+	pageLen := 30
+	if filter.Limit > 0 {
+		pageLen = min(filter.Limit, 50)
+	}
+
+	page := 1
+	if filter.Cursor != "" {
+		if n, err := strconv.Atoi(filter.Cursor); err == nil && n > 0 {
+			page = n
+		}
+	}
+
+	params := map[string]string{
+		"q":       strings.Join(conditions, " AND "),
+		"sort":    "-updated_on",
+		"pagelen": strconv.Itoa(pageLen),
+		"page":    strconv.Itoa(page),
+	}
+
+	resp, err := p.makeRequest(p.repoURL("/issues"), params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result bitbucketIssuePage
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse Bitbucket response: %w", err)
+	}
+
+	issues := make([]provider.Issue, len(result.Values))
+	for i, bbIssue := range result.Values {
+		issues[i] = p.convertIssue(bbIssue)
+	}
+
+	// A next link means there are more pages
+	hasMore := result.Next != ""
+	nextCursor := ""
+	if hasMore {
+		nextCursor = strconv.Itoa(page + 1)
+	}
+
+	return &provider.IssueList{
+		Issues:     issues,
+		TotalCount: result.Size,
+		HasMore:    hasMore,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetIssue fetches a single Bitbucket issue
+func (p *Provider) GetIssue(issueID string) (*provider.Issue, error) {
+	if err := p.ValidateConfig(); err != nil {
+		return nil, err
+	}
+
+	// Validate issue ID is a number
+	issueID = strings.TrimPrefix(issueID, "#")
+	if _, err := strconv.Atoi(issueID); err != nil {
+		return nil, fmt.Errorf("invalid Bitbucket issue ID: %s (must be a number)", issueID)
+	}
+
+	resp, err := p.makeRequest(p.repoURL("/issues/"+issueID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var bbIssue bitbucketIssue
+	if err := json.NewDecoder(resp.Body).Decode(&bbIssue); err != nil {
+		return nil, fmt.Errorf("failed to parse Bitbucket response: %w", err)
+	}
+
+	issue := p.convertIssue(bbIssue)
+	return &issue, nil
+}
+
+// CreateBranchName generates a branch name based on the issue
+func (p *Provider) CreateBranchName(issue *provider.Issue) string {
+	return provider.CreateBranchNameWithOptions(p.branchPrefix, issue, p.branchName)
+}
+
+// repoURL returns the API URL of a path under the configured repository
+func (p *Provider) repoURL(path string) string {
+	return fmt.Sprintf("%s/repositories/%s/%s%s", p.baseURL, url.PathEscape(p.workspace), url.PathEscape(p.repoSlug), path)
+}
+
+// currentAccountID returns the account ID of the authenticated user
+func (p *Provider) currentAccountID() (string, error) {
+	resp, err := p.makeRequest(p.baseURL+"/user", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var user bitbucketUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return "", fmt.Errorf("failed to parse Bitbucket response: %w", err)
+	}
+	return user.AccountID, nil
+}
+
+// makeRequest makes a GET request to the Bitbucket API
+func (p *Provider) makeRequest(url string, params map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add query parameters
+	if params != nil {
+		q := req.URL.Query()
+		for key, value := range params {
+			if value != "" {
+				q.Add(key, value)
+			}
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "workie/1.0")
+	req.SetBasicAuth(p.username, p.appPassword)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Bitbucket API request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var apiErr bitbucketError
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+		return nil, p.statusError(resp.StatusCode, apiErr.Error.Message)
+	}
+
+	return resp, nil
+}
+
+// statusError explains an unsuccessful response, including the common case of
+// a repository whose issue tracker is turned off
+func (p *Provider) statusError(status int, message string) error {
+	if status == http.StatusNotFound && strings.Contains(strings.ToLower(message), "issue tracker") {
+		return errcode.Errorf(errcode.ProviderNotConfigured, "the issue tracker is disabled for Bitbucket repository %s/%s\n\nTo fix this:\n  • Enable it under Repository settings → Issue tracker\n  • Or disable the bitbucket provider in your .workie.yaml", p.workspace, p.repoSlug)
+	}
+	if status == http.StatusUnauthorized {
+		return errcode.Errorf(errcode.ProviderAuth, "Bitbucket API returned status 401\n\nTo fix this:\n  • Check the username and app password in username_env and app_password_env\n  • Make sure the app password has the Issues: Read permission")
+	}
+	if message != "" {
+		return errcode.Errorf(provider.ErrorCode(status), "Bitbucket API returned status %d: %s", status, message)
+	}
+	return errcode.Errorf(provider.ErrorCode(status), "Bitbucket API returned status %d", status)
+}
+
+// convertIssue converts a Bitbucket issue to a provider issue
+func (p *Provider) convertIssue(bbIssue bitbucketIssue) provider.Issue {
+	var labels []string
+	if bbIssue.Component != nil && bbIssue.Component.Name != "" {
+		labels = append(labels, bbIssue.Component.Name)
+	}
+
+	metadata := map[string]string{
+		"created_at": bbIssue.CreatedOn,
+		"updated_at": bbIssue.UpdatedOn,
+		"priority":   bbIssue.Priority,
+	}
+	if bbIssue.Reporter != nil {
+		metadata["reporter"] = bbIssue.Reporter.DisplayName
+	}
+	if bbIssue.Assignee != nil {
+		metadata["assignee"] = bbIssue.Assignee.DisplayName
+	}
+
+	return provider.Issue{
+		ID:          strconv.Itoa(bbIssue.ID),
+		Title:       bbIssue.Title,
+		Description: bbIssue.Content.Raw,
+		Type:        bbIssue.Kind,
+		Status:      bbIssue.State,
+		Labels:      labels,
+		URL:         bbIssue.Links.HTML.Href,
+		Provider:    "bitbucket",
+		Metadata:    metadata,
+	}
+}
+
+// statusCondition returns the BBQL condition for a status filter. A status in
+// status_map matches its states (new, open, on hold, resolved, ...); otherwise
+// open, the default, matches new and open issues, closed the finished states
+// and in-progress open ones. Other statuses don't filter.
+func (p *Provider) statusCondition(status string) string {
+	if status == "" {
+		status = "open"
+	}
+
+	var states []string
+	if mapped, ok := p.statusMap.States(status); ok {
+		states = mapped
+	} else {
+		switch strings.ToLower(status) {
+		case "open":
+			states = []string{"new", "open"}
+		case "closed":
+			states = []string{"resolved", "closed", "invalid", "duplicate", "wontfix"}
+		case "in-progress":
+			states = []string{"open"}
+		}
+	}
+
+	conditions := make([]string, len(states))
+	for i, state := range states {
+		conditions[i] = "state = " + quoteBBQL(state)
+	}
+	switch len(conditions) {
+	case 0:
+		return ""
+	case 1:
+		return conditions[0]
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+// quoteBBQL quotes a string value for a Bitbucket query
+func quoteBBQL(value string) string {
+	return strconv.Quote(value)
+}
+
+// Bitbucket API types
+type bitbucketIssuePage struct {
+	Size   int              `json:"size"`
+	Page   int              `json:"page"`
+	Next   string           `json:"next"`
+	Values []bitbucketIssue `json:"values"`
+}
+
+type bitbucketIssue struct {
+	ID        int                 `json:"id"`
+	Title     string              `json:"title"`
+	Content   bitbucketContent    `json:"content"`
+	Kind      string              `json:"kind"` // bug, enhancement, proposal or task
+	State     string              `json:"state"`
+	Priority  string              `json:"priority"`
+	CreatedOn string              `json:"created_on"`
+	UpdatedOn string              `json:"updated_on"`
+	Reporter  *bitbucketUser      `json:"reporter"`
+	Assignee  *bitbucketUser      `json:"assignee"`
+	Component *bitbucketComponent `json:"component"`
+	Links     bitbucketLinks      `json:"links"`
+}
+
+type bitbucketContent struct {
+	Raw string `json:"raw"`
+}
+
+type bitbucketUser struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+	AccountID   string `json:"account_id"`
+}
+
+type bitbucketComponent struct {
+	Name string `json:"name"`
+}
+
+type bitbucketLinks struct {
+	HTML struct {
+		Href string `json:"href"`
+	} `json:"html"`
+}
+
+// bitbucketError is the error body returned by the Bitbucket API
+type bitbucketError struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
@@ -0,0 +1,128 @@
+package bitbucket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/agoodway/workie/provider"
+)
+
+// newTestProvider creates a provider that talks to the given test server
+func newTestProvider(t *testing.T, serverURL string) *Provider {
+	t.Helper()
+	t.Setenv("WORKIE_TEST_BITBUCKET_USER", "alice")
+	t.Setenv("WORKIE_TEST_BITBUCKET_PASSWORD", "secret")
+	p, err := NewProvider(map[string]interface{}{
+		"settings": map[string]interface{}{
+			"username_env":     "WORKIE_TEST_BITBUCKET_USER",
+			"app_password_env": "WORKIE_TEST_BITBUCKET_PASSWORD",
+			"workspace":        "team",
+			"repo_slug":        "app",
+			"base_url":         serverURL,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestListIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/team/app/issues" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		if user, password, ok := r.BasicAuth(); !ok || user != "alice" || password != "secret" {
+			t.Errorf("Expected app password auth, got %q %q", user, password)
+		}
+		if q := r.URL.Query().Get("q"); q != `(state = "new" OR state = "open") AND (component.name = "ui")` {
+			t.Errorf("Unexpected query %q", q)
+		}
+		w.Write([]byte(`{
+			"size": 2, "page": 1, "next": "https://api.bitbucket.org/2.0/next",
+			"values": [
+				{"id": 7, "title": "Login fails on Safari", "kind": "bug", "state": "new",
+				 "content": {"raw": "Steps to reproduce"}, "component": {"name": "ui"},
+				 "links": {"html": {"href": "https://bitbucket.org/team/app/issues/7"}}},
+				{"id": 8, "title": "Dark mode", "kind": "enhancement", "state": "open"}
+			]}`))
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	list, err := p.ListIssues(provider.ListFilter{Labels: []string{"ui"}, Limit: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(list.Issues) != 2 || !list.HasMore || list.NextCursor != "2" {
+		t.Fatalf("Unexpected issue list: %+v", list)
+	}
+
+	bug := list.Issues[0]
+	if bug.ID != "7" || bug.Description != "Steps to reproduce" || bug.URL != "https://bitbucket.org/team/app/issues/7" || bug.Labels[0] != "ui" {
+		t.Errorf("Unexpected issue: %+v", bug)
+	}
+	if got := p.CreateBranchName(&bug); got != "fix/7-login-fails-on-safari" {
+		t.Errorf("Expected a bug to get the fix/ prefix, got %s", got)
+	}
+	if got := p.CreateBranchName(&list.Issues[1]); got != "feat/8-dark-mode" {
+		t.Errorf("Expected an enhancement to get the feat/ prefix, got %s", got)
+	}
+}
+
+func TestIssueTrackerDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"type": "error", "error": {"message": "Repository has no issue tracker."}}`))
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	_, err := p.GetIssue("7")
+	if err == nil || !strings.Contains(err.Error(), "issue tracker is disabled for Bitbucket repository team/app") {
+		t.Errorf("Expected a disabled issue tracker error, got: %v", err)
+	}
+}
+
+func TestListIssuesMultipleAssignees(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := `(state = "new" OR state = "open") AND (assignee.nickname = "alice" OR assignee.nickname = "bob")`
+		if q := r.URL.Query().Get("q"); q != want {
+			t.Errorf("Unexpected query %q", q)
+		}
+		w.Write([]byte(`{"size": 0, "page": 1, "values": []}`))
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	if _, err := p.ListIssues(provider.ListFilter{Assignees: []string{"alice", "bob"}}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+}
+
+func TestStatusCondition(t *testing.T) {
+	p := &Provider{}
+	if got, want := p.statusCondition(""), `(state = "new" OR state = "open")`; got != want {
+		t.Errorf("Expected the default to match open issues, got %s, want %s", got, want)
+	}
+
+	p.statusMap = provider.StatusMapFromConfig(map[string]interface{}{
+		"status_map": map[string]interface{}{
+			"blocked": []interface{}{"on hold"},
+			"open":    []interface{}{"new", "open", "on hold"},
+		},
+	})
+	tests := map[string]string{
+		"Blocked":     `state = "on hold"`,
+		"":            `(state = "new" OR state = "open" OR state = "on hold")`,
+		"in-progress": `state = "open"`,
+		"unknown":     "",
+	}
+	for status, want := range tests {
+		if got := p.statusCondition(status); got != want {
+			t.Errorf("statusCondition(%q) = %s, want %s", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"strings"
+)
+
+// DefaultBranchPrefixes are the branch prefixes used when none are configured
+var DefaultBranchPrefixes = map[string]string{
+	"bug":     "fix/",
+	"feature": "feat/",
+	"task":    "task/",
+	"default": "issue/",
+}
+
+// BranchNameOptions controls how a provider turns issues into branch names
+type BranchNameOptions struct {
+	SanitizeOptions      // How the title is cleaned up, and the separator after the ID
+	PreserveIDCase  bool // Keep issue IDs such as PROJ-456 as they are instead of lowercasing them
+}
+
+// BranchNameOptionsFromConfig reads the branch_name section of a provider's
+// configuration:
+//
+//	branch_name:
+//	  separator: "_"          # -, _ or . (default: -)
+//	  max_length: 40          # longest title part (default: 63)
+//	  preserve_case: true     # keep the title's letter case
+//	  preserve_id_case: true  # keep IDs such as PROJ-456 uppercase
+func BranchNameOptionsFromConfig(config map[string]interface{}) BranchNameOptions {
+	var opts BranchNameOptions
+
+	settings, ok := config["branch_name"].(map[string]interface{})
+	if !ok {
+		return opts
+	}
+	if sep, ok := settings["separator"].(string); ok && (sep == "-" || sep == "_" || sep == ".") {
+		opts.Separator = sep
+	}
+	switch maxLength := settings["max_length"].(type) {
+	case int:
+		opts.MaxLength = maxLength
+	case float64:
+		opts.MaxLength = int(maxLength)
+	}
+	if preserve, ok := settings["preserve_case"].(bool); ok {
+		opts.PreserveCase = preserve
+	}
+	if preserve, ok := settings["preserve_id_case"].(bool); ok {
+		opts.PreserveIDCase = preserve
+	}
+	return opts
+}
+
+// CreateBranchName builds the standard branch name for an issue: the prefix for
+// its inferred type, its lowercased ID when it has one, and its sanitized title,
+// e.g. "fix/123-login-fails-on-safari"
+func CreateBranchName(prefixes map[string]string, issue *Issue) string {
+	return CreateBranchNameWithOptions(prefixes, issue, BranchNameOptions{})
+}
+
+// CreateBranchNameWithOptions builds the branch name for an issue like
+// CreateBranchName, formatted with opts, e.g. "bugfix/PROJ-456_login_fails"
+func CreateBranchNameWithOptions(prefixes map[string]string, issue *Issue, opts BranchNameOptions) string {
+	prefix := BranchPrefixForType(prefixes, InferIssueType(issue))
+	suffix := SanitizeBranchNameWithOptions(issue.Title, opts.SanitizeOptions)
+	return joinBranchName(prefix, issue.ID, suffix, opts)
+}
+
+// joinBranchName joins a prefix, an optional issue ID and a descriptive suffix,
+// formatting the ID and separator as CreateBranchNameWithOptions does. Issues
+// entered by hand may have no ID, in which case the suffix follows the prefix.
+func joinBranchName(prefix, issueID, suffix string, opts BranchNameOptions) string {
+	if issueID == "" {
+		return prefix + suffix
+	}
+	if !opts.PreserveIDCase {
+		issueID = strings.ToLower(issueID)
+	}
+	return prefix + issueID + opts.separator() + suffix
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// IssueCache stores fetched issues on disk, so looking up the same issue twice in
+// a row (e.g. 'workie issues github:123' then 'workie begin --issue github:123')
+// makes a single API request
+type IssueCache struct {
+	Dir   string        // Directory holding the cache entries
+	Scope string        // Keeps equal issue IDs of different repositories apart, e.g. the repository path
+	TTL   time.Duration // How long a fetched issue is reused
+}
+
+// cacheEntry is a cached issue and when it was fetched
+type cacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Issue     Issue     `json:"issue"`
+}
+
+// DefaultIssueCacheDir returns the user's issue cache directory, e.g.
+// ~/.cache/workie/issues on Linux
+func DefaultIssueCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "workie", "issues"), nil
+}
+
+// GetIssue returns the issue from the cache while it is fresh, and otherwise
+// fetches it from p and caches it. A nil cache always fetches. Unreadable or
+// corrupt entries are ignored and replaced by a live fetch.
+func (c *IssueCache) GetIssue(p Provider, issueID string) (*Issue, error) {
+	if c == nil || c.TTL <= 0 {
+		return p.GetIssue(issueID)
+	}
+
+	path := c.entryPath(p.Name(), issueID)
+	if issue, ok := c.load(path); ok {
+		return issue, nil
+	}
+
+	issue, err := p.GetIssue(issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A cache that can't be written only costs another request next time
+	_ = c.store(path, issue)
+	return issue, nil
+}
+
+// Clear deletes every cached issue and returns how many there were
+func (c *IssueCache) Clear() (int, error) {
+	entries, err := filepath.Glob(filepath.Join(c.Dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(c.Dir); err != nil {
+		return 0, fmt.Errorf("failed to clear issue cache %s: %w", c.Dir, err)
+	}
+	return len(entries), nil
+}
+
+// entryPath returns the file caching an issue, named after a hash of the scope,
+// provider and ID so that any ID is a valid file name
+func (c *IssueCache) entryPath(providerName, issueID string) string {
+	key := strings.Join([]string{c.Scope, providerName, strings.TrimPrefix(issueID, "#")}, "\x00")
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, providerName+"-"+hex.EncodeToString(sum[:12])+".json")
+}
+
+// load reads a cache entry, reporting false if it is missing, stale or corrupt
+func (c *IssueCache) load(path string) (*Issue, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Issue.ID == "" {
+		os.Remove(path)
+		return nil, false
+	}
+
+	age := time.Since(entry.FetchedAt)
+	if age < 0 || age >= c.TTL {
+		return nil, false
+	}
+	return &entry.Issue, true
+}
+
+// store writes a cache entry, replacing any existing one atomically
+func (c *IssueCache) store(path string, issue *Issue) error {
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cacheEntry{FetchedAt: time.Now(), Issue: *issue})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.Dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
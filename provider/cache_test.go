@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// countingProvider counts GetIssue calls
+type countingProvider struct {
+	mockProvider
+	calls int
+}
+
+func (c *countingProvider) GetIssue(issueID string) (*Issue, error) {
+	c.calls++
+	return c.mockProvider.GetIssue(issueID)
+}
+
+func TestIssueCache(t *testing.T) {
+	p := &countingProvider{mockProvider: mockProvider{name: "github", configured: true}}
+	cache := &IssueCache{Dir: filepath.Join(t.TempDir(), "issues"), Scope: "/repo", TTL: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		issue, err := cache.GetIssue(p, "123")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if issue.ID != "123" || issue.Title != "Test Issue" {
+			t.Errorf("Unexpected issue: %+v", issue)
+		}
+	}
+	if p.calls != 1 {
+		t.Errorf("Expected the second lookup to be served from the cache, got %d calls", p.calls)
+	}
+
+	// The same ID in another repository is a different issue
+	other := &IssueCache{Dir: cache.Dir, Scope: "/other", TTL: time.Minute}
+	if _, err := other.GetIssue(p, "123"); err != nil {
+		t.Fatal(err)
+	}
+	if p.calls != 2 {
+		t.Errorf("Expected a lookup in another scope to fetch, got %d calls", p.calls)
+	}
+
+	// A corrupt entry falls back to a live fetch and is replaced
+	path := cache.entryPath("github", "123")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if issue, err := cache.GetIssue(p, "123"); err != nil || issue.ID != "123" {
+		t.Fatalf("Expected a live fetch after corruption, got %+v, %v", issue, err)
+	}
+	if p.calls != 3 {
+		t.Errorf("Expected the corrupt entry to be fetched again, got %d calls", p.calls)
+	}
+
+	// Expired entries are fetched again
+	expired := &IssueCache{Dir: cache.Dir, Scope: "/repo", TTL: time.Nanosecond}
+	time.Sleep(time.Millisecond)
+	if _, err := expired.GetIssue(p, "123"); err != nil {
+		t.Fatal(err)
+	}
+	if p.calls != 4 {
+		t.Errorf("Expected an expired entry to be fetched again, got %d calls", p.calls)
+	}
+
+	// A nil cache always fetches
+	var disabled *IssueCache
+	if _, err := disabled.GetIssue(p, "123"); err != nil {
+		t.Fatal(err)
+	}
+	if p.calls != 5 {
+		t.Errorf("Expected a nil cache to fetch, got %d calls", p.calls)
+	}
+
+	cleared, err := cache.Clear()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cleared != 2 {
+		t.Errorf("Expected 2 cleared entries, got %d", cleared)
+	}
+	if _, err := os.Stat(cache.Dir); !os.IsNotExist(err) {
+		t.Errorf("Expected the cache directory to be removed, got err=%v", err)
+	}
+}
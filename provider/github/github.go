@@ -1,14 +1,18 @@
 package github
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/agoodway/workie/errcode"
 	"github.com/agoodway/workie/provider"
 )
 
@@ -19,6 +23,7 @@ type Provider struct {
 	repo         string
 	baseURL      string
 	branchPrefix map[string]string
+	branchName   provider.BranchNameOptions
 }
 
 // NewProvider creates a new GitHub provider
@@ -62,6 +67,9 @@ func NewProvider(config map[string]interface{}) (*Provider, error) {
 		}
 	}
 
+	// Branch name formatting
+	p.branchName = provider.BranchNameOptionsFromConfig(config)
+
 	return p, nil
 }
 
@@ -73,13 +81,13 @@ func (p *Provider) Name() string {
 // ValidateConfig checks if the provider is properly configured
 func (p *Provider) ValidateConfig() error {
 	if p.token == "" {
-		return fmt.Errorf("GitHub token not configured (check token_env setting)")
+		return errcode.Errorf(errcode.ProviderNotConfigured, "GitHub token not configured (check token_env setting)")
 	}
 	if p.owner == "" {
-		return fmt.Errorf("GitHub repository owner not configured")
+		return errcode.Errorf(errcode.ProviderNotConfigured, "GitHub repository owner not configured")
 	}
 	if p.repo == "" {
-		return fmt.Errorf("GitHub repository name not configured")
+		return errcode.Errorf(errcode.ProviderNotConfigured, "GitHub repository name not configured")
 	}
 	return nil
 }
@@ -112,64 +120,84 @@ func (p *Provider) ListIssues(filter provider.ListFilter) (*provider.IssueList,
 		params["state"] = "open" // Default to open issues
 	}
 
-	// Assignee
-	if filter.Assignee != "" {
-		params["assignee"] = filter.Assignee
-	}
-
 	// Labels
 	if len(filter.Labels) > 0 {
 		params["labels"] = strings.Join(filter.Labels, ",")
 	}
 
-	// Limit
+	// Limit (GitHub returns at most 100 issues per page)
 	perPage := 30
-	if filter.Limit > 0 && filter.Limit < 100 {
-		perPage = filter.Limit
+	if filter.Limit > 0 {
+		perPage = min(filter.Limit, 100)
 	}
-	params["per_page"] = strconv.Itoa(perPage)
 
-	// Pagination
+	// Pagination. Page numbers only line up when every page has the same size, so
+	// the cursor carries the page size it was computed with ("page:per_page").
 	page := 1
 	if filter.Cursor != "" {
-		if p, err := strconv.Atoi(filter.Cursor); err == nil {
-			page = p
-		}
+		page, perPage = parsePageCursor(filter.Cursor, perPage)
 	}
+	params["per_page"] = strconv.Itoa(perPage)
 	params["page"] = strconv.Itoa(page)
 
-	// Build URL
-	url := fmt.Sprintf("%s/repos/%s/%s/issues", p.baseURL, p.owner, p.repo)
-
-	// Make request
-	resp, err := p.makeRequest("GET", url, params)
-	if err != nil {
-		return nil, err
+	// GitHub filters by a single assignee, so each one is a request of its own
+	// and the pages are merged. "me" is the token's user.
+	assignees := slices.Clone(filter.Assignees)
+	if len(assignees) == 0 {
+		assignees = []string{""}
 	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var ghIssues []githubIssue
-	if err := json.NewDecoder(resp.Body).Decode(&ghIssues); err != nil {
-		return nil, fmt.Errorf("failed to parse GitHub response: %w", err)
+	for i, assignee := range assignees {
+		if assignee == "me" {
+			login, err := p.currentLogin()
+			if err != nil {
+				return nil, err
+			}
+			assignees[i] = login
+		}
 	}
 
-	// Convert to provider issues
-	issues := make([]provider.Issue, 0, len(ghIssues))
-	for _, ghIssue := range ghIssues {
-		// Skip pull requests
-		if ghIssue.PullRequest != nil {
-			continue
+	var issues []provider.Issue
+	seen := make(map[int]bool)
+	hasMore := false
+	for _, assignee := range assignees {
+		if assignee != "" {
+			params["assignee"] = assignee
+		}
+		ghIssues, err := p.listIssuesPage(params)
+		if err != nil {
+			return nil, err
+		}
+		if len(ghIssues) == perPage {
+			hasMore = true
 		}
 
-		issues = append(issues, p.convertIssue(ghIssue))
+		for _, ghIssue := range ghIssues {
+			// Skip pull requests unless asked for, and issues already listed for
+			// another assignee
+			if (ghIssue.PullRequest != nil && !filter.IncludePullRequests) || seen[ghIssue.Number] {
+				continue
+			}
+			seen[ghIssue.Number] = true
+			issues = append(issues, p.convertIssue(ghIssue))
+		}
 	}
 
-	// Check if there are more pages
-	hasMore := len(ghIssues) == perPage
+	// Keep GitHub's newest-first order across the merged pages, and the limit.
+	// The pages of several assignees can't be resumed where the merged list was
+	// cut, so they aren't paginated: only the newest perPage issues are listed.
 	nextCursor := ""
-	if hasMore {
-		nextCursor = strconv.Itoa(page + 1)
+	if len(assignees) > 1 {
+		sort.SliceStable(issues, func(i, j int) bool {
+			a, _ := strconv.Atoi(issues[i].ID)
+			b, _ := strconv.Atoi(issues[j].ID)
+			return a > b
+		})
+		if len(issues) > perPage {
+			issues = issues[:perPage]
+			hasMore = true
+		}
+	} else if hasMore {
+		nextCursor = fmt.Sprintf("%d:%d", page+1, perPage)
 	}
 
 	return &provider.IssueList{
@@ -180,6 +208,56 @@ func (p *Provider) ListIssues(filter provider.ListFilter) (*provider.IssueList,
 	}, nil
 }
 
+// listIssuesPage fetches one page of the repository's issues
+func (p *Provider) listIssuesPage(params map[string]string) ([]githubIssue, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues", p.baseURL, p.owner, p.repo)
+	resp, err := p.makeRequest("GET", url, params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var ghIssues []githubIssue
+	if err := json.NewDecoder(resp.Body).Decode(&ghIssues); err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub response: %w", err)
+	}
+	return ghIssues, nil
+}
+
+// currentLogin returns the login of the token's user
+func (p *Provider) currentLogin() (string, error) {
+	resp, err := p.makeRequest("GET", p.baseURL+"/user", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var user githubUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return "", fmt.Errorf("failed to parse GitHub response: %w", err)
+	}
+	if user.Login == "" {
+		return "", fmt.Errorf("GitHub did not return the token's user")
+	}
+	return user.Login, nil
+}
+
+// parsePageCursor parses a "page:per_page" cursor; a bare page number keeps the
+// given page size
+func parsePageCursor(cursor string, perPage int) (int, int) {
+	pageStr, sizeStr, hasSize := strings.Cut(cursor, ":")
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		page = 1
+	}
+	if hasSize {
+		if size, err := strconv.Atoi(sizeStr); err == nil && size > 0 && size <= 100 {
+			perPage = size
+		}
+	}
+	return page, perPage
+}
+
 // GetIssue fetches a single GitHub issue
 func (p *Provider) GetIssue(issueID string) (*provider.Issue, error) {
 	if err := p.ValidateConfig(); err != nil {
@@ -206,32 +284,43 @@ func (p *Provider) GetIssue(issueID string) (*provider.Issue, error) {
 
 	// Check if it's a pull request
 	if ghIssue.PullRequest != nil {
-		return nil, fmt.Errorf("ID %s is a pull request, not an issue", issueID)
+		return nil, fmt.Errorf("ID %s %w\n\nTo fix this:\n  • Fetch it as a pull request: workie issues pr:%s\n  • Or pass --include-prs", issueID, provider.ErrPullRequest, issueID)
 	}
 
 	issue := p.convertIssue(ghIssue)
 	return &issue, nil
 }
 
-// CreateBranchName generates a branch name based on the issue
-func (p *Provider) CreateBranchName(issue *provider.Issue) string {
-	prefix := p.branchPrefix["default"]
-
-	// Try to determine issue type from labels
-	for _, label := range issue.Labels {
-		labelLower := strings.ToLower(label)
-		if strings.Contains(labelLower, "bug") || strings.Contains(labelLower, "fix") {
-			prefix = p.branchPrefix["bug"]
-			break
-		} else if strings.Contains(labelLower, "feature") || strings.Contains(labelLower, "enhancement") {
-			prefix = p.branchPrefix["feature"]
-			break
-		}
+// GetPullRequest fetches a GitHub pull request as an issue of type pull_request
+func (p *Provider) GetPullRequest(number string) (*provider.Issue, error) {
+	if err := p.ValidateConfig(); err != nil {
+		return nil, err
+	}
+
+	if _, err := strconv.Atoi(number); err != nil {
+		return nil, fmt.Errorf("invalid GitHub pull request number: %s (must be a number)", number)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%s", p.baseURL, p.owner, p.repo, number)
+
+	resp, err := p.makeRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var ghPR githubPullRequestDetails
+	if err := json.NewDecoder(resp.Body).Decode(&ghPR); err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub response: %w", err)
 	}
 
-	// Create branch name
-	title := provider.SanitizeBranchName(issue.Title)
-	return fmt.Sprintf("%s%s-%s", prefix, issue.ID, title)
+	issue := p.convertPullRequest(ghPR)
+	return &issue, nil
+}
+
+// CreateBranchName generates a branch name based on the issue
+func (p *Provider) CreateBranchName(issue *provider.Issue) string {
+	return provider.CreateBranchNameWithOptions(p.branchPrefix, issue, p.branchName)
 }
 
 // makeRequest makes an HTTP request to the GitHub API
@@ -250,27 +339,216 @@ func (p *Provider) makeRequest(method, url string, params map[string]string) (*h
 		req.URL.RawQuery = q.Encode()
 	}
 
-	// Add headers
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "workie/1.0")
-	if p.token != "" {
-		req.Header.Set("Authorization", "token "+p.token)
+	p.setHeaders(req)
+
+	resp, err := p.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if err := rateLimitError(resp); err != nil {
+			return nil, err
+		}
+		return nil, errcode.Errorf(provider.ErrorCode(resp.StatusCode), "GitHub API returned status %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// makeJSONRequest sends a JSON payload to the GitHub API and accepts any 2xx response.
+// Error responses include GitHub's explanation (e.g. "A pull request already exists").
+func (p *Provider) makeJSONRequest(method, url string, payload interface{}) (*http.Response, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode GitHub request: %w", err)
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
 	}
+	p.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		if err := rateLimitError(resp); err != nil {
+			return nil, err
+		}
+		var apiErr githubError
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Message != "" {
+			return nil, errcode.Errorf(provider.ErrorCode(resp.StatusCode), "GitHub API returned status %d: %s", resp.StatusCode, apiErr.String())
+		}
+		return nil, errcode.Errorf(provider.ErrorCode(resp.StatusCode), "GitHub API returned status %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
 
+// maxRetryAfter is the longest Retry-After delay that is waited out before retrying
+const maxRetryAfter = time.Minute
+
+// do sends a request with a 30s timeout per attempt. When GitHub answers with a
+// Retry-After header (secondary rate limits), the request is retried once after
+// the delay, unless the delay is too long or the request's context ends first.
+func (p *Provider) do(req *http.Request) (*http.Response, error) {
 	client := &http.Client{Timeout: 30 * time.Second}
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("GitHub API request failed: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		resp.Body.Close()
-		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+	wait, ok := retryAfter(resp)
+	if !ok || wait > maxRetryAfter {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	ctx := req.Context()
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("GitHub API request cancelled while waiting to retry: %w", ctx.Err())
+	case <-timer.C:
 	}
 
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	resp, err = client.Do(retry)
+	if err != nil {
+		return nil, fmt.Errorf("GitHub API request failed: %w", err)
+	}
 	return resp, nil
 }
 
+// retryAfter returns the delay from a rate-limited response's Retry-After header,
+// given either in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// rateLimitError explains a rate-limited response, or returns nil if the response
+// was not rate limited
+func rateLimitError(resp *http.Response) error {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+
+	if wait, ok := retryAfter(resp); ok {
+		return errcode.Errorf(errcode.ProviderRateLimited, "GitHub API rate limit exceeded; retry after %s\n\nTo fix this:\n  • Wait and run the command again\n  • Make fewer requests in quick succession", wait.Round(time.Second))
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return nil
+	}
+
+	when := "later"
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		at := time.Unix(reset, 0)
+		when = fmt.Sprintf("at %s (in %s)", at.Format("15:04:05"), max(time.Until(at), 0).Round(time.Second))
+	}
+	return errcode.Errorf(errcode.ProviderRateLimited, "GitHub API rate limit exceeded; the limit resets %s\n\nTo fix this:\n  • Wait until the limit resets\n  • Configure token_env with a personal access token for a higher limit\n  • Fetch fewer issues, e.g. with --limit", when)
+}
+
+// setHeaders adds the GitHub API headers to a request
+func (p *Provider) setHeaders(req *http.Request) {
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "workie/1.0")
+	if p.token != "" {
+		req.Header.Set("Authorization", "token "+p.token)
+	}
+}
+
+// CreatePullRequest opens a pull request in the configured repository
+func (p *Provider) CreatePullRequest(pr provider.PullRequest) (*provider.PullRequestInfo, error) {
+	if err := p.ValidateConfig(); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls", p.baseURL, p.owner, p.repo)
+
+	resp, err := p.makeJSONRequest("POST", url, githubPullRequestRequest{
+		Title: pr.Title,
+		Body:  pr.Body,
+		Head:  pr.Head,
+		Base:  pr.Base,
+		Draft: pr.Draft,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var ghPR githubPullRequest
+	if err := json.NewDecoder(resp.Body).Decode(&ghPR); err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub response: %w", err)
+	}
+
+	info := &provider.PullRequestInfo{
+		ID:  strconv.Itoa(ghPR.Number),
+		URL: ghPR.HTMLURL,
+	}
+
+	// The pull request exists at this point, so return it even if reviewers fail
+	if len(pr.Reviewers) > 0 {
+		if err := p.requestReviewers(ghPR.Number, pr.Reviewers); err != nil {
+			return info, fmt.Errorf("pull request created but requesting reviewers failed: %w", err)
+		}
+	}
+
+	return info, nil
+}
+
+// requestReviewers requests reviews from users and teams ("org/team") on a pull request
+func (p *Provider) requestReviewers(number int, reviewers []string) error {
+	var req githubReviewersRequest
+	for _, reviewer := range reviewers {
+		reviewer = strings.TrimPrefix(strings.TrimSpace(reviewer), "@")
+		if reviewer == "" {
+			continue
+		}
+		if i := strings.Index(reviewer, "/"); i >= 0 {
+			req.TeamReviewers = append(req.TeamReviewers, reviewer[i+1:])
+		} else {
+			req.Reviewers = append(req.Reviewers, reviewer)
+		}
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/requested_reviewers", p.baseURL, p.owner, p.repo, number)
+	resp, err := p.makeJSONRequest("POST", url, req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
 // convertIssue converts a GitHub issue to a provider issue
 func (p *Provider) convertIssue(ghIssue githubIssue) provider.Issue {
 	labels := make([]string, len(ghIssue.Labels))
@@ -278,24 +556,10 @@ func (p *Provider) convertIssue(ghIssue githubIssue) provider.Issue {
 		labels[i] = label.Name
 	}
 
-	// Determine issue type from labels
-	issueType := "issue"
-	for _, label := range labels {
-		labelLower := strings.ToLower(label)
-		if strings.Contains(labelLower, "bug") {
-			issueType = "bug"
-			break
-		} else if strings.Contains(labelLower, "feature") || strings.Contains(labelLower, "enhancement") {
-			issueType = "feature"
-			break
-		}
-	}
-
-	return provider.Issue{
+	issue := provider.Issue{
 		ID:          strconv.Itoa(ghIssue.Number),
 		Title:       ghIssue.Title,
 		Description: ghIssue.Body,
-		Type:        issueType,
 		Status:      ghIssue.State,
 		Labels:      labels,
 		URL:         ghIssue.HTMLURL,
@@ -306,6 +570,61 @@ func (p *Provider) convertIssue(ghIssue githubIssue) provider.Issue {
 			"author":     ghIssue.User.Login,
 		},
 	}
+
+	// Prefer the native issue type (available for organizations that use issue
+	// types); pull requests listed with issues have a type of their own
+	switch {
+	case ghIssue.PullRequest != nil:
+		issue.Type = provider.IssueTypePullRequest
+	case ghIssue.Type != nil && ghIssue.Type.Name != "":
+		issue.Type = ghIssue.Type.Name
+	default:
+		issue.Type = provider.InferIssueType(&issue)
+	}
+
+	return issue
+}
+
+// convertPullRequest converts a GitHub pull request to a provider issue
+func (p *Provider) convertPullRequest(ghPR githubPullRequestDetails) provider.Issue {
+	labels := make([]string, len(ghPR.Labels))
+	for i, label := range ghPR.Labels {
+		labels[i] = label.Name
+	}
+
+	status := ghPR.State
+	if ghPR.Merged {
+		status = "merged"
+	}
+
+	// A pull request whose fork was deleted has no head repository; its
+	// changes can still be fetched from the base repository
+	headRepo := ""
+	if ghPR.Head.Repo != nil {
+		headRepo = ghPR.Head.Repo.FullName
+	}
+	fromFork := !strings.EqualFold(headRepo, p.owner+"/"+p.repo)
+
+	return provider.Issue{
+		ID:          strconv.Itoa(ghPR.Number),
+		Title:       ghPR.Title,
+		Description: ghPR.Body,
+		Type:        provider.IssueTypePullRequest,
+		Status:      status,
+		Labels:      labels,
+		URL:         ghPR.HTMLURL,
+		Provider:    "github",
+		Metadata: map[string]string{
+			"created_at": ghPR.CreatedAt,
+			"updated_at": ghPR.UpdatedAt,
+			"author":     ghPR.User.Login,
+			"head_ref":   ghPR.Head.Ref,
+			"head_sha":   ghPR.Head.SHA,
+			"head_repo":  headRepo,
+			"base_ref":   ghPR.Base.Ref,
+			"from_fork":  strconv.FormatBool(fromFork),
+		},
+	}
 }
 
 // GitHub API types
@@ -320,6 +639,11 @@ type githubIssue struct {
 	User        githubUser       `json:"user"`
 	Labels      []githubLabel    `json:"labels"`
 	PullRequest *json.RawMessage `json:"pull_request,omitempty"`
+	Type        *githubIssueType `json:"type,omitempty"`
+}
+
+type githubIssueType struct {
+	Name string `json:"name"`
 }
 
 type githubUser struct {
@@ -329,3 +653,67 @@ type githubUser struct {
 type githubLabel struct {
 	Name string `json:"name"`
 }
+
+type githubPullRequestRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Draft bool   `json:"draft,omitempty"`
+}
+
+type githubReviewersRequest struct {
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"team_reviewers,omitempty"`
+}
+
+type githubPullRequest struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+// githubPullRequestDetails is a pull request as returned by GET /pulls/{number}
+type githubPullRequestDetails struct {
+	Number    int                  `json:"number"`
+	Title     string               `json:"title"`
+	Body      string               `json:"body"`
+	State     string               `json:"state"`
+	Merged    bool                 `json:"merged"`
+	HTMLURL   string               `json:"html_url"`
+	CreatedAt string               `json:"created_at"`
+	UpdatedAt string               `json:"updated_at"`
+	User      githubUser           `json:"user"`
+	Labels    []githubLabel        `json:"labels"`
+	Head      githubPullRequestRef `json:"head"`
+	Base      githubPullRequestRef `json:"base"`
+}
+
+// githubPullRequestRef is the head or base branch of a pull request
+type githubPullRequestRef struct {
+	Ref  string      `json:"ref"`
+	SHA  string      `json:"sha"`
+	Repo *githubRepo `json:"repo"`
+}
+
+type githubRepo struct {
+	FullName string `json:"full_name"`
+}
+
+// githubError is the error body returned by the GitHub API
+type githubError struct {
+	Message string `json:"message"`
+	Errors  []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// String joins the top-level message with any detailed error messages
+func (e githubError) String() string {
+	parts := []string{e.Message}
+	for _, detail := range e.Errors {
+		if detail.Message != "" {
+			parts = append(parts, detail.Message)
+		}
+	}
+	return strings.Join(parts, ": ")
+}
@@ -0,0 +1,361 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agoodway/workie/provider"
+)
+
+// newTestProvider creates a provider that talks to the given test server
+func newTestProvider(t *testing.T, serverURL string) *Provider {
+	t.Helper()
+	t.Setenv("WORKIE_TEST_GITHUB_TOKEN", "secret")
+	p, err := NewProvider(map[string]interface{}{
+		"settings": map[string]interface{}{
+			"token_env": "WORKIE_TEST_GITHUB_TOKEN",
+			"owner":     "org",
+			"repo":      "repo",
+			"base_url":  serverURL,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	var got githubPullRequestRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/repos/org/repo/pulls" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "token secret" {
+			t.Errorf("Expected token auth header, got %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"number": 42, "html_url": "https://github.com/org/repo/pull/42"}`))
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	var _ provider.PullRequestCreator = p
+
+	info, err := p.CreatePullRequest(provider.PullRequest{Title: "Fix login", Body: "Closes #123", Head: "fix/123-login", Base: "main"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if info.ID != "42" || info.URL != "https://github.com/org/repo/pull/42" {
+		t.Errorf("Unexpected pull request info: %+v", info)
+	}
+	if got.Title != "Fix login" || got.Head != "fix/123-login" || got.Base != "main" || got.Body != "Closes #123" {
+		t.Errorf("Unexpected request payload: %+v", got)
+	}
+}
+
+func TestCreatePullRequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message": "Validation Failed", "errors": [{"message": "A pull request already exists for org:fix/123-login."}]}`))
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	_, err := p.CreatePullRequest(provider.PullRequest{Title: "Fix login", Head: "fix/123-login", Base: "main"})
+	if err == nil || !strings.Contains(err.Error(), "A pull request already exists") {
+		t.Errorf("Expected error with GitHub's explanation, got: %v", err)
+	}
+}
+
+func TestCreatePullRequestDraftWithReviewers(t *testing.T) {
+	var created githubPullRequestRequest
+	var reviewers githubReviewersRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/org/repo/pulls":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"number": 7, "html_url": "https://github.com/org/repo/pull/7"}`))
+		case "/repos/org/repo/pulls/7/requested_reviewers":
+			json.NewDecoder(r.Body).Decode(&reviewers)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	info, err := p.CreatePullRequest(provider.PullRequest{
+		Title:     "Fix login",
+		Head:      "fix/123-login",
+		Base:      "main",
+		Draft:     true,
+		Reviewers: []string{"alice", "@bob", "org/backend"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if info.ID != "7" {
+		t.Errorf("Expected pull request 7, got %s", info.ID)
+	}
+	if !created.Draft {
+		t.Error("Expected pull request to be created as a draft")
+	}
+	if strings.Join(reviewers.Reviewers, ",") != "alice,bob" || strings.Join(reviewers.TeamReviewers, ",") != "backend" {
+		t.Errorf("Unexpected reviewers request: %+v", reviewers)
+	}
+}
+
+func TestCreatePullRequestReviewersFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/requested_reviewers") {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"message": "Reviews may only be requested from collaborators."}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"number": 8, "html_url": "https://github.com/org/repo/pull/8"}`))
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	info, err := p.CreatePullRequest(provider.PullRequest{Title: "Fix", Head: "fix/1-x", Base: "main", Reviewers: []string{"stranger"}})
+	if err == nil {
+		t.Fatal("Expected reviewers error")
+	}
+	if info == nil || info.ID != "8" {
+		t.Errorf("Expected the created pull request to be returned alongside the error, got %+v", info)
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	reset := time.Now().Add(10 * time.Minute)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	_, err := p.GetIssue("123")
+	if err == nil {
+		t.Fatal("Expected a rate limit error")
+	}
+	if !strings.Contains(err.Error(), "rate limit exceeded") || !strings.Contains(err.Error(), reset.Format("15:04:05")) {
+		t.Errorf("Expected error with the reset time, got: %v", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"number": 42, "html_url": "https://github.com/org/repo/pull/42"}`))
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	info, err := p.CreatePullRequest(provider.PullRequest{Title: "Fix login", Head: "fix/123-login", Base: "main"})
+	if err != nil {
+		t.Fatalf("Expected the retry to succeed, got: %v", err)
+	}
+	if calls != 2 || info.ID != "42" {
+		t.Errorf("Expected one retry creating #42, got %d calls and %+v", calls, info)
+	}
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	_, err := p.GetIssue("123")
+	if err == nil || !strings.Contains(err.Error(), "retry after 1h0m0s") {
+		t.Errorf("Expected error with the retry delay, got: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected no retry for a long delay, got %d calls", calls)
+	}
+}
+
+func TestListIssuesPagination(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		requests = append(requests, query.Get("page")+"/"+query.Get("per_page"))
+
+		page, _ := strconv.Atoi(query.Get("page"))
+		perPage, _ := strconv.Atoi(query.Get("per_page"))
+		issues := []map[string]interface{}{}
+		for i := (page - 1) * perPage; i < page*perPage && i < 250; i++ {
+			issues = append(issues, map[string]interface{}{"number": i + 1, "title": fmt.Sprintf("Issue %d", i+1), "state": "open"})
+		}
+		json.NewEncoder(w).Encode(issues)
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	list, err := provider.ListIssuesUpTo(p, provider.ListFilter{Limit: 150})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Issues) != 150 || list.Issues[149].ID != "150" {
+		t.Errorf("Expected issues 1-150, got %d issues", len(list.Issues))
+	}
+	if fmt.Sprint(requests) != "[1/100 2/100]" {
+		t.Errorf("Expected two pages of 100, got %v", requests)
+	}
+}
+
+func TestListIssuesMultipleAssignees(t *testing.T) {
+	assigned := map[string][]int{"octocat": {3, 1}, "alice": {4, 3}}
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// "me" is resolved to the token's user
+		if r.URL.Path == "/user" {
+			fmt.Fprint(w, `{"login": "octocat"}`)
+			return
+		}
+		assignee := r.URL.Query().Get("assignee")
+		requested = append(requested, assignee)
+		issues := []map[string]interface{}{}
+		for _, number := range assigned[assignee] {
+			issues = append(issues, map[string]interface{}{"number": number, "title": fmt.Sprintf("Issue %d", number), "state": "open"})
+		}
+		json.NewEncoder(w).Encode(issues)
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	list, err := p.ListIssues(provider.ListFilter{Assignees: []string{"me", "alice"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(requested) != "[octocat alice]" {
+		t.Errorf("Expected a request per assignee, got %v", requested)
+	}
+	var ids []string
+	for _, issue := range list.Issues {
+		ids = append(ids, issue.ID)
+	}
+	if fmt.Sprint(ids) != "[4 3 1]" {
+		t.Errorf("Expected merged, deduplicated issues newest first, got %v", ids)
+	}
+	if list.HasMore {
+		t.Error("Expected no more pages")
+	}
+
+	// The merged issues are cut to the limit
+	list, err = p.ListIssues(provider.ListFilter{Assignees: []string{"me", "alice"}, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Issues) != 2 || list.Issues[1].ID != "3" || !list.HasMore || list.NextCursor != "" {
+		t.Errorf("Expected the newest 2 issues and more without a cursor, got %+v", list)
+	}
+}
+
+func TestListIssuesIncludePullRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"number": 2, "title": "Add login", "state": "open", "pull_request": {}}, {"number": 1, "title": "Login fails", "state": "open"}]`)
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	list, err := p.ListIssues(provider.ListFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Issues) != 1 || list.Issues[0].ID != "1" {
+		t.Errorf("Expected pull requests to be skipped, got %+v", list.Issues)
+	}
+
+	list, err = p.ListIssues(provider.ListFilter{IncludePullRequests: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Issues) != 2 || list.Issues[0].Type != provider.IssueTypePullRequest || list.Issues[1].Type == provider.IssueTypePullRequest {
+		t.Errorf("Expected the pull request listed with type pull_request, got %+v", list.Issues)
+	}
+}
+
+func TestGetIssuePullRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"number": 42, "title": "Add login", "state": "open", "pull_request": {}}`)
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	_, err := p.GetIssue("42")
+	if !errors.Is(err, provider.ErrPullRequest) {
+		t.Errorf("Expected ErrPullRequest, got: %v", err)
+	}
+}
+
+func TestGetPullRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		headRepo string
+		fromFork string
+	}{
+		{"same repository", `{"full_name": "Org/Repo"}`, "false"},
+		{"fork", `{"full_name": "alice/repo"}`, "true"},
+		{"deleted fork", `null`, "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/org/repo/pulls/42" {
+					t.Errorf("Unexpected path: %s", r.URL.Path)
+				}
+				fmt.Fprintf(w, `{"number": 42, "title": "Add login", "state": "closed", "merged": true,
+					"head": {"ref": "feat/login", "sha": "abc123", "repo": %s},
+					"base": {"ref": "main", "repo": {"full_name": "org/repo"}}}`, tt.headRepo)
+			}))
+			defer server.Close()
+
+			p := newTestProvider(t, server.URL)
+			issue, err := p.GetPullRequest("42")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if issue.Type != provider.IssueTypePullRequest || issue.Status != "merged" {
+				t.Errorf("Expected a merged pull_request, got type %q status %q", issue.Type, issue.Status)
+			}
+			if issue.Metadata["head_ref"] != "feat/login" || issue.Metadata["base_ref"] != "main" {
+				t.Errorf("Expected feat/login into main, got %v", issue.Metadata)
+			}
+			if issue.Metadata["from_fork"] != tt.fromFork {
+				t.Errorf("Expected from_fork %s, got %s", tt.fromFork, issue.Metadata["from_fork"])
+			}
+		})
+	}
+}
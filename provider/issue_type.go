@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Canonical issue types returned by InferIssueType
+const (
+	IssueTypeBug     = "bug"
+	IssueTypeFeature = "feature"
+	IssueTypeTask    = "task"
+	IssueTypeUnknown = "issue"
+)
+
+// issueTypeKeywords maps words found in types, labels and titles to canonical types
+var issueTypeKeywords = map[string]string{
+	"bug":         IssueTypeBug,
+	"bugfix":      IssueTypeBug,
+	"defect":      IssueTypeBug,
+	"regression":  IssueTypeBug,
+	"hotfix":      IssueTypeBug,
+	"crash":       IssueTypeBug,
+	"fix":         IssueTypeBug,
+	"feature":     IssueTypeFeature,
+	"feat":        IssueTypeFeature,
+	"enhancement": IssueTypeFeature,
+	"improvement": IssueTypeFeature,
+	"story":       IssueTypeFeature,
+	"epic":        IssueTypeFeature,
+	"proposal":    IssueTypeFeature,
+	"task":        IssueTypeTask,
+	"subtask":     IssueTypeTask,
+	"chore":       IssueTypeTask,
+	"maintenance": IssueTypeTask,
+}
+
+// titleKeywords are weaker signals only consulted for the title, in addition to issueTypeKeywords
+var titleKeywords = map[string]string{
+	"broken":    IssueTypeBug,
+	"error":     IssueTypeBug,
+	"fails":     IssueTypeBug,
+	"add":       IssueTypeFeature,
+	"implement": IssueTypeFeature,
+	"support":   IssueTypeFeature,
+	"introduce": IssueTypeFeature,
+	"refactor":  IssueTypeTask,
+	"cleanup":   IssueTypeTask,
+	"update":    IssueTypeTask,
+	"bump":      IssueTypeTask,
+}
+
+// InferIssueType classifies an issue as bug, feature or task by combining signals
+// in order of precedence:
+//
+//  1. The provider's native type field (Issue.Type, e.g. Jira issuetype or GitHub issue type)
+//  2. Labels, matched as whole words so "needs-triage" or "prefix" never count as a fix
+//  3. Keywords in the title, such as "fix", "crash" or "add"
+//
+// Within labels, bug outranks feature which outranks task. When no signal
+// matches, IssueTypeUnknown is returned.
+func InferIssueType(issue *Issue) string {
+	if issue == nil {
+		return IssueTypeUnknown
+	}
+
+	if t := classifyWords(issue.Type, issueTypeKeywords); t != "" {
+		return t
+	}
+
+	labelType := ""
+	for _, label := range issue.Labels {
+		if t := classifyWords(label, issueTypeKeywords); rankIssueType(t) > rankIssueType(labelType) {
+			labelType = t
+		}
+	}
+	if labelType != "" {
+		return labelType
+	}
+
+	// The first matching title word wins, e.g. "Add retry to fix flaky upload" is a feature
+	for _, word := range splitWords(issue.Title) {
+		if t, ok := issueTypeKeywords[word]; ok {
+			return t
+		}
+		if t, ok := titleKeywords[word]; ok {
+			return t
+		}
+	}
+
+	return IssueTypeUnknown
+}
+
+// BranchPrefixForType looks up the branch prefix for a canonical issue type,
+// falling back to the "default" prefix
+func BranchPrefixForType(prefixes map[string]string, issueType string) string {
+	if prefix, ok := LookupBranchPrefix(prefixes, issueType); ok {
+		return prefix
+	}
+	return prefixes["default"]
+}
+
+// LookupBranchPrefix returns the prefix configured for a canonical issue type,
+// accepting common alternative keys (e.g. Jira's "story" for features). It does
+// not fall back to the "default" prefix.
+func LookupBranchPrefix(prefixes map[string]string, issueType string) (string, bool) {
+	aliases := map[string][]string{
+		IssueTypeBug:     {"bug", "fix"},
+		IssueTypeFeature: {"feature", "story", "enhancement"},
+		IssueTypeTask:    {"task", "chore"},
+	}
+	for _, key := range aliases[issueType] {
+		if prefix, ok := prefixes[key]; ok {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// classifyWords returns the highest-ranked type whose keyword appears as a word in s
+func classifyWords(s string, keywords map[string]string) string {
+	best := ""
+	for _, word := range splitWords(s) {
+		if t, ok := keywords[word]; ok && rankIssueType(t) > rankIssueType(best) {
+			best = t
+		}
+	}
+	return best
+}
+
+// rankIssueType orders types for tie-breaking: bug > feature > task
+func rankIssueType(t string) int {
+	switch t {
+	case IssueTypeBug:
+		return 3
+	case IssueTypeFeature:
+		return 2
+	case IssueTypeTask:
+		return 1
+	}
+	return 0
+}
+
+// splitWords lowercases s and splits it on anything that is not a letter or digit
+func splitWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
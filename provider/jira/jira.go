@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/agoodway/workie/errcode"
 	"github.com/agoodway/workie/provider"
 )
 
@@ -18,6 +19,8 @@ type Provider struct {
 	apiToken     string
 	project      string
 	branchPrefix map[string]string
+	branchName   provider.BranchNameOptions
+	statusMap    provider.StatusMap
 }
 
 // NewProvider creates a new Jira provider
@@ -61,6 +64,12 @@ func NewProvider(config map[string]interface{}) (*Provider, error) {
 		}
 	}
 
+	// Branch name formatting
+	p.branchName = provider.BranchNameOptionsFromConfig(config)
+
+	// States of custom workflows, for status filters
+	p.statusMap = provider.StatusMapFromConfig(config)
+
 	return p, nil
 }
 
@@ -72,16 +81,16 @@ func (p *Provider) Name() string {
 // ValidateConfig checks if the provider is properly configured
 func (p *Provider) ValidateConfig() error {
 	if p.baseURL == "" {
-		return fmt.Errorf("Jira base URL not configured")
+		return errcode.Errorf(errcode.ProviderNotConfigured, "Jira base URL not configured")
 	}
 	if p.email == "" {
-		return fmt.Errorf("Jira email not configured (check email_env setting)")
+		return errcode.Errorf(errcode.ProviderNotConfigured, "Jira email not configured (check email_env setting)")
 	}
 	if p.apiToken == "" {
-		return fmt.Errorf("Jira API token not configured (check api_token_env setting)")
+		return errcode.Errorf(errcode.ProviderNotConfigured, "Jira API token not configured (check api_token_env setting)")
 	}
 	if p.project == "" {
-		return fmt.Errorf("Jira project key not configured")
+		return errcode.Errorf(errcode.ProviderNotConfigured, "Jira project key not configured")
 	}
 	return nil
 }
@@ -100,27 +109,25 @@ func (p *Provider) ListIssues(filter provider.ListFilter) (*provider.IssueList,
 	// Build JQL query
 	jql := fmt.Sprintf("project = %s", p.project)
 
-	// Status filter
-	if filter.Status != "" {
-		switch strings.ToLower(filter.Status) {
-		case "open":
-			jql += " AND status != Done AND status != Closed"
-		case "closed":
-			jql += " AND (status = Done OR status = Closed)"
-		case "in-progress":
-			jql += " AND status = 'In Progress'"
-		}
-	} else {
-		// Default to non-closed issues
-		jql += " AND status != Done AND status != Closed"
+	// Status filter, defaulting to non-closed issues
+	if condition := p.statusJQL(filter.Status); condition != "" {
+		jql += " AND " + condition
 	}
 
 	// Assignee filter
-	if filter.Assignee != "" {
-		if filter.Assignee == "me" {
-			jql += " AND assignee = currentUser()"
+	if len(filter.Assignees) > 0 {
+		assignees := make([]string, len(filter.Assignees))
+		for i, assignee := range filter.Assignees {
+			if assignee == "me" {
+				assignees[i] = "currentUser()"
+			} else {
+				assignees[i] = fmt.Sprintf("'%s'", assignee)
+			}
+		}
+		if len(assignees) == 1 {
+			jql += " AND assignee = " + assignees[0]
 		} else {
-			jql += fmt.Sprintf(" AND assignee = '%s'", filter.Assignee)
+			jql += fmt.Sprintf(" AND assignee IN (%s)", strings.Join(assignees, ", "))
 		}
 	}
 
@@ -199,6 +206,43 @@ func (p *Provider) ListIssues(filter provider.ListFilter) (*provider.IssueList,
 	}, nil
 }
 
+// statusJQL returns the JQL condition for a status filter. A status in
+// status_map matches its states; otherwise open (the default) excludes the
+// closed states, which status_map can also set, closed matches them and
+// in-progress matches "In Progress". Other statuses don't filter.
+func (p *Provider) statusJQL(status string) string {
+	if status == "" {
+		status = "open"
+	}
+	if states, ok := p.statusMap.States(status); ok {
+		return fmt.Sprintf("status IN (%s)", jqlList(states))
+	}
+
+	closed, ok := p.statusMap.States("closed")
+	if !ok {
+		closed = []string{"Done", "Closed"}
+	}
+	switch strings.ToLower(status) {
+	case "open":
+		return fmt.Sprintf("status NOT IN (%s)", jqlList(closed))
+	case "closed":
+		return fmt.Sprintf("status IN (%s)", jqlList(closed))
+	case "in-progress":
+		return `status = "In Progress"`
+	}
+	return ""
+}
+
+// jqlList quotes values as JQL strings and joins them for an IN clause
+func jqlList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+		quoted[i] = `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // GetIssue fetches a single Jira issue
 func (p *Provider) GetIssue(issueID string) (*provider.Issue, error) {
 	if err := p.ValidateConfig(); err != nil {
@@ -230,21 +274,7 @@ func (p *Provider) GetIssue(issueID string) (*provider.Issue, error) {
 
 // CreateBranchName generates a branch name based on the issue
 func (p *Provider) CreateBranchName(issue *provider.Issue) string {
-	prefix := p.branchPrefix["default"]
-
-	// Use issue type to determine prefix
-	issueTypeLower := strings.ToLower(issue.Type)
-	if strings.Contains(issueTypeLower, "bug") {
-		prefix = p.branchPrefix["bug"]
-	} else if strings.Contains(issueTypeLower, "story") {
-		prefix = p.branchPrefix["story"]
-	} else if strings.Contains(issueTypeLower, "task") {
-		prefix = p.branchPrefix["task"]
-	}
-
-	// Create branch name
-	title := provider.SanitizeBranchName(issue.Title)
-	return fmt.Sprintf("%s%s-%s", prefix, strings.ToLower(issue.ID), title)
+	return provider.CreateBranchNameWithOptions(p.branchPrefix, issue, p.branchName)
 }
 
 // makeRequest makes an HTTP request to the Jira API
@@ -276,7 +306,7 @@ func (p *Provider) makeRequest(method, url string, params map[string]string) (*h
 
 	if resp.StatusCode != http.StatusOK {
 		resp.Body.Close()
-		return nil, fmt.Errorf("Jira API returned status %d", resp.StatusCode)
+		return nil, errcode.Errorf(provider.ErrorCode(resp.StatusCode), "Jira API returned status %d", resp.StatusCode)
 	}
 
 	return resp, nil
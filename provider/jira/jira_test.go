@@ -0,0 +1,38 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/agoodway/workie/provider"
+)
+
+func TestStatusJQL(t *testing.T) {
+	p := &Provider{}
+	tests := map[string]string{
+		"":            `status NOT IN ("Done", "Closed")`,
+		"closed":      `status IN ("Done", "Closed")`,
+		"in-progress": `status = "In Progress"`,
+		"review":      "",
+	}
+	for status, want := range tests {
+		if got := p.statusJQL(status); got != want {
+			t.Errorf("statusJQL(%q) = %s, want %s", status, got, want)
+		}
+	}
+
+	p.statusMap = provider.StatusMap{
+		"in-progress": {"In Progress", "In Review"},
+		"review":      {"In Review"},
+		"closed":      {"Done", `Won't "Do"`},
+	}
+	tests = map[string]string{
+		"":            `status NOT IN ("Done", "Won't \"Do\"")`,
+		"Review":      `status IN ("In Review")`,
+		"in-progress": `status IN ("In Progress", "In Review")`,
+	}
+	for status, want := range tests {
+		if got := p.statusJQL(status); got != want {
+			t.Errorf("statusJQL(%q) = %s, want %s", status, got, want)
+		}
+	}
+}
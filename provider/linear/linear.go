@@ -2,13 +2,17 @@ package linear
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand/v2"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/agoodway/workie/errcode"
 	"github.com/agoodway/workie/provider"
 )
 
@@ -18,6 +22,8 @@ type Provider struct {
 	teamID       string
 	baseURL      string
 	branchPrefix map[string]string
+	branchName   provider.BranchNameOptions
+	statusMap    provider.StatusMap
 }
 
 // NewProvider creates a new Linear provider
@@ -54,6 +60,12 @@ func NewProvider(config map[string]interface{}) (*Provider, error) {
 		}
 	}
 
+	// Branch name formatting
+	p.branchName = provider.BranchNameOptionsFromConfig(config)
+
+	// State names and types of custom workflows, for status filters
+	p.statusMap = provider.StatusMapFromConfig(config)
+
 	return p, nil
 }
 
@@ -65,7 +77,7 @@ func (p *Provider) Name() string {
 // ValidateConfig checks if the provider is properly configured
 func (p *Provider) ValidateConfig() error {
 	if p.apiKey == "" {
-		return fmt.Errorf("Linear API key not configured (check api_key_env setting)")
+		return errcode.Errorf(errcode.ProviderNotConfigured, "Linear API key not configured (check api_key_env setting)")
 	}
 	return nil
 }
@@ -91,27 +103,13 @@ func (p *Provider) ListIssues(filter provider.ListFilter) (*provider.IssueList,
 	}
 
 	// Status filter
-	if filter.Status != "" {
-		switch strings.ToLower(filter.Status) {
-		case "open":
-			filterParts = append(filterParts, `state: { type: { in: ["backlog", "unstarted", "started"] } }`)
-		case "closed":
-			filterParts = append(filterParts, `state: { type: { in: ["completed", "canceled"] } }`)
-		case "in-progress":
-			filterParts = append(filterParts, `state: { type: { eq: "started" } }`)
-		}
-	} else {
-		// Default to non-completed issues
-		filterParts = append(filterParts, `state: { type: { nin: ["completed", "canceled"] } }`)
+	if stateFilter := linearStatusFilter(filter.Status, p.statusMap); stateFilter != "" {
+		filterParts = append(filterParts, "state: "+stateFilter)
 	}
 
 	// Assignee filter
-	if filter.Assignee != "" {
-		if filter.Assignee == "me" {
-			filterParts = append(filterParts, `assignee: { isMe: { eq: true } }`)
-		} else {
-			filterParts = append(filterParts, fmt.Sprintf(`assignee: { email: { eq: "%s" } }`, filter.Assignee))
-		}
+	if assigneeFilter := linearAssigneeFilter(filter.Assignees); assigneeFilter != "" {
+		filterParts = append(filterParts, "assignee: "+assigneeFilter)
 	}
 
 	// Labels filter
@@ -214,6 +212,82 @@ func (p *Provider) ListIssues(filter provider.ListFilter) (*provider.IssueList,
 	}, nil
 }
 
+// linearAssigneeFilter builds the user filter for issues assigned to any of the
+// given emails, where "me" is the API key's user. It returns "" for no assignees.
+func linearAssigneeFilter(assignees []string) string {
+	var isMe bool
+	var emails []string
+	for _, assignee := range assignees {
+		if assignee == "me" {
+			isMe = true
+		} else {
+			emails = append(emails, fmt.Sprintf("%q", assignee))
+		}
+	}
+
+	var filters []string
+	if isMe {
+		filters = append(filters, `{ isMe: { eq: true } }`)
+	}
+	switch len(emails) {
+	case 0:
+	case 1:
+		filters = append(filters, fmt.Sprintf(`{ email: { eq: %s } }`, emails[0]))
+	default:
+		filters = append(filters, fmt.Sprintf(`{ email: { in: [%s] } }`, strings.Join(emails, ", ")))
+	}
+
+	switch len(filters) {
+	case 0:
+		return ""
+	case 1:
+		return filters[0]
+	}
+	return fmt.Sprintf(`{ or: [%s] }`, strings.Join(filters, ", "))
+}
+
+// linearStateTypes are the workflow state types shared by all Linear teams
+var linearStateTypes = []string{"triage", "backlog", "unstarted", "started", "completed", "canceled"}
+
+// linearStatusFilter builds the workflow state filter for a status. A status in
+// status_map matches its states, given as state types (started) or names (In
+// Review); otherwise open, closed and in-progress match state types, and no
+// status excludes completed and canceled issues. Other statuses don't filter.
+func linearStatusFilter(status string, statusMap provider.StatusMap) string {
+	if states, ok := statusMap.States(status); ok {
+		var types, names []string
+		for _, state := range states {
+			if slices.Contains(linearStateTypes, strings.ToLower(state)) {
+				types = append(types, fmt.Sprintf("%q", strings.ToLower(state)))
+			} else {
+				names = append(names, fmt.Sprintf("%q", state))
+			}
+		}
+		switch {
+		case len(names) == 0:
+			return fmt.Sprintf(`{ type: { in: [%s] } }`, strings.Join(types, ", "))
+		case len(types) == 0:
+			return fmt.Sprintf(`{ name: { in: [%s] } }`, strings.Join(names, ", "))
+		}
+		return fmt.Sprintf(`{ or: [{ type: { in: [%s] } }, { name: { in: [%s] } }] }`, strings.Join(types, ", "), strings.Join(names, ", "))
+	}
+
+	switch strings.ToLower(status) {
+	case "":
+		if _, ok := statusMap.States("open"); ok {
+			return linearStatusFilter("open", statusMap)
+		}
+		return `{ type: { nin: ["completed", "canceled"] } }`
+	case "open":
+		return `{ type: { in: ["backlog", "unstarted", "started"] } }`
+	case "closed":
+		return `{ type: { in: ["completed", "canceled"] } }`
+	case "in-progress":
+		return `{ type: { eq: "started" } }`
+	}
+	return ""
+}
+
 // GetIssue fetches a single Linear issue
 func (p *Provider) GetIssue(issueID string) (*provider.Issue, error) {
 	if err := p.ValidateConfig(); err != nil {
@@ -282,32 +356,20 @@ func (p *Provider) GetIssue(issueID string) (*provider.Issue, error) {
 
 // CreateBranchName generates a branch name based on the issue
 func (p *Provider) CreateBranchName(issue *provider.Issue) string {
-	prefix := p.branchPrefix["default"]
-
-	// Determine prefix based on issue metadata
-	if stateType, ok := issue.Metadata["state_type"]; ok {
-		switch stateType {
-		case "backlog", "unstarted":
-			prefix = p.branchPrefix["feature"]
-		case "started":
-			prefix = p.branchPrefix["task"]
-		}
-	}
-
-	// Check labels for bug
-	for _, label := range issue.Labels {
-		if strings.Contains(strings.ToLower(label), "bug") {
-			prefix = p.branchPrefix["bug"]
-			break
-		}
-	}
-
-	// Create branch name
-	title := provider.SanitizeBranchName(issue.Title)
-	return fmt.Sprintf("%s%s-%s", prefix, strings.ToLower(issue.ID), title)
+	return provider.CreateBranchNameWithOptions(p.branchPrefix, issue, p.branchName)
 }
 
-// makeGraphQLRequest makes a GraphQL request to the Linear API
+// Retry policy for Linear API requests. Each attempt has its own 30s timeout and
+// all attempts, including the waits between them, share maxRequestDuration.
+var (
+	maxAttempts        = 3
+	retryBaseDelay     = 500 * time.Millisecond
+	maxRequestDuration = 90 * time.Second
+)
+
+// makeGraphQLRequest makes a GraphQL request to the Linear API. Connection errors
+// and 429/5xx responses are retried with exponential backoff; other 4xx responses
+// and GraphQL errors are returned right away.
 func (p *Provider) makeGraphQLRequest(query string, variables map[string]interface{}) ([]byte, error) {
 	requestBody := map[string]interface{}{
 		"query":     query,
@@ -319,9 +381,43 @@ func (p *Provider) makeGraphQLRequest(query string, variables map[string]interfa
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", p.baseURL, bytes.NewBuffer(jsonBody))
+	ctx, cancel := context.WithTimeout(context.Background(), maxRequestDuration)
+	defer cancel()
+
+	for attempt := 1; ; attempt++ {
+		body, retryable, err := p.postGraphQL(ctx, jsonBody)
+		if err == nil {
+			return body, nil
+		}
+		if !retryable || attempt >= maxAttempts {
+			return nil, err
+		}
+
+		timer := time.NewTimer(retryDelay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay returns the wait before retrying after the given attempt: the base
+// delay doubled for each earlier attempt, plus up to 50% random jitter
+func retryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay << (attempt - 1)
+	if jitter := int64(delay / 2); jitter > 0 {
+		delay += time.Duration(rand.Int64N(jitter))
+	}
+	return delay
+}
+
+// postGraphQL sends one GraphQL request and reports whether a failure is worth retrying
+func (p *Provider) postGraphQL(ctx context.Context, jsonBody []byte) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL, bytes.NewReader(jsonBody))
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	// Add headers
@@ -331,18 +427,19 @@ func (p *Provider) makeGraphQLRequest(query string, variables map[string]interfa
 	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("Linear API request failed: %w", err)
+		return nil, ctx.Err() == nil, fmt.Errorf("Linear API request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	var body bytes.Buffer
 	_, err = body.ReadFrom(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, ctx.Err() == nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Linear API returned status %d: %s", resp.StatusCode, body.String())
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return nil, retryable, errcode.Errorf(provider.ErrorCode(resp.StatusCode), "Linear API returned status %d: %s", resp.StatusCode, body.String())
 	}
 
 	// Check for GraphQL errors
@@ -352,10 +449,10 @@ func (p *Provider) makeGraphQLRequest(query string, variables map[string]interfa
 		} `json:"errors"`
 	}
 	if err := json.Unmarshal(body.Bytes(), &errorCheck); err == nil && len(errorCheck.Errors) > 0 {
-		return nil, fmt.Errorf("Linear GraphQL error: %s", errorCheck.Errors[0].Message)
+		return nil, false, fmt.Errorf("Linear GraphQL error: %s", errorCheck.Errors[0].Message)
 	}
 
-	return body.Bytes(), nil
+	return body.Bytes(), false, nil
 }
 
 // convertIssue converts a Linear issue to a provider issue
@@ -365,19 +462,6 @@ func (p *Provider) convertIssue(linearIssue linearIssue) provider.Issue {
 		labels[i] = label.Name
 	}
 
-	// Determine issue type based on state and labels
-	issueType := "issue"
-	for _, label := range labels {
-		labelLower := strings.ToLower(label)
-		if strings.Contains(labelLower, "bug") {
-			issueType = "bug"
-			break
-		} else if strings.Contains(labelLower, "feature") {
-			issueType = "feature"
-			break
-		}
-	}
-
 	metadata := map[string]string{
 		"created_at": linearIssue.CreatedAt,
 		"updated_at": linearIssue.UpdatedAt,
@@ -391,17 +475,21 @@ func (p *Provider) convertIssue(linearIssue linearIssue) provider.Issue {
 		metadata["assignee"] = linearIssue.Assignee.Name
 	}
 
-	return provider.Issue{
+	issue := provider.Issue{
 		ID:          linearIssue.Identifier,
 		Title:       linearIssue.Title,
 		Description: linearIssue.Description,
-		Type:        issueType,
 		Status:      linearIssue.State.Name,
 		Labels:      labels,
 		URL:         linearIssue.URL,
 		Provider:    "linear",
 		Metadata:    metadata,
 	}
+
+	// Linear has no native issue type, so infer it from labels and title
+	issue.Type = provider.InferIssueType(&issue)
+
+	return issue
 }
 
 // Linear API types
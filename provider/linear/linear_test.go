@@ -0,0 +1,154 @@
+package linear
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agoodway/workie/provider"
+)
+
+// newTestProvider creates a provider that talks to the given test server
+func newTestProvider(t *testing.T, serverURL string) *Provider {
+	t.Helper()
+	t.Setenv("WORKIE_TEST_LINEAR_KEY", "lin_api_test")
+	p, err := NewProvider(map[string]interface{}{
+		"settings": map[string]interface{}{"api_key_env": "WORKIE_TEST_LINEAR_KEY"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.baseURL = serverURL
+
+	retryBaseDelay = time.Millisecond
+	t.Cleanup(func() { retryBaseDelay = 500 * time.Millisecond })
+	return p
+}
+
+func TestGetIssueRetriesTransientFailures(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") != "lin_api_test" {
+			t.Errorf("Expected the API key to be sent, got %q", r.Header.Get("Authorization"))
+		}
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"data": {"issue": {"identifier": "ENG-42", "title": "Fix flaky sync",
+				"url": "https://linear.app/acme/issue/ENG-42", "state": {"name": "Todo", "type": "unstarted"}}}}`))
+		}
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	issue, err := p.GetIssue("ENG-42")
+	if err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got: %v", err)
+	}
+	if issue.ID != "ENG-42" || issue.Title != "Fix flaky sync" {
+		t.Errorf("Unexpected issue: %+v", issue)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestGetIssueGivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	if _, err := p.GetIssue("ENG-42"); err == nil || !strings.Contains(err.Error(), "status 503") {
+		t.Errorf("Expected the last 503 to be returned, got: %v", err)
+	}
+	if calls != maxAttempts {
+		t.Errorf("Expected %d attempts, got %d", maxAttempts, calls)
+	}
+}
+
+func TestGetIssueDoesNotRetryClientErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, body: `{}`, wantErr: "status 401"},
+		{name: "GraphQL error", status: http.StatusOK, body: `{"errors": [{"message": "Entity not found"}]}`, wantErr: "Entity not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			p := newTestProvider(t, server.URL)
+			if _, err := p.GetIssue("ENG-42"); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got: %v", tt.wantErr, err)
+			}
+			if calls != 1 {
+				t.Errorf("Expected a single attempt, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestLinearAssigneeFilter(t *testing.T) {
+	tests := []struct {
+		assignees []string
+		want      string
+	}{
+		{nil, ""},
+		{[]string{"me"}, `{ isMe: { eq: true } }`},
+		{[]string{"alice@example.com"}, `{ email: { eq: "alice@example.com" } }`},
+		{[]string{"alice@example.com", "bob@example.com"}, `{ email: { in: ["alice@example.com", "bob@example.com"] } }`},
+		{[]string{"me", "alice@example.com"}, `{ or: [{ isMe: { eq: true } }, { email: { eq: "alice@example.com" } }] }`},
+		{[]string{`a" } }, { id: { neq: "x`}, `{ email: { eq: "a\" } }, { id: { neq: \"x" } }`},
+	}
+	for _, tt := range tests {
+		if got := linearAssigneeFilter(tt.assignees); got != tt.want {
+			t.Errorf("linearAssigneeFilter(%v) = %s, want %s", tt.assignees, got, tt.want)
+		}
+	}
+}
+
+func TestLinearStatusFilter(t *testing.T) {
+	statusMap := provider.StatusMap{
+		"review":  {"In Review"},
+		"active":  {"started", "In Review"},
+		"backlog": {"Backlog"},
+	}
+	tests := []struct {
+		status    string
+		statusMap provider.StatusMap
+		want      string
+	}{
+		{"", nil, `{ type: { nin: ["completed", "canceled"] } }`},
+		{"in-progress", nil, `{ type: { eq: "started" } }`},
+		{"unknown", nil, ""},
+		{"Review", statusMap, `{ name: { in: ["In Review"] } }`},
+		{"active", statusMap, `{ or: [{ type: { in: ["started"] } }, { name: { in: ["In Review"] } }] }`},
+		{"backlog", statusMap, `{ type: { in: ["backlog"] } }`},
+		{"", provider.StatusMap{"open": {"Todo", "Doing"}}, `{ name: { in: ["Todo", "Doing"] } }`},
+	}
+	for _, tt := range tests {
+		if got := linearStatusFilter(tt.status, tt.statusMap); got != tt.want {
+			t.Errorf("linearStatusFilter(%q) = %s, want %s", tt.status, got, tt.want)
+		}
+	}
+}
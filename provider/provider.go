@@ -1,8 +1,16 @@
 package provider
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/agoodway/workie/errcode"
 )
 
 // Issue represents a single issue from any provider
@@ -14,7 +22,7 @@ type Issue struct {
 	Status      string            // Current status
 	Labels      []string          // Labels/tags
 	URL         string            // Web URL to the issue
-	Provider    string            // Provider name (github, jira, linear)
+	Provider    string            // Provider name (github, jira, linear, bitbucket)
 	Metadata    map[string]string // Provider-specific metadata
 }
 
@@ -47,21 +55,67 @@ type Provider interface {
 	IsConfigured() bool
 }
 
+// PullRequest describes a pull request to be opened
+type PullRequest struct {
+	Title string // Pull request title
+	Body  string // Pull request description
+	Head  string // Branch containing the changes
+	Base  string // Branch the changes should be merged into
+	Draft bool   // Open as a draft pull request
+
+	// Reviewers to request; entries of the form "org/team" request a team
+	Reviewers []string
+}
+
+// PullRequestInfo describes a pull request that was opened
+type PullRequestInfo struct {
+	ID  string // Provider-specific ID (e.g., "42" for GitHub)
+	URL string // Web URL to the pull request
+}
+
+// PullRequestCreator is implemented by providers that can open pull requests
+// (e.g., GitHub). It is optional; use a type assertion to check for support.
+type PullRequestCreator interface {
+	// CreatePullRequest opens a pull request and returns its details
+	CreatePullRequest(pr PullRequest) (*PullRequestInfo, error)
+}
+
+// IssueTypePullRequest is the Type of a pull request listed or fetched as an issue
+const IssueTypePullRequest = "pull_request"
+
+// ErrPullRequest is returned, wrapped, by GetIssue for an ID that belongs to a
+// pull request, so callers can fetch it with GetPullRequest instead
+var ErrPullRequest = errors.New("is a pull request, not an issue")
+
+// PullRequestFetcher is implemented by providers that can fetch pull requests as
+// issues, for reviewing them in a worktree (e.g., GitHub). It is optional; use a
+// type assertion to check for support.
+type PullRequestFetcher interface {
+	// GetPullRequest fetches a pull request as an issue of type pull_request.
+	// Metadata["head_ref"] is the branch with its changes, and
+	// Metadata["from_fork"] is "true" when that branch is not in the repository.
+	GetPullRequest(number string) (*Issue, error)
+}
+
 // ListFilter defines filtering options for listing issues
 type ListFilter struct {
-	Status   string   // Filter by status (open, closed, in-progress, etc.)
-	Assignee string   // Filter by assignee
-	Labels   []string // Filter by labels
-	Type     string   // Filter by issue type
-	Limit    int      // Maximum number of issues to return
-	Cursor   string   // Pagination cursor
-	Query    string   // Free-text search query
+	Status    string   // Filter by status (open, closed, in-progress, etc.)
+	Assignees []string // Filter by assignee, matching any of them; "me" is the authenticated user
+	Labels    []string // Filter by labels
+	Type      string   // Filter by issue type
+	Limit     int      // Maximum number of issues to return
+	Cursor    string   // Pagination cursor
+	Query     string   // Free-text search query
+
+	// Also list pull requests, as issues of type pull_request, from providers
+	// that keep them with issues (GitHub)
+	IncludePullRequests bool
 }
 
 // ProviderConfig represents configuration for a provider
 type ProviderConfig struct {
 	Enabled      bool                   `yaml:"enabled"`
-	Type         string                 `yaml:"type"` // github, jira, linear
+	Type         string                 `yaml:"type"` // github, jira, linear, bitbucket
 	BranchPrefix map[string]string      `yaml:"branch_prefix,omitempty"`
 	Settings     map[string]interface{} `yaml:"settings,omitempty"`
 }
@@ -117,6 +171,140 @@ func (r *Registry) ListConfigured() []string {
 	return names
 }
 
+// ListIssuesUpTo fetches pages from the provider, following NextCursor, until
+// filter.Limit issues are collected or there are no more. Each request asks only
+// for the issues still needed, and the result never exceeds the limit. A limit
+// of zero or less fetches a single page.
+func ListIssuesUpTo(p Provider, filter ListFilter) (*IssueList, error) {
+	limit := filter.Limit
+	result := &IssueList{Issues: []Issue{}}
+	seen := make(map[string]bool)
+
+	for {
+		if limit > 0 {
+			filter.Limit = limit - len(result.Issues)
+		}
+
+		page, err := p.ListIssues(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Issues = append(result.Issues, page.Issues...)
+		result.HasMore = page.HasMore
+		result.NextCursor = page.NextCursor
+		result.TotalCount = max(result.TotalCount, page.TotalCount)
+
+		if limit > 0 && len(result.Issues) >= limit {
+			result.HasMore = result.HasMore || len(result.Issues) > limit
+			result.Issues = result.Issues[:limit]
+			break
+		}
+
+		// Stop at the last page, or if the provider does not advance its cursor
+		if limit <= 0 || !page.HasMore || page.NextCursor == "" || seen[page.NextCursor] {
+			break
+		}
+		seen[page.NextCursor] = true
+		filter.Cursor = page.NextCursor
+	}
+
+	result.TotalCount = max(result.TotalCount, len(result.Issues))
+	return result, nil
+}
+
+// Retry policy for ListIssuesFrom: a failing provider is tried listAttempts
+// times, waiting listRetryDelay before the first retry and twice as long before
+// each one after that
+const listAttempts = 3
+
+var listRetryDelay = time.Second
+
+// maxConcurrentProviders bounds how many providers ListIssuesFrom queries at once
+const maxConcurrentProviders = 4
+
+// ProviderResult reports how listing issues went for one provider
+type ProviderResult struct {
+	Provider string
+	Count    int   // Issues returned
+	Err      error // Why the provider failed, or nil
+}
+
+// ListIssuesFrom lists issues from each named provider, querying up to
+// maxConcurrentProviders of them at a time. A provider that fails is retried
+// with exponential backoff; if it still fails its error is recorded in the
+// results and the other providers' issues are still returned, so callers can
+// tell an incomplete list from an empty one. Issues and results are in the
+// order of names, however long each provider takes.
+func (r *Registry) ListIssuesFrom(names []string, filter ListFilter) ([]Issue, []ProviderResult) {
+	lists := make([][]Issue, len(names))
+	results := make([]ProviderResult, len(names))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxConcurrentProviders)
+	for i, name := range names {
+		results[i].Provider = name
+
+		p, err := r.Get(name)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, p Provider) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			list, err := listIssuesWithRetry(p, filter)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			lists[i] = list.Issues
+			results[i].Count = len(list.Issues)
+		}(i, p)
+	}
+	wg.Wait()
+
+	issues := make([]Issue, 0)
+	for _, list := range lists {
+		issues = append(issues, list...)
+	}
+	return issues, results
+}
+
+// listIssuesWithRetry calls ListIssuesUpTo, retrying failures with exponential backoff
+func listIssuesWithRetry(p Provider, filter ListFilter) (*IssueList, error) {
+	delay := listRetryDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		var list *IssueList
+		if list, err = ListIssuesUpTo(p, filter); err == nil {
+			return list, nil
+		}
+		if attempt == listAttempts {
+			return nil, err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// ErrorCode classifies an unsuccessful HTTP status from a provider's API
+func ErrorCode(status int) errcode.Code {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errcode.ProviderAuth
+	case http.StatusNotFound:
+		return errcode.NotFound
+	case http.StatusTooManyRequests:
+		return errcode.ProviderRateLimited
+	}
+	return errcode.Unknown
+}
+
 // ParseIssueReference parses a reference like "github:123" or "jira:PROJ-123"
 func ParseIssueReference(ref string) (provider, issueID string, err error) {
 	parts := strings.SplitN(ref, ":", 2)
@@ -134,61 +322,132 @@ func ParseIssueReference(ref string) (provider, issueID string, err error) {
 	return provider, issueID, nil
 }
 
+// ResolveIssueReference finds the provider and issue ID an issue reference
+// refers to. A "provider:id" reference names its provider. A bare ID uses, in
+// order, flagProvider (a --provider flag), defaultProvider (default_provider in
+// the config) and the only configured provider; with several configured
+// providers and neither set, the reference is ambiguous.
+func (r *Registry) ResolveIssueReference(ref, flagProvider, defaultProvider string) (Provider, string, error) {
+	configured := r.ListConfigured()
+	sort.Strings(configured)
+	if len(configured) == 0 {
+		return nil, "", fmt.Errorf("no issue providers are configured\n\nTo fix this:\n  • Configure a provider under providers in your .workie.yaml")
+	}
+
+	name, issueID, source := "", strings.TrimSpace(ref), ""
+	switch {
+	case strings.Contains(ref, ":"):
+		var err error
+		if name, issueID, err = ParseIssueReference(ref); err != nil {
+			return nil, "", err
+		}
+		source = "in '" + ref + "'"
+	case flagProvider != "":
+		name, source = strings.ToLower(flagProvider), "by --provider"
+	case defaultProvider != "":
+		name, source = strings.ToLower(defaultProvider), "as default_provider"
+	case len(configured) == 1:
+		name = configured[0]
+	default:
+		return nil, "", errcode.Errorf(errcode.ProviderAmbiguous, "issue '%s' is ambiguous: several providers are configured (%s)\n\nTo fix this:\n  • Name the provider: %s:%s\n  • Or pass --provider\n  • Or set default_provider in .workie.yaml", ref, strings.Join(configured, ", "), configured[0], issueID)
+	}
+	if issueID == "" {
+		return nil, "", fmt.Errorf("invalid issue reference: ID cannot be empty")
+	}
+
+	p, err := r.Get(name)
+	if err != nil || !p.IsConfigured() {
+		return nil, "", errcode.Errorf(errcode.ProviderNotConfigured, "provider '%s' named %s is not configured\n\nTo fix this:\n  • Use one of the configured providers: %s\n  • Or enable and configure %s under providers in your .workie.yaml", name, source, strings.Join(configured, ", "), name)
+	}
+	return p, issueID, nil
+}
+
+// branchIssueIDPattern matches an issue ID at the start of a branch name segment,
+// either numeric ("123") or key-based ("PROJ-456", "eng-42"). Numbers are capped at
+// 7 digits so timestamps in auto-generated names (feature/work-20060102-150405) don't match.
+var branchIssueIDPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9]*-[0-9]{1,7}|[0-9]{1,7})(?:-|$)`)
+
+// IssueIDFromBranch extracts the issue ID from a branch name created from an issue,
+// e.g. "fix/123-login-error" → "123" and "feat/proj-456-dark-mode" → "PROJ-456".
+// It returns an empty string when the branch does not contain an issue ID.
+func IssueIDFromBranch(branchName string) string {
+	segment := branchName
+	if i := strings.LastIndex(segment, "/"); i >= 0 {
+		segment = segment[i+1:]
+	}
+
+	match := branchIssueIDPattern.FindStringSubmatch(segment)
+	if match == nil {
+		return ""
+	}
+	return strings.ToUpper(match[1])
+}
+
+// SanitizeOptions controls how SanitizeBranchNameWithOptions cleans up a name.
+// The zero value gives SanitizeBranchName's behavior.
+type SanitizeOptions struct {
+	Separator    string // Replaces spaces and special characters (default: "-")
+	MaxLength    int    // Longest result in bytes (default: 63)
+	PreserveCase bool   // Keep letter case instead of lowercasing
+}
+
+// branchNameSpecialChars are replaced by the separator when sanitizing
+var branchNameSpecialChars = []string{
+	" ", "/", "\\", ":", "*", "?", "\"", "<", ">", "|", ".", ",", ";", "'", "`",
+	"~", "!", "@", "#", "$", "%", "^", "&", "(", ")", "[", "]", "{", "}", "=", "+",
+}
+
+// separator returns the configured separator or the default hyphen
+func (o SanitizeOptions) separator() string {
+	if o.Separator == "" {
+		return "-"
+	}
+	return o.Separator
+}
+
 // SanitizeBranchName cleans up a string to be safe for use as a git branch name
 func SanitizeBranchName(name string) string {
-	// Replace spaces and special characters with hyphens
-	replacer := strings.NewReplacer(
-		" ", "-",
-		"/", "-",
-		"\\", "-",
-		":", "-",
-		"*", "-",
-		"?", "-",
-		"\"", "-",
-		"<", "-",
-		">", "-",
-		"|", "-",
-		".", "-",
-		",", "-",
-		";", "-",
-		"'", "-",
-		"`", "-",
-		"~", "-",
-		"!", "-",
-		"@", "-",
-		"#", "-",
-		"$", "-",
-		"%", "-",
-		"^", "-",
-		"&", "-",
-		"(", "-",
-		")", "-",
-		"[", "-",
-		"]", "-",
-		"{", "-",
-		"}", "-",
-		"=", "-",
-		"+", "-",
-	)
-
-	name = replacer.Replace(name)
-
-	// Replace multiple consecutive hyphens with a single hyphen
-	for strings.Contains(name, "--") {
-		name = strings.ReplaceAll(name, "--", "-")
-	}
-
-	// Remove leading and trailing hyphens
-	name = strings.Trim(name, "-")
-
-	// Convert to lowercase
-	name = strings.ToLower(name)
-
-	// Limit length to 63 characters (git branch name limit is 255, but let's be conservative)
-	if len(name) > 63 {
-		name = name[:63]
-		// Remove trailing hyphen if truncation created one
-		name = strings.TrimRight(name, "-")
+	return SanitizeBranchNameWithOptions(name, SanitizeOptions{})
+}
+
+// SanitizeBranchNameWithOptions cleans up a string to be safe for use as a git
+// branch name, with a configurable separator, length limit and casing
+func SanitizeBranchNameWithOptions(name string, opts SanitizeOptions) string {
+	sep := opts.separator()
+
+	// Replace spaces and special characters with the separator. With another
+	// separator, hyphens are replaced too so the result is consistent.
+	chars := branchNameSpecialChars
+	if sep != "-" {
+		chars = append(chars[:len(chars):len(chars)], "-")
+	}
+	pairs := make([]string, 0, 2*len(chars))
+	for _, char := range chars {
+		pairs = append(pairs, char, sep)
+	}
+	name = strings.NewReplacer(pairs...).Replace(name)
+
+	// Replace multiple consecutive separators with a single one
+	for strings.Contains(name, sep+sep) {
+		name = strings.ReplaceAll(name, sep+sep, sep)
+	}
+
+	// Remove leading and trailing separators
+	name = strings.Trim(name, sep)
+
+	if !opts.PreserveCase {
+		name = strings.ToLower(name)
+	}
+
+	// Limit length to 63 characters by default (git branch name limit is 255, but let's be conservative)
+	maxLength := opts.MaxLength
+	if maxLength <= 0 {
+		maxLength = 63
+	}
+	if len(name) > maxLength {
+		name = name[:maxLength]
+		// Remove trailing separator if truncation created one
+		name = strings.TrimRight(name, sep)
 	}
 
 	return name
@@ -1,7 +1,13 @@
 package provider
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestParseIssueReference(t *testing.T) {
@@ -153,6 +159,74 @@ func TestSanitizeBranchName(t *testing.T) {
 	}
 }
 
+func TestSanitizeBranchNameWithOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     SanitizeOptions
+		expected string
+	}{
+		{
+			name:     "Underscore separator",
+			input:    "Fix bug in login-form",
+			opts:     SanitizeOptions{Separator: "_"},
+			expected: "fix_bug_in_login_form",
+		},
+		{
+			name:     "Preserved case",
+			input:    "Support OAuth2 in API",
+			opts:     SanitizeOptions{PreserveCase: true},
+			expected: "Support-OAuth2-in-API",
+		},
+		{
+			name:     "Max length trims the trailing separator",
+			input:    "Add dark mode toggle",
+			opts:     SanitizeOptions{Separator: "_", MaxLength: 9},
+			expected: "add_dark",
+		},
+		{
+			name:     "Zero value matches SanitizeBranchName",
+			input:    "Fix trailing hyphens---",
+			expected: "fix-trailing-hyphens",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SanitizeBranchNameWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("SanitizeBranchNameWithOptions(%q, %+v) = %q, want %q", tt.input, tt.opts, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCreateBranchNameWithOptions(t *testing.T) {
+	issue := &Issue{ID: "PROJ-456", Title: "Login fails on Safari", Type: "bug"}
+
+	if got := CreateBranchName(DefaultBranchPrefixes, issue); got != "fix/proj-456-login-fails-on-safari" {
+		t.Errorf("Expected the default name to lowercase the ID, got %s", got)
+	}
+
+	opts := BranchNameOptionsFromConfig(map[string]interface{}{
+		"branch_name": map[string]interface{}{
+			"separator":        "_",
+			"preserve_id_case": true,
+		},
+	})
+	if got := CreateBranchNameWithOptions(DefaultBranchPrefixes, issue, opts); got != "fix/PROJ-456_login_fails_on_safari" {
+		t.Errorf("Expected the ID's case to be preserved with an underscore separator, got %s", got)
+	}
+
+	// Separators that aren't valid in branch names are ignored
+	opts = BranchNameOptionsFromConfig(map[string]interface{}{
+		"branch_name": map[string]interface{}{"separator": " "},
+	})
+	if opts.Separator != "" {
+		t.Errorf("Expected an invalid separator to be ignored, got %q", opts.Separator)
+	}
+}
+
 func TestRegistry(t *testing.T) {
 	t.Run("Register and Get providers", func(t *testing.T) {
 		registry := NewRegistry()
@@ -250,3 +324,335 @@ func (m *mockProvider) ValidateConfig() error {
 func (m *mockProvider) IsConfigured() bool {
 	return m.configured
 }
+
+// flakyProvider fails the first `failures` calls to ListIssues
+type flakyProvider struct {
+	mockProvider
+	failures int
+	calls    int
+}
+
+func (f *flakyProvider) ListIssues(filter ListFilter) (*IssueList, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, errors.New("service unavailable")
+	}
+	return &IssueList{Issues: []Issue{{ID: "1", Provider: f.name}}}, nil
+}
+
+func TestListIssuesFrom(t *testing.T) {
+	listRetryDelay = 0
+
+	registry := NewRegistry()
+	flaky := &flakyProvider{mockProvider: mockProvider{name: "flaky", configured: true}, failures: 1}
+	down := &flakyProvider{mockProvider: mockProvider{name: "down", configured: true}, failures: 10}
+	for _, p := range []Provider{flaky, down} {
+		if err := registry.Register(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	issues, results := registry.ListIssuesFrom([]string{"flaky", "down", "missing"}, ListFilter{})
+	if len(issues) != 1 || issues[0].Provider != "flaky" {
+		t.Errorf("Expected the retried provider's issue, got %v", issues)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected a result per provider, got %v", results)
+	}
+	if results[0].Err != nil || results[0].Count != 1 {
+		t.Errorf("Expected flaky to succeed on retry, got %+v", results[0])
+	}
+	if results[1].Err == nil || down.calls != listAttempts {
+		t.Errorf("Expected down to fail after %d attempts, got %+v after %d calls", listAttempts, results[1], down.calls)
+	}
+	if results[2].Err == nil {
+		t.Errorf("Expected an unknown provider to be reported as failed")
+	}
+}
+
+// blockingProvider returns its issue once every provider sharing started has begun listing
+type blockingProvider struct {
+	mockProvider
+	started *sync.WaitGroup
+}
+
+func (b *blockingProvider) ListIssues(filter ListFilter) (*IssueList, error) {
+	b.started.Done()
+	b.started.Wait()
+	return &IssueList{Issues: []Issue{{ID: "1", Provider: b.name}}}, nil
+}
+
+func TestListIssuesFromConcurrently(t *testing.T) {
+	// Each provider waits for the others, so querying them one at a time would hang
+	registry := NewRegistry()
+	var started sync.WaitGroup
+	names := []string{"a", "b", "c"}
+	started.Add(len(names))
+	for _, name := range names {
+		if err := registry.Register(&blockingProvider{mockProvider: mockProvider{name: name, configured: true}, started: &started}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	done := make(chan []Issue)
+	go func() {
+		issues, _ := registry.ListIssuesFrom(names, ListFilter{})
+		done <- issues
+	}()
+
+	select {
+	case issues := <-done:
+		var got []string
+		for _, issue := range issues {
+			got = append(got, issue.Provider)
+		}
+		if strings.Join(got, ",") != "a,b,c" {
+			t.Errorf("Expected issues in provider order, got %v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected providers to be queried concurrently")
+	}
+}
+
+func TestInferIssueType(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue *Issue
+		want  string
+	}{
+		{"native type wins over labels", &Issue{Type: "Story", Labels: []string{"bug"}}, IssueTypeFeature},
+		{"jira sub-task", &Issue{Type: "Sub-task"}, IssueTypeTask},
+		{"jira new feature", &Issue{Type: "New Feature"}, IssueTypeFeature},
+		{"unrecognized native type falls through to labels", &Issue{Type: "Spike", Labels: []string{"bug"}}, IssueTypeBug},
+		{"bug label alongside triage label", &Issue{Labels: []string{"needs-triage", "bug"}}, IssueTypeBug},
+		{"bug outranks feature in labels", &Issue{Labels: []string{"enhancement", "type: bug"}}, IssueTypeBug},
+		{"labels match whole words only", &Issue{Labels: []string{"prefix-handling", "debugging"}, Title: "Something"}, IssueTypeUnknown},
+		{"labels win over title", &Issue{Labels: []string{"enhancement"}, Title: "Fix login"}, IssueTypeFeature},
+		{"title keyword", &Issue{Title: "Crash when opening settings"}, IssueTypeBug},
+		{"first title keyword wins", &Issue{Title: "Add retry to fix flaky upload"}, IssueTypeFeature},
+		{"conventional commit style title", &Issue{Title: "chore: bump dependencies"}, IssueTypeTask},
+		{"no signals", &Issue{Title: "Investigate performance"}, IssueTypeUnknown},
+		{"nil issue", nil, IssueTypeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InferIssueType(tt.issue); got != tt.want {
+				t.Errorf("InferIssueType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchPrefixForType(t *testing.T) {
+	jira := map[string]string{"bug": "fix/", "story": "feat/", "task": "task/", "default": "issue/"}
+	github := map[string]string{"bug": "fix/", "feature": "feat/", "default": "issue/"}
+
+	tests := []struct {
+		prefixes  map[string]string
+		issueType string
+		want      string
+	}{
+		{jira, IssueTypeFeature, "feat/"},
+		{jira, IssueTypeTask, "task/"},
+		{github, IssueTypeBug, "fix/"},
+		{github, IssueTypeTask, "issue/"},
+		{github, IssueTypeUnknown, "issue/"},
+	}
+
+	for _, tt := range tests {
+		if got := BranchPrefixForType(tt.prefixes, tt.issueType); got != tt.want {
+			t.Errorf("BranchPrefixForType(%v, %q) = %q, want %q", tt.prefixes, tt.issueType, got, tt.want)
+		}
+	}
+}
+
+func TestCreateBranchName(t *testing.T) {
+	tests := []struct {
+		issue Issue
+		want  string
+	}{
+		{Issue{ID: "123", Title: "Login fails on Safari", Type: "bug"}, "fix/123-login-fails-on-safari"},
+		{Issue{ID: "PROJ-456", Title: "Dark mode", Type: "story"}, "feat/proj-456-dark-mode"},
+		{Issue{Title: "Add dark mode"}, "feat/add-dark-mode"},
+		{Issue{Title: "Quarterly report"}, "issue/quarterly-report"},
+	}
+
+	for _, tt := range tests {
+		if got := CreateBranchName(DefaultBranchPrefixes, &tt.issue); got != tt.want {
+			t.Errorf("CreateBranchName(%+v) = %q, want %q", tt.issue, got, tt.want)
+		}
+	}
+}
+
+func TestIssueIDFromBranch(t *testing.T) {
+	tests := map[string]string{
+		"fix/123-login-error":          "123",
+		"feat/proj-456-dark-mode":      "PROJ-456",
+		"issue/ENG-42":                 "ENG-42",
+		"123-no-prefix":                "123",
+		"feature/new-ui":               "",
+		"feature/v2-redesign":          "",
+		"feature/work-20250101-120000": "",
+		"team/alice/fix/77-timeout":    "77",
+	}
+	for branch, want := range tests {
+		if got := IssueIDFromBranch(branch); got != want {
+			t.Errorf("IssueIDFromBranch(%q) = %q, want %q", branch, got, want)
+		}
+	}
+}
+
+// pagedProvider serves total issues in pages of at most pageSize, using the
+// offset as the cursor, and records the limit of each request
+type pagedProvider struct {
+	mockProvider
+	total    int
+	pageSize int
+	limits   []int
+}
+
+func (m *pagedProvider) ListIssues(filter ListFilter) (*IssueList, error) {
+	m.limits = append(m.limits, filter.Limit)
+
+	start := 0
+	if filter.Cursor != "" {
+		start, _ = strconv.Atoi(filter.Cursor)
+	}
+	size := m.pageSize
+	if filter.Limit > 0 && filter.Limit < size {
+		size = filter.Limit
+	}
+	end := min(start+size, m.total)
+
+	list := &IssueList{TotalCount: m.total, HasMore: end < m.total}
+	for i := start; i < end; i++ {
+		list.Issues = append(list.Issues, Issue{ID: strconv.Itoa(i)})
+	}
+	if list.HasMore {
+		list.NextCursor = strconv.Itoa(end)
+	}
+	return list, nil
+}
+
+func TestListIssuesUpTo(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int
+		limit      int
+		wantIssues int
+		wantLimits []int
+		wantMore   bool
+	}{
+		{"limit spans pages", 200, 120, 120, []int{120, 70, 20}, true},
+		{"fewer issues than limit", 60, 120, 60, []int{120, 70}, false},
+		{"limit within first page", 200, 10, 10, []int{10}, true},
+		{"no limit fetches one page", 200, 0, 50, []int{0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &pagedProvider{total: tt.total, pageSize: 50}
+			list, err := ListIssuesUpTo(p, ListFilter{Limit: tt.limit})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(list.Issues) != tt.wantIssues {
+				t.Errorf("Expected %d issues, got %d", tt.wantIssues, len(list.Issues))
+			}
+			if fmt.Sprint(p.limits) != fmt.Sprint(tt.wantLimits) {
+				t.Errorf("Expected requests with limits %v, got %v", tt.wantLimits, p.limits)
+			}
+			if list.HasMore != tt.wantMore {
+				t.Errorf("Expected HasMore=%v, got %v", tt.wantMore, list.HasMore)
+			}
+			for i, issue := range list.Issues {
+				if issue.ID != strconv.Itoa(i) {
+					t.Fatalf("Expected issue %d in order, got %s", i, issue.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveIssueReference(t *testing.T) {
+	registry := NewRegistry()
+	for _, p := range []Provider{
+		&mockProvider{name: "github", configured: true},
+		&mockProvider{name: "jira", configured: true},
+		&mockProvider{name: "linear", configured: false},
+	} {
+		if err := registry.Register(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		ref             string
+		flagProvider    string
+		defaultProvider string
+		wantProvider    string
+		wantID          string
+		wantErr         string
+	}{
+		{"explicit provider wins", "jira:PROJ-1", "github", "github", "jira", "PROJ-1", ""},
+		{"flag before default", "123", "GitHub", "jira", "github", "123", ""},
+		{"default provider", "PROJ-1", "", "jira", "jira", "PROJ-1", ""},
+		{"ambiguous", "123", "", "", "", "", "ambiguous"},
+		{"unconfigured provider", "linear:ENG-1", "", "", "", "", "'linear' named in 'linear:ENG-1' is not configured"},
+		{"unknown default", "123", "", "gitlab", "", "", "'gitlab' named as default_provider is not configured"},
+		{"empty id", "github:", "", "", "", "", "cannot be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, id, err := registry.ResolveIssueReference(tt.ref, tt.flagProvider, tt.defaultProvider)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Name() != tt.wantProvider || id != tt.wantID {
+				t.Errorf("Expected %s:%s, got %s:%s", tt.wantProvider, tt.wantID, p.Name(), id)
+			}
+		})
+	}
+
+	single := NewRegistry()
+	if err := single.Register(&mockProvider{name: "github", configured: true}); err != nil {
+		t.Fatal(err)
+	}
+	if p, id, err := single.ResolveIssueReference("42", "", ""); err != nil || p.Name() != "github" || id != "42" {
+		t.Errorf("Expected the only configured provider to be used, got %v, %q, %v", p, id, err)
+	}
+	if _, _, err := NewRegistry().ResolveIssueReference("42", "", ""); err == nil || !strings.Contains(err.Error(), "no issue providers") {
+		t.Errorf("Expected an error without providers, got %v", err)
+	}
+}
+
+func TestStatusMapFromConfig(t *testing.T) {
+	statusMap := StatusMapFromConfig(map[string]interface{}{
+		"status_map": map[string]interface{}{
+			"In-Progress": []interface{}{"In Progress", "In Review"},
+			"blocked":     []interface{}{"Blocked", ""},
+			"empty":       []interface{}{},
+		},
+	})
+
+	if states, ok := statusMap.States("in-progress"); !ok || strings.Join(states, ",") != "In Progress,In Review" {
+		t.Errorf("Expected in-progress to map to its states, got %v, %v", states, ok)
+	}
+	if states, ok := statusMap.States("BLOCKED"); !ok || len(states) != 1 {
+		t.Errorf("Expected statuses to match case-insensitively and skip empty states, got %v, %v", states, ok)
+	}
+	if _, ok := statusMap.States("empty"); ok {
+		t.Error("Expected a status without states to be left unmapped")
+	}
+	if _, ok := StatusMapFromConfig(map[string]interface{}{}).States("open"); ok {
+		t.Error("Expected no mapping without a status_map section")
+	}
+}
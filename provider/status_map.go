@@ -0,0 +1,48 @@
+package provider
+
+import "strings"
+
+// StatusMap maps the statuses accepted by --status, workie's own (open, closed,
+// in-progress) as well as custom ones, to a provider's native states
+type StatusMap map[string][]string
+
+// StatusMapFromConfig reads the status_map section of a provider's
+// configuration. Each status lists the native states it stands for:
+//
+//	status_map:
+//	  in-progress: [In Progress, In Review]
+//	  blocked: [Blocked, Waiting for Support]
+//	  closed: [Done, "Won't Do"]
+//
+// Statuses are matched case-insensitively.
+func StatusMapFromConfig(config map[string]interface{}) StatusMap {
+	settings, ok := config["status_map"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	statuses := make(StatusMap)
+	for status, value := range settings {
+		var states []string
+		switch value := value.(type) {
+		case []interface{}:
+			for _, state := range value {
+				if state, ok := state.(string); ok && state != "" {
+					states = append(states, state)
+				}
+			}
+		case []string:
+			states = value
+		}
+		if len(states) > 0 {
+			statuses[strings.ToLower(status)] = states
+		}
+	}
+	return statuses
+}
+
+// States returns the native states status is mapped to, and whether it is mapped
+func (m StatusMap) States(status string) ([]string, bool) {
+	states, ok := m[strings.ToLower(status)]
+	return states, ok
+}
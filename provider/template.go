@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// issueTemplateFuncs are the helper functions available to issue templates
+var issueTemplateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+// RenderIssueTemplate renders a text/template with the issue as its data, e.g.
+// "# {{.Title}}\n\nCloses {{.URL}}"
+func RenderIssueTemplate(text string, issue *Issue) ([]byte, error) {
+	tmpl, err := template.New("issue_worktree_template").Funcs(issueTemplateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, issue); err != nil {
+		return nil, fmt.Errorf("failed to render issue template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
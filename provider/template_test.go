@@ -0,0 +1,29 @@
+package provider
+
+import "testing"
+
+func TestRenderIssueTemplate(t *testing.T) {
+	issue := &Issue{
+		ID:       "123",
+		Title:    "Fix login",
+		Labels:   []string{"bug", "auth"},
+		URL:      "https://github.com/org/repo/issues/123",
+		Metadata: map[string]string{"author": "octocat"},
+	}
+
+	got, err := RenderIssueTemplate("# {{.Title}} (#{{.ID}})\nLabels: {{join .Labels \", \"}}\nReported by {{.Metadata.author}}{{.Metadata.missing}}\nCloses {{.URL}}\n", issue)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := "# Fix login (#123)\nLabels: bug, auth\nReported by octocat\nCloses https://github.com/org/repo/issues/123\n"
+	if string(got) != want {
+		t.Errorf("RenderIssueTemplate() = %q, want %q", string(got), want)
+	}
+
+	if _, err := RenderIssueTemplate("{{.Title", issue); err == nil {
+		t.Error("Expected parse error for malformed template")
+	}
+	if _, err := RenderIssueTemplate("{{.Nope}}", issue); err == nil {
+		t.Error("Expected execution error for unknown field")
+	}
+}
@@ -0,0 +1,142 @@
+// Package table renders plain-text tables with aligned columns, so every
+// command that prints tabular output looks the same.
+package table
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// columnGap separates adjacent columns
+const columnGap = "  "
+
+// ansiEscape matches terminal color sequences, which take up no width
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// Column describes one column of a table
+type Column struct {
+	Header   string
+	MaxWidth int // Cells wider than this are truncated with "..." (0 for no limit)
+}
+
+// Table collects rows and renders them with aligned columns
+type Table struct {
+	Columns []Column
+	NoColor bool // Print headers plainly and strip color from cells
+
+	rows [][]string
+}
+
+// New creates a table with the given column headers. Color is disabled when
+// stdout is not a terminal or NO_COLOR is set.
+func New(headers ...string) *Table {
+	t := &Table{NoColor: color.NoColor}
+	for _, header := range headers {
+		t.Columns = append(t.Columns, Column{Header: header})
+	}
+	return t
+}
+
+// SetMaxWidth limits the width of the column at index col
+func (t *Table) SetMaxWidth(col, width int) {
+	if col >= 0 && col < len(t.Columns) {
+		t.Columns[col].MaxWidth = width
+	}
+}
+
+// AddRow appends a row. Missing cells are left empty and extra cells are dropped.
+func (t *Table) AddRow(cells ...string) {
+	row := make([]string, len(t.Columns))
+	copy(row, cells)
+	t.rows = append(t.rows, row)
+}
+
+// Len returns the number of rows added
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// Render writes the header, a separator line and every row to w
+func (t *Table) Render(w io.Writer) error {
+	rows := make([][]string, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = make([]string, len(row))
+		for j, cell := range row {
+			if t.NoColor {
+				cell = ansiEscape.ReplaceAllString(cell, "")
+			}
+			rows[i][j] = Truncate(cell, t.Columns[j].MaxWidth)
+		}
+	}
+
+	widths := make([]int, len(t.Columns))
+	for j, col := range t.Columns {
+		widths[j] = Width(col.Header)
+		for _, row := range rows {
+			widths[j] = max(widths[j], Width(row[j]))
+		}
+	}
+
+	headers := make([]string, len(t.Columns))
+	separators := make([]string, len(t.Columns))
+	for j, col := range t.Columns {
+		headers[j] = col.Header
+		if !t.NoColor {
+			headers[j] = color.New(color.Bold).Sprint(col.Header)
+		}
+		separators[j] = strings.Repeat("-", widths[j])
+	}
+
+	if err := writeRow(w, headers, widths); err != nil {
+		return err
+	}
+	if err := writeRow(w, separators, widths); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writeRow(w, row, widths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRow pads each cell to its column width. The last column is not padded,
+// so lines carry no trailing spaces.
+func writeRow(w io.Writer, cells []string, widths []int) error {
+	var line strings.Builder
+	for j, cell := range cells {
+		line.WriteString(cell)
+		if j < len(cells)-1 {
+			line.WriteString(strings.Repeat(" ", widths[j]-Width(cell)))
+			line.WriteString(columnGap)
+		}
+	}
+	_, err := fmt.Fprintln(w, strings.TrimRight(line.String(), " "))
+	return err
+}
+
+// Width returns the number of characters s occupies, ignoring color sequences
+func Width(s string) int {
+	return utf8.RuneCountInString(ansiEscape.ReplaceAllString(s, ""))
+}
+
+// Truncate shortens s to at most maxWidth characters, ending it with "..." when
+// anything was cut. A maxWidth of 0 or less leaves s unchanged. Color sequences
+// are dropped from truncated cells so no color is left unterminated.
+func Truncate(s string, maxWidth int) string {
+	if maxWidth <= 0 || Width(s) <= maxWidth {
+		return s
+	}
+
+	runes := []rune(ansiEscape.ReplaceAllString(s, ""))
+	if maxWidth <= 3 {
+		return string(runes[:maxWidth])
+	}
+	return string(runes[:maxWidth-3]) + "..."
+}
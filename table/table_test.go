@@ -0,0 +1,56 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tbl := New("ID", "TITLE", "STATUS")
+	tbl.NoColor = true
+	tbl.SetMaxWidth(1, 10)
+	tbl.AddRow("1", "Short", "open")
+	tbl.AddRow("1234", "A title that is far too long", "\x1b[32mclosed\x1b[0m")
+	tbl.AddRow("5")
+
+	var out bytes.Buffer
+	if err := tbl.Render(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "ID    TITLE       STATUS\n" +
+		"----  ----------  ------\n" +
+		"1     Short       open\n" +
+		"1234  A title...  closed\n" +
+		"5\n"
+	if out.String() != want {
+		t.Errorf("Unexpected table:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"hello", 0, "hello"},
+		{"hello", 5, "hello"},
+		{"hello world", 8, "hello..."},
+		{"héllo wörld", 8, "héllo..."},
+		{"hello", 2, "he"},
+		{"\x1b[31mred text here\x1b[0m", 6, "red..."},
+	}
+
+	for _, tt := range tests {
+		if got := Truncate(tt.in, tt.max); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestWidthIgnoresColor(t *testing.T) {
+	if got := Width("\x1b[1mbold\x1b[0m"); got != 4 {
+		t.Errorf("Expected width 4, got %d", got)
+	}
+}
@@ -8,7 +8,12 @@ import (
 )
 
 // CommitMessageTool generates commit messages based on git changes
-type CommitMessageTool struct{}
+type CommitMessageTool struct {
+	// Root is the sandbox root git runs in; empty means the repository root of
+	// the current directory. Only changes inside it are described, leaving out
+	// files excluded by .workieignore.
+	Root string
+}
 
 // NewCommitMessageTool creates a new commit message tool
 func NewCommitMessageTool() *CommitMessageTool {
@@ -22,7 +27,7 @@ func (c *CommitMessageTool) Name() string {
 
 // Description returns what the tool does
 func (c *CommitMessageTool) Description() string {
-	return "Generate commit messages based on git changes. Analyzes staged and unstaged files to create descriptive commit messages"
+	return "Generate commit messages based on git changes. Analyzes staged and unstaged files to create descriptive commit messages, or with since the commits since a ref to summarize a branch (e.g. for a squash or PR description)"
 }
 
 // Parameters returns the JSON schema for the tool's parameters
@@ -42,6 +47,10 @@ func (c *CommitMessageTool) Parameters() map[string]interface{} {
 				"enum":        []string{"conventional", "simple", "detailed"},
 				"default":     "conventional",
 			},
+			"since": map[string]interface{}{
+				"type":        "string",
+				"description": "Ref or commit to summarize the commits since (git diff <since>..HEAD), e.g. origin/main; type is ignored when set",
+			},
 		},
 	}
 }
@@ -58,27 +67,133 @@ func (c *CommitMessageTool) Execute(ctx context.Context, params map[string]inter
 		format = f
 	}
 
-	// Get the changes
-	changes, err := c.getChanges(ctx, changeType)
+	since, _ := params["since"].(string)
+
+	// Describe only the sandbox's files
+	sandbox, err := NewSandbox(c.Root)
+	if err != nil {
+		return "", err
+	}
+	ignored, err := sandbox.loadIgnore()
+	if err != nil {
+		return "", err
+	}
+	excludes, err := ignoredPathspecs(ctx, sandbox, ignored)
+	if err != nil {
+		return "", err
+	}
+	git := func(args ...string) *exec.Cmd {
+		args = append(append(args, "--", "."), excludes...)
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = sandbox.Root
+		return cmd
+	}
+
+	// Get the changes, of the working tree or of the commits since a ref
+	var changes string
+	var commits []string
+	if since != "" {
+		changes, commits, err = c.getCommitChanges(ctx, sandbox.Root, git, since)
+	} else {
+		changes, err = c.getChanges(git, changeType)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to get changes: %v", err)
 	}
 
 	if changes == "" {
+		if since != "" {
+			return fmt.Sprintf("No changes since %s to create a commit message", since), nil
+		}
 		return "No changes detected to create a commit message", nil
 	}
 
 	// Generate commit message based on changes
 	message := c.generateMessage(changes, format)
 
+	// A summary of several commits lists them in its body, as a squash does
+	if len(commits) > 0 {
+		message += "\n\nCommits:\n- " + strings.Join(commits, "\n- ")
+	}
+
 	return message, nil
 }
 
-func (c *CommitMessageTool) getChanges(ctx context.Context, changeType string) (string, error) {
+// getCommitChanges describes the changes of the commits since a ref in the form
+// getChanges uses for the working tree, and returns their subjects oldest first.
+// git builds the git commands run on the sandbox's files, in dir.
+func (c *CommitMessageTool) getCommitChanges(ctx context.Context, dir string, git func(args ...string) *exec.Cmd, since string) (string, []string, error) {
+	if strings.HasPrefix(since, "-") {
+		return "", nil, fmt.Errorf("invalid ref '%s'", since)
+	}
+	verifyCmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", since+"^{commit}")
+	verifyCmd.Dir = dir
+	if err := verifyCmd.Run(); err != nil {
+		return "", nil, fmt.Errorf("'%s' is not a ref or commit in this repository", since)
+	}
+	commitRange := since + "..HEAD"
+
+	logCmd := git("log", "--reverse", "--format=%s", commitRange)
+	logOutput, err := logCmd.Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get git log: %v", err)
+	}
+	var commits []string
+	for _, subject := range strings.Split(string(logOutput), "\n") {
+		if subject = strings.TrimSpace(subject); subject != "" {
+			commits = append(commits, subject)
+		}
+	}
+
+	statusCmd := git("diff", "--name-status", commitRange)
+	statusOutput, err := statusCmd.Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get git diff: %v", err)
+	}
+	if len(statusOutput) == 0 {
+		return "", commits, nil
+	}
+
+	// Report each file like git status --porcelain does, treating renames and
+	// copies as modifications of the new path
+	var result strings.Builder
+	var files []string
+	result.WriteString("File changes:\n")
+	for _, line := range strings.Split(strings.TrimSpace(string(statusOutput)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		status := fields[0][:1]
+		if status != "A" && status != "D" {
+			status = "M"
+		}
+		file := fields[len(fields)-1]
+		files = append(files, file)
+		result.WriteString(status + "  " + file + "\n")
+	}
+
+	statCmd := git("diff", "--stat", commitRange)
+	if statOutput, err := statCmd.Output(); err == nil && len(statOutput) > 0 {
+		result.WriteString("\nChange summary:\n")
+		result.WriteString(string(statOutput))
+	}
+
+	result.WriteString("\nModified files:\n")
+	for _, file := range files {
+		result.WriteString("- " + file + "\n")
+	}
+
+	return result.String(), commits, nil
+}
+
+// getChanges describes the working tree's changes of changeType; git builds the
+// git commands run on the sandbox's files
+func (c *CommitMessageTool) getChanges(git func(args ...string) *exec.Cmd, changeType string) (string, error) {
 	var result strings.Builder
 
 	// Get status
-	statusCmd := exec.CommandContext(ctx, "git", "status", "--porcelain")
+	statusCmd := git("status", "--porcelain")
 	statusOutput, err := statusCmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get git status: %v", err)
@@ -106,7 +221,7 @@ func (c *CommitMessageTool) getChanges(ctx context.Context, changeType string) (
 	}
 
 	if len(diffArgs) > 0 {
-		diffCmd := exec.CommandContext(ctx, "git", diffArgs...)
+		diffCmd := git(diffArgs...)
 		diffOutput, err := diffCmd.Output()
 		if err == nil && len(diffOutput) > 0 {
 			result.WriteString("\nChange summary:\n")
@@ -123,7 +238,7 @@ func (c *CommitMessageTool) getChanges(ctx context.Context, changeType string) (
 	}
 	detailArgs = append(detailArgs, "--name-only")
 
-	detailCmd := exec.CommandContext(ctx, "git", detailArgs...)
+	detailCmd := git(detailArgs...)
 	detailOutput, err := detailCmd.Output()
 	if err == nil && len(detailOutput) > 0 {
 		files := strings.Split(strings.TrimSpace(string(detailOutput)), "\n")
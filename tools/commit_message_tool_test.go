@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommitMessageToolSince(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, env := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(env, "test")
+	}
+	for _, env := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(env, "test@example.com")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	commit := func(file, content, subject string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, file), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", file)
+		git("commit", "-q", "-m", subject)
+	}
+
+	git("init", "-q", "-b", "main")
+	commit("README.md", "readme\n", "Initial commit")
+	git("tag", "base")
+	commit("search.go", "package search\n", "Add search")
+	commit("README.md", "readme\nsearch\n", "Document search")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	tool := NewCommitMessageTool()
+	message, err := tool.Execute(context.Background(), map[string]interface{}{"since": "base", "format": "simple"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := "Add search.go, Update README.md\n\nCommits:\n- Add search\n- Document search"
+	if message != want {
+		t.Errorf("Expected a summary of both commits:\n%s\ngot:\n%s", want, message)
+	}
+
+	message, err = tool.Execute(context.Background(), map[string]interface{}{"since": "HEAD"})
+	if err != nil || !strings.Contains(message, "No changes since HEAD") {
+		t.Errorf("Expected no changes since HEAD, got %q, %v", message, err)
+	}
+
+	for _, since := range []string{"missing", "--output=x"} {
+		if _, err := tool.Execute(context.Background(), map[string]interface{}{"since": since}); err == nil {
+			t.Errorf("Expected %q to be refused", since)
+		}
+	}
+}
+
+func TestSinceRef(t *testing.T) {
+	for query, want := range map[string]string{
+		"generate a commit message":                        "",
+		"write a commit message since origin/main":         "origin/main",
+		"Commit message since v1.2.0?":                     "v1.2.0",
+		"summarize commits since main in a commit message": "main",
+	} {
+		if got := sinceRef(query); got != want {
+			t.Errorf("sinceRef(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
@@ -6,14 +6,21 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/agoodway/workie/ignore"
 )
 
-// FileSystemTool provides file system operations
-type FileSystemTool struct{}
+// FileSystemTool provides file system operations. The write and append
+// operations are only available when AllowWrite is set.
+type FileSystemTool struct {
+	MaxFileSize int64  // Files larger than this many bytes are not read or written (0 disables the limit)
+	Root        string // Sandbox root; empty means the repository root of the current directory
+	AllowWrite  bool   // Whether files may be written and appended to
+}
 
-// NewFileSystemTool creates a new file system tool
+// NewFileSystemTool creates a new file system tool that can write files
 func NewFileSystemTool() *FileSystemTool {
-	return &FileSystemTool{}
+	return &FileSystemTool{MaxFileSize: DefaultMaxFileSize, AllowWrite: true}
 }
 
 // Name returns the name of the tool
@@ -23,7 +30,7 @@ func (f *FileSystemTool) Name() string {
 
 // Description returns what the tool does
 func (f *FileSystemTool) Description() string {
-	return "Read files and get information about the file system"
+	return "Read, create and append to files and get information about the file system"
 }
 
 // Parameters returns the JSON schema for the tool's parameters
@@ -34,16 +41,28 @@ func (f *FileSystemTool) Parameters() map[string]interface{} {
 			"operation": map[string]interface{}{
 				"type":        "string",
 				"description": "The file system operation to perform",
-				"enum":        []string{"read", "list", "exists", "info"},
+				"enum":        []string{"read", "list", "exists", "info", "write", "append"},
 			},
 			"path": map[string]interface{}{
 				"type":        "string",
-				"description": "The file or directory path",
+				"description": "The file or directory path, relative to the repository root",
 			},
 			"limit": map[string]interface{}{
 				"type":        "integer",
 				"description": "For read operation, limit number of lines (default: 100)",
 			},
+			"max_file_size": map[string]interface{}{
+				"type":        "integer",
+				"description": "For read operation, skip files larger than this many bytes (default: 10485760, 0 for no limit)",
+			},
+			"content": map[string]interface{}{
+				"type":        "string",
+				"description": "For write and append operations, the text to write",
+			},
+			"overwrite": map[string]interface{}{
+				"type":        "boolean",
+				"description": "For write operation, replace the file if it already exists (default: false)",
+			},
 		},
 		"required": []string{"operation", "path"},
 	}
@@ -61,49 +80,36 @@ func (f *FileSystemTool) Execute(ctx context.Context, params map[string]interfac
 		return "", fmt.Errorf("path parameter is required")
 	}
 
-	// Get the current working directory as the base directory
-	baseDir, err := os.Getwd()
+	// Confine access to the sandbox, checking containment after resolving symlinks
+	sandbox, err := NewSandbox(f.Root)
 	if err != nil {
-		return "", fmt.Errorf("failed to get working directory: %v", err)
+		return "", err
 	}
-
-	// Clean and resolve the path
-	path = filepath.Clean(path)
-
-	// If path is relative, join it with base directory
-	if !filepath.IsAbs(path) {
-		path = filepath.Join(baseDir, path)
+	path, relPath, err := sandbox.Resolve(path)
+	if err != nil {
+		return "", err
 	}
 
-	// Resolve any symlinks
-	resolvedPath, err := filepath.EvalSymlinks(path)
+	// Refuse paths excluded by .workieignore
+	ignored, err := sandbox.loadIgnore()
 	if err != nil {
-		// If file doesn't exist yet, just use the cleaned path
-		if !os.IsNotExist(err) {
-			return "", fmt.Errorf("failed to resolve path: %v", err)
-		}
-		resolvedPath = path
+		return "", err
 	}
-
-	// Ensure the resolved path is within the base directory
-	relPath, err := filepath.Rel(baseDir, resolvedPath)
-	if err != nil || strings.HasPrefix(relPath, "..") {
-		return "", fmt.Errorf("access denied: path is outside the working directory")
+	info, statErr := os.Stat(path)
+	if ignored.Match(path, statErr == nil && info.IsDir()) {
+		return "", fmt.Errorf("access denied: path is excluded by %s", ignore.FileName)
 	}
 
-	// Use the safe resolved path
-	path = resolvedPath
-
 	switch operation {
 	case "read":
 		limit := 100
 		if limitParam, ok := params["limit"].(float64); ok {
 			limit = int(limitParam)
 		}
-		return f.readFile(path, limit)
+		return f.readFile(path, limit, maxFileSizeParam(params, f.MaxFileSize))
 
 	case "list":
-		return f.listDirectory(path)
+		return f.listDirectory(path, ignored)
 
 	case "exists":
 		return f.checkExists(path)
@@ -111,12 +117,36 @@ func (f *FileSystemTool) Execute(ctx context.Context, params map[string]interfac
 	case "info":
 		return f.getFileInfo(path)
 
+	case "write", "append":
+		if !f.AllowWrite {
+			return "", fmt.Errorf("writing files is disabled; show the content to the user instead")
+		}
+		if sandbox.InGitDir(path) {
+			return "", fmt.Errorf("access denied: %s is in the git directory", relPath)
+		}
+		content, ok := params["content"].(string)
+		if !ok {
+			return "", fmt.Errorf("content parameter is required for %s", operation)
+		}
+		if f.MaxFileSize > 0 && int64(len(content)) > f.MaxFileSize {
+			return "", fmt.Errorf("content is too large (%d bytes exceeds limit of %d bytes)", len(content), f.MaxFileSize)
+		}
+		if operation == "append" {
+			return f.appendFile(path, relPath, content)
+		}
+		overwrite, _ := params["overwrite"].(bool)
+		return f.writeFile(path, relPath, content, overwrite)
+
 	default:
 		return "", fmt.Errorf("unknown operation: %s", operation)
 	}
 }
 
-func (f *FileSystemTool) readFile(path string, limit int) (string, error) {
+func (f *FileSystemTool) readFile(path string, limit int, maxFileSize int64) (string, error) {
+	if info, err := os.Stat(path); err == nil && maxFileSize > 0 && info.Size() > maxFileSize {
+		return fmt.Sprintf("%s: skipped (too large, %d bytes exceeds limit of %d bytes)", filepath.Base(path), info.Size(), maxFileSize), nil
+	}
+
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return "", fmt.Errorf("failed to read file: %v", err)
@@ -131,7 +161,7 @@ func (f *FileSystemTool) readFile(path string, limit int) (string, error) {
 	return string(content), nil
 }
 
-func (f *FileSystemTool) listDirectory(path string) (string, error) {
+func (f *FileSystemTool) listDirectory(path string, ignored *ignoreRules) (string, error) {
 	entries, err := os.ReadDir(path)
 	if err != nil {
 		return "", fmt.Errorf("failed to list directory: %v", err)
@@ -139,6 +169,10 @@ func (f *FileSystemTool) listDirectory(path string) (string, error) {
 
 	var result []string
 	for _, entry := range entries {
+		if ignored.Match(filepath.Join(path, entry.Name()), entry.IsDir()) {
+			continue
+		}
+
 		info, err := entry.Info()
 		if err != nil {
 			continue
@@ -188,3 +222,50 @@ func (f *FileSystemTool) getFileInfo(path string) (string, error) {
 
 	return result, nil
 }
+
+// writeFile creates the file at path, with any missing parent directories. An
+// existing file is only replaced when overwrite is set, keeping its mode.
+func (f *FileSystemTool) writeFile(path, relPath, content string, overwrite bool) (string, error) {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			return "", fmt.Errorf("cannot write %s: path is a directory", relPath)
+		}
+		if !overwrite {
+			return "", fmt.Errorf("file %s already exists; pass overwrite: true to replace it", relPath)
+		}
+		mode = info.Mode().Perm()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %v", err)
+	}
+	if err := writeFileAtomic(path, []byte(content), mode); err != nil {
+		return "", fmt.Errorf("failed to write file: %v", err)
+	}
+	return fmt.Sprintf("Wrote %d bytes to %s", len(content), relPath), nil
+}
+
+// appendFile appends content to the file at path, creating it and any missing
+// parent directories if needed
+func (f *FileSystemTool) appendFile(path, relPath, content string) (string, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return "", fmt.Errorf("cannot append to %s: path is a directory", relPath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %v", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %v", err)
+	}
+	n, err := file.WriteString(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to append to file: %v", err)
+	}
+	return fmt.Sprintf("Appended %d bytes to %s", n, relPath), nil
+}
@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSystemToolWrite(t *testing.T) {
+	root := t.TempDir()
+	tool := NewFileSystemTool()
+	tool.Root = root
+
+	write := func(params map[string]interface{}) (string, error) {
+		return tool.Execute(context.Background(), params)
+	}
+
+	out, err := write(map[string]interface{}{"operation": "write", "path": "docs/notes.md", "content": "hello\n"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if out != "Wrote 6 bytes to docs/notes.md" {
+		t.Errorf("Unexpected output: %q", out)
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "docs", "notes.md")); err != nil || string(data) != "hello\n" {
+		t.Errorf("Expected the file and its parent directory to be created, got %q, %v", data, err)
+	}
+
+	if _, err := write(map[string]interface{}{"operation": "write", "path": "docs/notes.md", "content": "replaced"}); err == nil || !strings.Contains(err.Error(), "overwrite: true") {
+		t.Errorf("Expected writing an existing file without overwrite to be refused, got: %v", err)
+	}
+	if _, err := write(map[string]interface{}{"operation": "write", "path": "docs/notes.md", "content": "replaced", "overwrite": true}); err != nil {
+		t.Fatalf("Expected overwrite to succeed, got: %v", err)
+	}
+
+	out, err = write(map[string]interface{}{"operation": "append", "path": "docs/notes.md", "content": " more"})
+	if err != nil || out != "Appended 5 bytes to docs/notes.md" {
+		t.Errorf("Unexpected append result: %q, %v", out, err)
+	}
+	out, err = write(map[string]interface{}{"operation": "read", "path": "docs/notes.md"})
+	if err != nil || out != "replaced more" {
+		t.Errorf("Expected the written content to be read back, got %q, %v", out, err)
+	}
+
+	if _, err := write(map[string]interface{}{"operation": "write", "path": "docs", "content": "x", "overwrite": true}); err == nil {
+		t.Error("Expected writing over a directory to be refused")
+	}
+	if _, err := write(map[string]interface{}{"operation": "write", "path": "empty.txt"}); err == nil {
+		t.Error("Expected write without content to be refused")
+	}
+}
+
+func TestFileSystemToolWriteOutsideSandbox(t *testing.T) {
+	root, outside := setupEscapeFixture(t)
+	tool := NewFileSystemTool()
+	tool.Root = root
+
+	paths := []string{
+		"../" + filepath.Base(outside) + "/new.txt",
+		filepath.Join(outside, "new.txt"),
+		"dir-escape/new.txt",
+		"dangling-escape",
+	}
+	for _, path := range paths {
+		for _, operation := range []string{"write", "append"} {
+			if _, err := tool.Execute(context.Background(), map[string]interface{}{"operation": operation, "path": path, "content": "x"}); err == nil {
+				t.Errorf("Expected %s of %q to be denied", operation, path)
+			}
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outside, "new.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be written outside the sandbox, got err=%v", err)
+	}
+}
+
+func TestFileSystemToolWriteGitDir(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	cmd := exec.Command("git", "init", "-q")
+	cmd.Dir = root
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	tool := NewFileSystemTool()
+	tool.Root = root
+	// sub/.git stands for the .git file of a worktree checked out inside the repository
+	for _, path := range []string{".git/hooks/pre-commit", ".git/config", ".GIT/config", "sub/.git"} {
+		for _, operation := range []string{"write", "append"} {
+			if _, err := tool.Execute(context.Background(), map[string]interface{}{"operation": operation, "path": path, "content": "x", "overwrite": true}); err == nil || !strings.Contains(err.Error(), "git directory") {
+				t.Errorf("Expected %s of %q to be denied, got: %v", operation, path, err)
+			}
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, ".git", "hooks", "pre-commit")); !os.IsNotExist(err) {
+		t.Errorf("Expected no hook to be written, got err=%v", err)
+	}
+
+	if _, err := tool.Execute(context.Background(), map[string]interface{}{"operation": "write", "path": "notes.md", "content": "x"}); err != nil {
+		t.Errorf("Expected other files to be writable, got: %v", err)
+	}
+}
+
+func TestFileSystemToolWriteDisabled(t *testing.T) {
+	root := t.TempDir()
+	tool := NewFileSystemTool()
+	tool.Root = root
+	tool.AllowWrite = false
+
+	if _, err := tool.Execute(context.Background(), map[string]interface{}{"operation": "write", "path": "new.txt", "content": "x"}); err == nil {
+		t.Error("Expected writes to be refused when AllowWrite is off")
+	}
+	if _, err := os.Stat(filepath.Join(root, "new.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected no file to be created, got err=%v", err)
+	}
+}
@@ -3,18 +3,82 @@ package tools
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
+
+	"github.com/agoodway/workie/ignore"
 )
 
+// DefaultGitCommands are the read-only git subcommands the git tool runs unless
+// configured otherwise (tools.git.allowed_commands)
+var DefaultGitCommands = []string{"status", "branch", "diff", "log"}
+
+// unsafeGitOptions write files or run programs, so the git tool refuses them
+// with any subcommand, as well as -c and unambiguous abbreviations such as --out
+var unsafeGitOptions = []string{"--output", "--ext-diff", "--no-index", "--exec", "--upload-pack", "--receive-pack", "--open-files-in-pager"}
+
+// branchListOptions are the git branch options that only list branches
+var branchListOptions = map[string]bool{
+	"--show-current": true, "-a": true, "--all": true, "-r": true, "--remotes": true,
+	"-l": true, "--list": true, "-v": true, "-vv": true, "--verbose": true,
+	"--column": true, "--no-column": true, "--color": true, "--no-color": true,
+	"-i": true, "--ignore-case": true, "--omit-empty": true,
+}
+
+// branchValueOptions are the git branch options that filter or format the
+// listing, with a value after = or as the next argument
+var branchValueOptions = map[string]bool{
+	"--contains": true, "--no-contains": true, "--merged": true, "--no-merged": true,
+	"--points-at": true, "--sort": true, "--format": true,
+}
+
+// gitPathCommands are the git subcommands whose output the git tool limits to
+// the sandbox with pathspecs, leaving out files excluded by .workieignore
+var gitPathCommands = map[string]bool{
+	"status": true, "diff": true, "log": true, "show": true, "shortlog": true, "whatchanged": true, "ls-files": true,
+}
+
+// gitValueOptions are git options that may take their value as the next
+// argument, which is then not a revision or path
+var gitValueOptions = map[string]bool{
+	"-n": true, "--max-count": true, "--skip": true, "-S": true, "-G": true, "--grep": true,
+	"--author": true, "--committer": true, "--since": true, "--until": true, "--after": true,
+	"--before": true, "--format": true, "--pretty": true, "--date": true, "-U": true, "-L": true,
+}
+
 // GitTool provides Git operations
-type GitTool struct{}
+type GitTool struct {
+	// AllowedCommands are the git subcommands Execute runs; any other is
+	// refused. Empty means DefaultGitCommands.
+	AllowedCommands []string
 
-// NewGitTool creates a new Git tool
+	// Root is the sandbox root git runs in; empty means the repository root of
+	// the current directory. Paths outside it or excluded by .workieignore are
+	// refused.
+	Root string
+}
+
+// NewGitTool creates a new Git tool that runs the default read-only commands
 func NewGitTool() *GitTool {
 	return &GitTool{}
 }
 
+// allowedCommands returns the subcommands the tool may run
+func (g *GitTool) allowedCommands() []string {
+	if len(g.AllowedCommands) == 0 {
+		return DefaultGitCommands
+	}
+	return g.AllowedCommands
+}
+
+// Allows reports whether the tool may run the git subcommand
+func (g *GitTool) Allows(command string) bool {
+	return slices.Contains(g.allowedCommands(), command)
+}
+
 // Name returns the name of the tool
 func (g *GitTool) Name() string {
 	return "git"
@@ -22,7 +86,7 @@ func (g *GitTool) Name() string {
 
 // Description returns what the tool does
 func (g *GitTool) Description() string {
-	return "Execute Git commands to get repository information. Use 'branch' command to get current branch name, 'status' for repository status, 'log' for commit history"
+	return "Execute Git commands to get repository information. Use 'branch' command to get current branch name, 'status' for repository status, 'log' for commit history. Only the commands listed in the command parameter are allowed"
 }
 
 // Parameters returns the JSON schema for the tool's parameters
@@ -33,11 +97,11 @@ func (g *GitTool) Parameters() map[string]interface{} {
 			"command": map[string]interface{}{
 				"type":        "string",
 				"description": "The git subcommand to execute (e.g., 'branch', 'status', 'log')",
-				"enum":        []string{"branch", "status", "log", "remote", "diff", "show", "add", "commit"},
+				"enum":        g.allowedCommands(),
 			},
 			"args": map[string]interface{}{
 				"type":        "array",
-				"description": "Additional arguments for the git command",
+				"description": "Additional arguments for the git command; options that write files or run programs are refused, and branch only lists",
 				"items": map[string]interface{}{
 					"type": "string",
 				},
@@ -53,6 +117,9 @@ func (g *GitTool) Execute(ctx context.Context, params map[string]interface{}) (s
 	if !ok {
 		return "", fmt.Errorf("command parameter is required")
 	}
+	if !g.Allows(command) {
+		return "", fmt.Errorf("git command '%s' is not allowed (allowed: %s)\n\nTo fix this:\n  • Use one of the allowed commands\n  • Or add it to tools.git.allowed_commands in .workie.yaml", command, strings.Join(g.allowedCommands(), ", "))
+	}
 
 	// Build the git command
 	args := []string{command}
@@ -65,6 +132,9 @@ func (g *GitTool) Execute(ctx context.Context, params map[string]interface{}) (s
 			}
 		}
 	}
+	if err := checkGitArgs(command, args[1:]); err != nil {
+		return "", err
+	}
 
 	// Special handling for common queries
 	switch command {
@@ -90,8 +160,24 @@ func (g *GitTool) Execute(ctx context.Context, params map[string]interface{}) (s
 		}
 	}
 
+	// Run in the sandbox, keeping paths and output inside it
+	sandbox, err := NewSandbox(g.Root)
+	if err != nil {
+		return "", err
+	}
+	if command != "branch" {
+		ignored, err := sandbox.loadIgnore()
+		if err != nil {
+			return "", err
+		}
+		if args, err = sandboxGitArgs(ctx, sandbox, ignored, args); err != nil {
+			return "", err
+		}
+	}
+
 	// Execute the git command
 	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = sandbox.Root
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return "", fmt.Errorf("git command failed: %v\nOutput: %s", err, string(output))
@@ -104,3 +190,194 @@ func (g *GitTool) Execute(ctx context.Context, params map[string]interface{}) (s
 
 	return result, nil
 }
+
+// checkGitArgs refuses arguments that would let an allowed command write: the
+// unsafe options with any command, and anything but listing with branch
+func checkGitArgs(command string, args []string) error {
+	for _, arg := range args {
+		name, _, _ := strings.Cut(arg, "=")
+		unsafe := arg == "-c"
+		for _, option := range unsafeGitOptions {
+			if len(name) > 2 && strings.HasPrefix(option, name) {
+				unsafe = true
+			}
+		}
+		if unsafe {
+			return fmt.Errorf("git option '%s' is not allowed: it can write files or run programs", arg)
+		}
+	}
+
+	if command != "branch" {
+		return nil
+	}
+	listing := slices.Contains(args, "--list") || slices.Contains(args, "-l")
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, _, hasValue := strings.Cut(arg, "=")
+		switch {
+		case branchValueOptions[name]:
+			if !hasValue && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+			}
+		case branchListOptions[name]:
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("git branch option '%s' is not allowed: the git tool only lists branches", arg)
+		case !listing:
+			return fmt.Errorf("git branch %s would create a branch; the git tool only lists branches (use --list to match branch names)", arg)
+		}
+	}
+	return nil
+}
+
+// sandboxGitArgs checks the paths in a git command's arguments, args[0] being
+// the subcommand: pathspecs, which are relative to the sandbox root git runs
+// in, and the paths of rev:path objects, which are relative to the repository
+// root. A path outside the sandbox or excluded by .workieignore is refused.
+// Commands in gitPathCommands get their pathspecs after "--", the sandbox
+// root when there are none, so output about other files is left out, along
+// with exclusions for the files .workieignore excludes.
+func sandboxGitArgs(ctx context.Context, sandbox *Sandbox, ignored *ignoreRules, args []string) ([]string, error) {
+	var revs, paths []string
+	objectPaths := false
+	afterDashes := false
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case afterDashes:
+			paths = append(paths, arg)
+		case arg == "--":
+			afterDashes = true
+		case strings.HasPrefix(arg, "-"):
+			revs = append(revs, arg)
+			if gitValueOptions[arg] && i+1 < len(args) {
+				i++
+				revs = append(revs, args[i])
+			}
+		case strings.Contains(arg, ":"):
+			if err := checkGitObjectPath(sandbox, ignored, arg); err != nil {
+				return nil, err
+			}
+			revs = append(revs, arg)
+			objectPaths = true
+		case isGitRevision(ctx, sandbox.Root, arg):
+			revs = append(revs, arg)
+		default:
+			paths = append(paths, arg)
+		}
+	}
+
+	for _, path := range paths {
+		if strings.HasPrefix(path, ":") {
+			return nil, fmt.Errorf("git pathspec '%s' is not allowed: pathspec magic can reach outside the sandbox", path)
+		}
+		if err := checkSandboxPath(sandbox, ignored, path); err != nil {
+			return nil, err
+		}
+	}
+
+	if !gitPathCommands[args[0]] || objectPaths {
+		return args, nil
+	}
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	excludes, err := ignoredPathspecs(ctx, sandbox, ignored)
+	if err != nil {
+		return nil, err
+	}
+	result := append([]string{args[0]}, revs...)
+	result = append(result, "--")
+	result = append(result, paths...)
+	return append(result, excludes...), nil
+}
+
+// checkGitObjectPath checks the path of a rev:path argument such as
+// HEAD:README.md. As in git, the path is relative to the repository root unless
+// it starts with ./ or ../, and :<n>:path names a file in the index.
+func checkGitObjectPath(sandbox *Sandbox, ignored *ignoreRules, arg string) error {
+	rev, path, _ := strings.Cut(arg, ":")
+	if rev == "" {
+		if strings.HasPrefix(path, "/") || strings.HasPrefix(path, "(") {
+			return fmt.Errorf("git argument '%s' is not allowed: it can reach outside the sandbox", arg)
+		}
+		if len(path) >= 2 && path[1] == ':' && path[0] >= '0' && path[0] <= '3' {
+			path = path[2:]
+		}
+	}
+
+	base := ignored.root
+	if path == "." || path == ".." || strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") {
+		base = sandbox.Root
+	}
+	return checkSandboxPath(sandbox, ignored, filepath.Join(base, path))
+}
+
+// checkSandboxPath refuses a path outside the sandbox or excluded by .workieignore
+func checkSandboxPath(sandbox *Sandbox, ignored *ignoreRules, path string) error {
+	resolved, _, err := sandbox.Resolve(path)
+	if err != nil {
+		return err
+	}
+	info, statErr := os.Stat(resolved)
+	if ignored.Match(resolved, statErr == nil && info.IsDir()) {
+		return fmt.Errorf("access denied: %s is excluded by %s", path, ignore.FileName)
+	}
+	return nil
+}
+
+// isGitRevision reports whether arg names a revision or a range of them, such
+// as HEAD~2, origin/main or main..feature
+func isGitRevision(ctx context.Context, dir, arg string) bool {
+	arg = strings.TrimPrefix(arg, "^")
+	parts := []string{arg}
+	if from, to, ok := strings.Cut(arg, "..."); ok {
+		parts = []string{from, to}
+	} else if from, to, ok := strings.Cut(arg, ".."); ok {
+		parts = []string{from, to}
+	}
+
+	verified := false
+	for _, part := range parts {
+		if part == "" {
+			continue // main.. means main..HEAD
+		}
+		if strings.HasPrefix(part, "-") {
+			return false
+		}
+		cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", part+"^{object}")
+		cmd.Dir = dir
+		if cmd.Run() != nil {
+			return false
+		}
+		verified = true
+	}
+	return verified
+}
+
+// ignoredPathspecs returns exclusion pathspecs for the tracked and untracked
+// files in the sandbox that .workieignore excludes, relative to the sandbox root
+func ignoredPathspecs(ctx context.Context, sandbox *Sandbox, ignored *ignoreRules) ([]string, error) {
+	if ignored.matcher.Empty() {
+		return nil, nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "ls-files", "-z", "--cached", "--others", "--exclude-standard", "--", ".")
+	cmd.Dir = sandbox.Root
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files for %s: %v", ignore.FileName, err)
+	}
+
+	var excludes []string
+	seen := make(map[string]bool)
+	for _, file := range strings.Split(string(output), "\x00") {
+		if file == "" || seen[file] {
+			continue
+		}
+		seen[file] = true
+		if ignored.Match(filepath.Join(sandbox.Root, file), false) {
+			excludes = append(excludes, ":(exclude,literal)"+file)
+		}
+	}
+	return excludes, nil
+}
@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGitToolAllowlist(t *testing.T) {
+	tool := NewGitTool()
+
+	for _, command := range []string{"push", "commit", "reset", "-c"} {
+		_, err := tool.Execute(context.Background(), map[string]interface{}{"command": command})
+		if err == nil || !strings.Contains(err.Error(), "is not allowed") {
+			t.Errorf("Expected %q to be refused by default, got: %v", command, err)
+		}
+	}
+	for _, command := range DefaultGitCommands {
+		if !tool.Allows(command) {
+			t.Errorf("Expected %q to be allowed by default", command)
+		}
+	}
+
+	// A configured allowlist replaces the default one, in the schema too
+	tool.AllowedCommands = []string{"log", "push"}
+	if tool.Allows("status") || !tool.Allows("push") {
+		t.Errorf("Expected only log and push to be allowed, got %v", tool.allowedCommands())
+	}
+	properties := tool.Parameters()["properties"].(map[string]interface{})
+	enum := properties["command"].(map[string]interface{})["enum"].([]string)
+	if !slices.Equal(enum, []string{"log", "push"}) {
+		t.Errorf("Expected the allowlist as the command enum, got %v", enum)
+	}
+}
+
+func TestGitToolArguments(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", "-b", "main", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	tool := NewGitTool()
+	run := func(command string, args ...string) error {
+		params := map[string]interface{}{"command": command}
+		if len(args) > 0 {
+			list := make([]interface{}, len(args))
+			for i, arg := range args {
+				list[i] = arg
+			}
+			params["args"] = list
+		}
+		_, err := tool.Execute(context.Background(), params)
+		return err
+	}
+
+	output := filepath.Join(t.TempDir(), "written")
+	refused := [][]string{
+		{"branch", "evil"},
+		{"branch", "-D", "main"},
+		{"branch", "--delete", "main"},
+		{"branch", "-m", "main", "other"},
+		{"branch", "--contains", "HEAD", "evil"},
+		{"diff", "--output=" + output},
+		{"diff", "--output", output},
+		{"diff", "--outp=" + output},
+		{"log", "--output=" + output},
+		{"diff", "--ext-diff"},
+		{"diff", "--no-index", "/etc/hostname", "/etc/hosts"},
+		{"diff", "-c"},
+	}
+	for _, args := range refused {
+		if err := run(args[0], args[1:]...); err == nil || !(strings.Contains(err.Error(), "not allowed") || strings.Contains(err.Error(), "only lists branches")) {
+			t.Errorf("Expected git %s to be refused, got: %v", strings.Join(args, " "), err)
+		}
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Errorf("Expected --output not to write %s, got err=%v", output, err)
+	}
+	if out, _ := exec.Command("git", "branch", "--list").Output(); strings.TrimSpace(string(out)) != "" {
+		t.Errorf("Expected no branches to be created, got %q", out)
+	}
+
+	for _, args := range [][]string{
+		{"branch", "--list", "feat/*"},
+		{"branch", "-a", "-v"},
+		{"branch", "--sort=-committerdate", "--format", "%(refname:short)"},
+		{"status", "--porcelain"},
+	} {
+		if err := run(args[0], args[1:]...); err != nil && strings.Contains(err.Error(), "not allowed") {
+			t.Errorf("Expected git %s to be allowed, got: %v", strings.Join(args, " "), err)
+		}
+	}
+}
+
+func TestGitToolSandbox(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, env := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(env, "test")
+	}
+	for _, env := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(env, "test@example.com")
+	}
+
+	repo := t.TempDir()
+	files := map[string]string{
+		"app/main.go":    "package main\n",
+		"app/secret.env": "TOKEN=app\n",
+		"other/notes.md": "sibling notes\n",
+		".workieignore":  "*.env\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{{"init", "-q", "-b", "main"}, {"add", "."}, {"commit", "-q", "-m", "init"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+
+	tool := NewGitTool()
+	tool.Root = filepath.Join(repo, "app")
+	tool.AllowedCommands = []string{"log", "show", "diff", "status"}
+	run := func(command string, args ...string) (string, error) {
+		list := make([]interface{}, len(args))
+		for i, arg := range args {
+			list[i] = arg
+		}
+		return tool.Execute(context.Background(), map[string]interface{}{"command": command, "args": list})
+	}
+
+	refused := [][]string{
+		{"log", "-p", "--", "../other"},
+		{"log", "-p", "../other/notes.md"},
+		{"show", "HEAD:other/notes.md"},
+		{"show", "HEAD:../other/notes.md"},
+		{"show", "HEAD:app/secret.env"},
+		{"show", ":app/secret.env"},
+		{"log", "-p", "--", "secret.env"},
+		{"diff", "HEAD", "--", ":(top)other"},
+	}
+	for _, args := range refused {
+		if out, err := run(args[0], args[1:]...); err == nil {
+			t.Errorf("Expected git %s to be refused, got: %s", strings.Join(args, " "), out)
+		}
+	}
+
+	// Without paths, output is limited to the sandbox and leaves ignored files out
+	out, err := run("log", "-p", "-n", "1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(out, "app/main.go") || strings.Contains(out, "notes.md") || strings.Contains(out, "secret.env") || strings.Contains(out, "TOKEN") {
+		t.Errorf("Expected only the sandbox's files that are not ignored, got: %s", out)
+	}
+	if out, err := run("show", "HEAD:app/main.go"); err != nil || !strings.Contains(out, "package main") {
+		t.Errorf("Expected a file in the sandbox to be shown, got %q, %v", out, err)
+	}
+	if _, err := run("log", "main", "--oneline", "main.go"); err != nil {
+		t.Errorf("Expected a revision and a path in the sandbox to be allowed, got: %v", err)
+	}
+}
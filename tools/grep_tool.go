@@ -4,18 +4,29 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"hash/fnv"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // GrepTool provides code search functionality for the LLM
-type GrepTool struct{}
+type GrepTool struct {
+	MaxFileSize int64  // Files larger than this many bytes are skipped (0 disables the limit)
+	Root        string // Sandbox root; empty means the repository root of the current directory
+
+	cache *grepCache // Results from earlier searches in this session; nil disables caching
+}
 
 // NewGrepTool creates a new grep tool
 func NewGrepTool() *GrepTool {
-	return &GrepTool{}
+	return &GrepTool{MaxFileSize: DefaultMaxFileSize, cache: newGrepCache()}
 }
 
 // Name returns the name of the tool
@@ -39,12 +50,17 @@ func (g *GrepTool) Parameters() map[string]interface{} {
 			},
 			"path": map[string]interface{}{
 				"type":        "string",
-				"description": "The directory or file to search in (default: current directory)",
+				"description": "The directory or file to search in, relative to the repository root (default: repository root)",
 			},
 			"file_pattern": map[string]interface{}{
 				"type":        "string",
 				"description": "File name pattern to filter files (e.g., '*.go', '*.js')",
 			},
+			"type": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "File types to search, like ripgrep's --type (e.g. ['go'], ['js', 'ts']). Known types: " + strings.Join(knownFileTypes(), ", "),
+			},
 			"case_sensitive": map[string]interface{}{
 				"type":        "boolean",
 				"description": "Whether the search should be case sensitive (default: true)",
@@ -61,6 +77,14 @@ func (g *GrepTool) Parameters() map[string]interface{} {
 				"type":        "integer",
 				"description": "Number of context lines to show before and after matches (default: 0)",
 			},
+			"count_only": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Only return per-file and total match counts, not the matching lines (default: false)",
+			},
+			"max_file_size": map[string]interface{}{
+				"type":        "integer",
+				"description": "Skip files larger than this many bytes (default: 10485760, 0 for no limit)",
+			},
 		},
 		"required": []string{"pattern"},
 	}
@@ -84,6 +108,11 @@ func (g *GrepTool) Execute(ctx context.Context, params map[string]interface{}) (
 		filePattern = fp
 	}
 
+	typeGlobs, fileTypes, err := fileTypeGlobs(params["type"])
+	if err != nil {
+		return "", err
+	}
+
 	caseSensitive := true
 	if cs, ok := params["case_sensitive"].(bool); ok {
 		caseSensitive = cs
@@ -104,9 +133,15 @@ func (g *GrepTool) Execute(ctx context.Context, params map[string]interface{}) (
 		contextLines = int(cl)
 	}
 
+	countOnly := false
+	if co, ok := params["count_only"].(bool); ok {
+		countOnly = co
+	}
+
+	maxFileSize := maxFileSizeParam(params, g.MaxFileSize)
+
 	// Compile the regex pattern
 	var re *regexp.Regexp
-	var err error
 	if caseSensitive {
 		re, err = regexp.Compile(pattern)
 	} else {
@@ -116,83 +151,500 @@ func (g *GrepTool) Execute(ctx context.Context, params map[string]interface{}) (
 		return "", fmt.Errorf("invalid regex pattern: %v", err)
 	}
 
-	// Get the current working directory as the base directory
-	baseDir, err := os.Getwd()
+	// Confine the search to the sandbox, checking containment after resolving symlinks
+	sandbox, err := NewSandbox(g.Root)
 	if err != nil {
-		return "", fmt.Errorf("failed to get working directory: %v", err)
+		return "", err
 	}
-
-	// Clean and resolve the search path
-	searchPath = filepath.Clean(searchPath)
-	if !filepath.IsAbs(searchPath) {
-		searchPath = filepath.Join(baseDir, searchPath)
+	searchPath, _, err = sandbox.Resolve(searchPath)
+	if err != nil {
+		return "", err
+	}
+	baseDir := sandbox.Root
+
+	// Reuse the result of an identical search if nothing it covered has changed
+	// since. This is checked before walking: the entry lists the paths the
+	// earlier walk saw, and stat-ing those again is far cheaper than a new walk.
+	cacheKey := strings.Join([]string{
+		pattern, searchPath, filePattern, strings.Join(fileTypes, ","), gitState(baseDir),
+		fmt.Sprint(caseSensitive, maxResults, includeLineNumbers, contextLines, maxFileSize, countOnly),
+	}, "\x00")
+	if result, ok := g.cache.get(cacheKey); ok {
+		return result, nil
 	}
 
-	// Ensure the search path is within the base directory
-	relPath, err := filepath.Rel(baseDir, searchPath)
-	if err != nil || strings.HasPrefix(relPath, "..") {
-		return "", fmt.Errorf("access denied: path is outside the working directory")
+	ignored, err := sandbox.loadIgnore()
+	if err != nil {
+		return "", err
 	}
 
-	// Perform the search
-	results := []string{}
-	resultCount := 0
+	// Collect candidate files; the walk visits paths in lexical order, which keeps
+	// merged results deterministic. Every directory walked and file considered is
+	// recorded so a cached result is only reused for an unchanged tree: a new or
+	// removed file changes its directory's modification time.
+	var candidates []string
+	var skipped []string
+	watched := []string{filepath.Join(ignored.root, ".workieignore")}
+	fingerprint := fnv.New64a()
+	writeFileState(fingerprint, watched[0], statOrNil(watched[0]))
 
 	err = filepath.Walk(searchPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil // Skip files with errors
 		}
 
-		// Skip directories and binary files
-		if info.IsDir() || isBinaryFile(path) {
+		// Skip anything excluded by .workieignore
+		if ignored.Match(path, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Skip hidden directories such as .git without walking or watching them,
+		// so git activity doesn't invalidate cached results
+		if info.IsDir() && path != searchPath && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		// Skip directories and binary files, remembering the directories walked
+		if info.IsDir() {
+			watched = append(watched, path)
+			writeFileState(fingerprint, path, info)
+			return nil
+		}
+		if isBinaryFile(path) {
+			return nil
+		}
+
+		// Never follow a symlinked file out of the sandbox
+		if info.Mode()&os.ModeSymlink != 0 && !sandbox.Contains(path) {
 			return nil
 		}
 
-		// Skip hidden files and directories
-		if strings.Contains(path, "/.") {
+		// Skip hidden files
+		if strings.HasPrefix(info.Name(), ".") {
 			return nil
 		}
 
 		// Check file pattern
 		matched, err := filepath.Match(filePattern, filepath.Base(path))
-		if err != nil || !matched {
+		if err != nil || !matched || !matchesAny(typeGlobs, filepath.Base(path)) {
 			return nil
 		}
 
-		// Search in the file
-		fileResults, count, err := searchInFile(path, re, includeLineNumbers, contextLines, maxResults-resultCount)
+		watched = append(watched, path)
+		writeFileState(fingerprint, path, info)
+
+		// Skip files that are too large to search quickly, before opening them
+		if maxFileSize > 0 && info.Size() > maxFileSize {
+			relPath, _ := filepath.Rel(baseDir, path)
+			skipped = append(skipped, fmt.Sprintf("%s: skipped (too large, %d bytes)", relPath, info.Size()))
+			return nil
+		}
+
+		candidates = append(candidates, path)
+		return nil
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("error during search: %v", err)
+	}
+
+	skippedNote := ""
+	if len(skipped) > 0 {
+		skippedNote = "\n\n" + strings.Join(skipped, "\n")
+	}
+
+	if countOnly {
+		result, err := countMatches(ctx, candidates, baseDir, re)
 		if err != nil {
-			return nil // Skip files with errors
+			return "", err
 		}
+		result += skippedNote
+		g.cache.put(cacheKey, watched, fingerprint.Sum64(), result)
+		return result, nil
+	}
 
-		if len(fileResults) > 0 {
-			relPath, _ := filepath.Rel(baseDir, path)
-			results = append(results, fmt.Sprintf("\n=== %s ===", relPath))
-			results = append(results, fileResults...)
-			resultCount += count
+	// Search candidates in parallel and merge them back in path order
+	searched := searchFiles(ctx, candidates, maxResults, func(path string) ([]string, int, error) {
+		return searchInFile(path, re, includeLineNumbers, contextLines, maxResults)
+	})
+
+	results := []string{}
+	resultCount := 0
+	for i, fr := range searched {
+		if resultCount >= maxResults {
+			break
+		}
+		if fr.count == 0 {
+			continue
+		}
+
+		fileResults := fr.lines
+		if resultCount+fr.count > maxResults {
+			// Only part of this file fits; search it again with the remaining budget
+			fileResults, fr.count, _ = searchInFile(candidates[i], re, includeLineNumbers, contextLines, maxResults-resultCount)
 		}
 
+		relPath, _ := filepath.Rel(baseDir, candidates[i])
+		results = append(results, fmt.Sprintf("\n=== %s ===", relPath))
+		results = append(results, fileResults...)
+		resultCount += fr.count
+	}
+
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("search cancelled: %v", err)
+	}
+
+	result := "No matches found"
+	if len(results) > 0 {
+		result = strings.Join(results, "\n")
 		if resultCount >= maxResults {
-			return filepath.SkipAll
+			result += fmt.Sprintf("\n\n... (search limited to %d results)", maxResults)
 		}
+	}
+	result += skippedNote
+
+	g.cache.put(cacheKey, watched, fingerprint.Sum64(), result)
+	return result, nil
+}
+
+// maxGrepCacheEntries bounds the memory used by a session's grep cache
+const maxGrepCacheEntries = 128
+
+// grepCache holds search results keyed by the search parameters and git state.
+// Each entry also records the paths its search walked and a fingerprint of their
+// sizes and modification times, so edits to the working tree that don't touch
+// git still invalidate it.
+type grepCache struct {
+	mu      sync.Mutex
+	entries map[string]grepCacheEntry
+}
+
+// grepCacheEntry is a cached search result
+type grepCacheEntry struct {
+	paths       []string
+	fingerprint uint64
+	result      string
+}
+
+// newGrepCache creates an empty grep cache
+func newGrepCache() *grepCache {
+	return &grepCache{entries: make(map[string]grepCacheEntry)}
+}
+
+// get returns the cached result for key if the paths it covered are unchanged
+func (c *grepCache) get(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	fingerprint := fnv.New64a()
+	for _, path := range entry.paths {
+		writeFileState(fingerprint, path, statOrNil(path))
+	}
+	if entry.fingerprint != fingerprint.Sum64() {
+		delete(c.entries, key)
+		return "", false
+	}
+	return entry.result, true
+}
+
+// put stores a result, starting over when the cache is full
+func (c *grepCache) put(key string, paths []string, fingerprint uint64, result string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
+	if len(c.entries) >= maxGrepCacheEntries {
+		c.entries = make(map[string]grepCacheEntry)
+	}
+	c.entries[key] = grepCacheEntry{paths: paths, fingerprint: fingerprint, result: result}
+}
+
+// writeFileState adds the size and modification time of path to a fingerprint;
+// info is nil for a path that does not exist
+func writeFileState(w io.Writer, path string, info os.FileInfo) {
+	if info == nil {
+		fmt.Fprintf(w, "%s\x00missing\n", path)
+		return
+	}
+	fmt.Fprintf(w, "%s\x00%d\x00%d\n", path, info.Size(), info.ModTime().UnixNano())
+}
+
+// statOrNil returns the file info of path without following a final symlink,
+// matching filepath.Walk, or nil when it cannot be read
+func statOrNil(path string) os.FileInfo {
+	info, err := os.Lstat(path)
+	if err != nil {
 		return nil
+	}
+	return info
+}
+
+// gitState identifies the git state of the repository containing dir from its
+// HEAD and index, reading the files directly rather than starting git, so it is
+// cheap enough to check on every search. It returns "" outside a repository.
+func gitState(dir string) string {
+	gitDir := findGitDir(dir)
+	if gitDir == "" {
+		return ""
+	}
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	state := strings.TrimSpace(string(head))
+	if ref, ok := strings.CutPrefix(state, "ref: "); ok {
+		// Branches of a linked worktree live in the main repository's git directory.
+		// A ref that is only in packed-refs is left out: committing to it rewrites
+		// the index, which is checked below.
+		refDir := gitDir
+		if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+			refDir = strings.TrimSpace(string(common))
+			if !filepath.IsAbs(refDir) {
+				refDir = filepath.Join(gitDir, refDir)
+			}
+		}
+		if commit, err := os.ReadFile(filepath.Join(refDir, ref)); err == nil {
+			state += "\x00" + strings.TrimSpace(string(commit))
+		}
+	}
+	if info, err := os.Stat(filepath.Join(gitDir, "index")); err == nil {
+		state += fmt.Sprintf("\x00%d\x00%d", info.Size(), info.ModTime().UnixNano())
+	}
+	return state
+}
+
+// findGitDir returns the git directory of the repository containing dir,
+// following the .git file of a linked worktree, or "" when there is none
+func findGitDir(dir string) string {
+	for {
+		dotGit := filepath.Join(dir, ".git")
+		if info, err := os.Stat(dotGit); err == nil {
+			if info.IsDir() {
+				return dotGit
+			}
+			content, err := os.ReadFile(dotGit)
+			if err != nil {
+				return ""
+			}
+			gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir: ")
+			if !ok {
+				return ""
+			}
+			if !filepath.IsAbs(gitDir) {
+				gitDir = filepath.Join(dir, gitDir)
+			}
+			return gitDir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// fileTypeGlobsByName maps file type names to the file name globs they cover,
+// modelled on ripgrep's --type
+var fileTypeGlobsByName = map[string][]string{
+	"c":        {"*.c", "*.h"},
+	"cpp":      {"*.cc", "*.cpp", "*.cxx", "*.hh", "*.hpp", "*.hxx", "*.h"},
+	"css":      {"*.css", "*.scss", "*.sass", "*.less"},
+	"go":       {"*.go"},
+	"html":     {"*.html", "*.htm"},
+	"java":     {"*.java"},
+	"js":       {"*.js", "*.jsx", "*.mjs", "*.cjs"},
+	"json":     {"*.json"},
+	"markdown": {"*.md", "*.markdown"},
+	"python":   {"*.py", "*.pyi"},
+	"ruby":     {"*.rb", "*.rake", "*.gemspec", "Gemfile", "Rakefile"},
+	"rust":     {"*.rs"},
+	"sh":       {"*.sh", "*.bash", "*.zsh"},
+	"sql":      {"*.sql"},
+	"ts":       {"*.ts", "*.tsx", "*.mts", "*.cts"},
+	"yaml":     {"*.yaml", "*.yml"},
+}
+
+// fileTypeAliases maps alternative names to entries in fileTypeGlobsByName
+var fileTypeAliases = map[string]string{
+	"golang":     "go",
+	"javascript": "js",
+	"md":         "markdown",
+	"py":         "python",
+	"rb":         "ruby",
+	"rs":         "rust",
+	"shell":      "sh",
+	"typescript": "ts",
+	"yml":        "yaml",
+}
+
+// knownFileTypes returns the sorted names of the built-in file types
+func knownFileTypes() []string {
+	names := make([]string, 0, len(fileTypeGlobsByName))
+	for name := range fileTypeGlobsByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fileTypeGlobs resolves the type parameter, given as a string (optionally comma
+// separated) or a list of strings, into file name globs and the normalized type names
+func fileTypeGlobs(param interface{}) ([]string, []string, error) {
+	var names []string
+	switch v := param.(type) {
+	case nil:
+		return nil, nil, nil
+	case string:
+		names = strings.Split(v, ",")
+	case []string:
+		names = v
+	case []interface{}:
+		for _, item := range v {
+			name, ok := item.(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("type must be a string or a list of strings")
+			}
+			names = append(names, name)
+		}
+	default:
+		return nil, nil, fmt.Errorf("type must be a string or a list of strings")
+	}
+
+	var globs, types []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if alias, ok := fileTypeAliases[name]; ok {
+			name = alias
+		}
+		typeGlobs, ok := fileTypeGlobsByName[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown file type '%s' (known types: %s)", name, strings.Join(knownFileTypes(), ", "))
+		}
+		globs = append(globs, typeGlobs...)
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return globs, types, nil
+}
+
+// matchesAny reports whether name matches one of globs; an empty list matches everything
+func matchesAny(globs []string, name string) bool {
+	if len(globs) == 0 {
+		return true
+	}
+	for _, glob := range globs {
+		if matched, _ := filepath.Match(glob, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// fileResult holds the matches found in one file by a search worker
+type fileResult struct {
+	lines []string
+	count int
+}
+
+// countMatches counts the matching lines in every file and formats per-file and
+// total counts. Unlike a normal search it is not limited by max_results.
+func countMatches(ctx context.Context, files []string, baseDir string, re *regexp.Regexp) (string, error) {
+	counted := searchFiles(ctx, files, 0, func(path string) ([]string, int, error) {
+		count, err := countInFile(path, re)
+		return nil, count, err
 	})
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("search cancelled: %v", err)
+	}
 
-	if err != nil && err != filepath.SkipAll {
-		return "", fmt.Errorf("error during search: %v", err)
+	var lines []string
+	total, fileCount := 0, 0
+	for i, fr := range counted {
+		if fr.count == 0 {
+			continue
+		}
+		relPath, _ := filepath.Rel(baseDir, files[i])
+		lines = append(lines, fmt.Sprintf("%s: %d", relPath, fr.count))
+		total += fr.count
+		fileCount++
 	}
 
-	if len(results) == 0 {
+	if total == 0 {
 		return "No matches found", nil
 	}
+	lines = append(lines, fmt.Sprintf("\nTotal: %d matches in %d files", total, fileCount))
+	return strings.Join(lines, "\n"), nil
+}
 
-	result := strings.Join(results, "\n")
-	if resultCount >= maxResults {
-		result += fmt.Sprintf("\n\n... (search limited to %d results)", maxResults)
+// countInFile counts the lines in a file that match re
+func countInFile(path string, re *regexp.Regexp) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
 	}
+	defer file.Close()
 
-	return result, nil
+	count := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if re.Match(scanner.Bytes()) {
+			count++
+		}
+	}
+	return count, scanner.Err()
+}
+
+// searchFiles runs search over files with a bounded worker pool. Workers claim
+// files in order and stop claiming once maxResults matches have been found (0
+// means no limit), so every file that was searched precedes every file that was
+// not. The returned slice is indexed like files.
+func searchFiles(ctx context.Context, files []string, maxResults int, search func(path string) ([]string, int, error)) []fileResult {
+	results := make([]fileResult, len(files))
+
+	workers := runtime.NumCPU()
+	if workers > len(files) {
+		workers = len(files)
+	}
+
+	var next, found atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil && (maxResults <= 0 || found.Load() < int64(maxResults)) {
+				i := int(next.Add(1)) - 1
+				if i >= len(files) {
+					return
+				}
+
+				lines, count, err := search(files[i])
+				if err != nil {
+					continue // Skip files with errors
+				}
+				results[i] = fileResult{lines: lines, count: count}
+				found.Add(int64(count))
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
 }
 
 // searchInFile searches for pattern in a single file
@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSourceTree creates a synthetic Go source tree under root and returns its size in bytes
+func writeSourceTree(b *testing.B, root string, packages, filesPerPackage int) int64 {
+	b.Helper()
+	var body strings.Builder
+	for i := 0; i < 200; i++ {
+		fmt.Fprintf(&body, "func helper%d(input string) string { return strings.ToUpper(input) }\n", i)
+	}
+	body.WriteString("// TODO: remove this once the migration is complete\n")
+	content := []byte("package sample\n\n" + body.String())
+
+	var total int64
+	for p := 0; p < packages; p++ {
+		dir := filepath.Join(root, fmt.Sprintf("pkg%02d", p))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			b.Fatal(err)
+		}
+		for f := 0; f < filesPerPackage; f++ {
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%02d.go", f)), content, 0644); err != nil {
+				b.Fatal(err)
+			}
+			total += int64(len(content))
+		}
+	}
+	return total
+}
+
+func BenchmarkGrepTool(b *testing.B) {
+	root := b.TempDir()
+	total := writeSourceTree(b, root, 20, 25)
+
+	// GrepTool only searches below the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		b.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	tool := NewGrepTool()
+	tool.cache = nil // Measure the search itself, not cache hits
+	queries := map[string]map[string]interface{}{
+		"rare-match": {"pattern": "TODO: remove", "file_pattern": "*.go", "max_results": float64(1000)},
+		"no-match":   {"pattern": "does-not-appear-anywhere"},
+		"count-only": {"pattern": "strings", "count_only": true},
+	}
+
+	for name, params := range queries {
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(total)
+			for i := 0; i < b.N; i++ {
+				if _, err := tool.Execute(context.Background(), params); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
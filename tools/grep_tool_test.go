@@ -0,0 +1,239 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGrepToolCache(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "main.go")
+	if err := os.WriteFile(file, []byte("package main // alpha\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(file, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	tool := NewGrepTool()
+	tool.Root = root
+	params := map[string]interface{}{"pattern": "alpha|bravo"}
+
+	search := func() string {
+		t.Helper()
+		result, err := tool.Execute(context.Background(), params)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		return result
+	}
+
+	if result := search(); !strings.Contains(result, "alpha") {
+		t.Fatalf("Expected a match for alpha, got: %s", result)
+	}
+
+	// Same size and modification time: the tree looks unchanged, so the cached
+	// result is returned without searching the file again
+	if err := os.WriteFile(file, []byte("package main // bravo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(file, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	if result := search(); !strings.Contains(result, "alpha") {
+		t.Errorf("Expected the cached result, got: %s", result)
+	}
+
+	// A modified file invalidates the cached result
+	if err := os.Chtimes(file, time.Now(), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if result := search(); !strings.Contains(result, "bravo") {
+		t.Errorf("Expected a fresh search after the file changed, got: %s", result)
+	}
+
+	// A new file invalidates it too
+	if err := os.WriteFile(filepath.Join(root, "other.go"), []byte("// bravo again\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if result := search(); !strings.Contains(result, "other.go") {
+		t.Errorf("Expected the new file to be searched, got: %s", result)
+	}
+}
+
+func TestGrepToolCacheSkipsHiddenDirectories(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "main.go"), []byte("package main // alpha\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	objects := filepath.Join(root, ".git", "objects")
+	if err := os.MkdirAll(objects, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(objects, "ab"), []byte("alpha\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tool := NewGrepTool()
+	tool.Root = root
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"pattern": "alpha"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if strings.Contains(result, ".git") {
+		t.Errorf("Expected .git not to be searched, got: %s", result)
+	}
+
+	// Nothing under .git is watched, so git activity leaves cached results alone
+	if len(tool.cache.entries) != 1 {
+		t.Fatalf("Expected the result to be cached, got %d entries", len(tool.cache.entries))
+	}
+	for _, entry := range tool.cache.entries {
+		for _, path := range entry.paths {
+			if strings.Contains(path, string(filepath.Separator)+".git") {
+				t.Errorf("Expected .git not to be watched, got %s", path)
+			}
+		}
+	}
+}
+
+func TestGitState(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, env := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(env, "test")
+	}
+	for _, env := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(env, "test@example.com")
+	}
+
+	repo := t.TempDir()
+	worktree := filepath.Join(t.TempDir(), "feature")
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+
+	if state := gitState(repo); state != "" {
+		t.Fatalf("Expected no git state outside a repository, got %q", state)
+	}
+	git(repo, "init", "-q", "-b", "main")
+	git(repo, "commit", "-q", "--allow-empty", "-m", "Initial commit")
+	git(repo, "worktree", "add", "-q", "-b", "feature", worktree)
+
+	// A commit changes the state seen from anywhere in the worktree committed in,
+	// including a linked worktree whose branch lives in the main repository
+	for _, dir := range []string{repo, worktree} {
+		sub := filepath.Join(dir, "sub")
+		if err := os.MkdirAll(sub, 0755); err != nil {
+			t.Fatal(err)
+		}
+		before := gitState(sub)
+		git(dir, "commit", "-q", "--allow-empty", "-m", "Change")
+		if after := gitState(sub); after == before {
+			t.Errorf("Expected the state of %s to change after a commit, still %q", dir, after)
+		}
+	}
+}
+
+func TestGrepToolCountOnly(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"a.go":     "// TODO: one\n// TODO: two\nfunc a() {}\n",
+		"b/b.go":   "// TODO: three\n",
+		"c/c.txt":  "nothing here\n",
+		"d/big.go": strings.Repeat("// TODO\n", 150),
+	}
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tool := NewGrepTool()
+	tool.Root = root
+
+	// Counts are complete even beyond max_results
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"pattern":     "TODO",
+		"count_only":  true,
+		"max_results": float64(10),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := "a.go: 2\nb/b.go: 1\nd/big.go: 150\n\nTotal: 153 matches in 3 files"
+	if result != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, result)
+	}
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"pattern": "missing", "count_only": true})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result != "No matches found" {
+		t.Errorf("Expected no matches, got: %s", result)
+	}
+}
+
+func TestGrepToolFileTypes(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{"main.go", "app.ts", "view.tsx", "lib.js", "script.py", "Gemfile", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(root, rel), []byte("needle\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tool := NewGrepTool()
+	tool.Root = root
+
+	tests := []struct {
+		name      string
+		fileTypes interface{}
+		want      []string
+	}{
+		{"single type", "go", []string{"main.go"}},
+		{"list of types", []interface{}{"ts", "javascript"}, []string{"app.ts", "lib.js", "view.tsx"}},
+		{"comma separated", "py,ruby", []string{"Gemfile", "script.py"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Execute(context.Background(), map[string]interface{}{
+				"pattern":    "needle",
+				"type":       tt.fileTypes,
+				"count_only": true,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			var got []string
+			for _, line := range strings.Split(result, "\n") {
+				if file, _, ok := strings.Cut(line, ": "); ok && file != "Total" {
+					got = append(got, file)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected files %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := tool.Execute(context.Background(), map[string]interface{}{"pattern": "needle", "type": "cobol"}); err == nil {
+		t.Error("Expected error for an unknown file type")
+	}
+}
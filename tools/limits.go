@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"fmt"
+	"time"
+)
+
+// AgentLimits bounds the work an agent may perform for a single query so a
+// misbehaving model cannot run away with expensive tool calls
+type AgentLimits struct {
+	MaxIterations        int            // Maximum LLM round trips
+	MaxCallsPerTool      int            // Maximum invocations of any one tool
+	ToolCallLimits       map[string]int // Per-tool overrides of MaxCallsPerTool
+	Timeout              time.Duration  // Total wall-clock budget (0 for none)
+	MaxConsecutiveErrors int            // Circuit breaker: abort after this many failed tool calls in a row
+}
+
+// DefaultAgentLimits returns the limits used when none are configured
+func DefaultAgentLimits() AgentLimits {
+	return AgentLimits{
+		MaxIterations:        5,
+		MaxCallsPerTool:      10,
+		Timeout:              2 * time.Minute,
+		MaxConsecutiveErrors: 3,
+	}
+}
+
+// callLimit returns the maximum number of calls allowed for the named tool
+func (l AgentLimits) callLimit(name string) int {
+	if limit, ok := l.ToolCallLimits[name]; ok {
+		return limit
+	}
+	return l.MaxCallsPerTool
+}
+
+// toolBudget tracks tool usage against AgentLimits during one query
+type toolBudget struct {
+	limits            AgentLimits
+	calls             map[string]int
+	consecutiveErrors int
+}
+
+func newToolBudget(limits AgentLimits) *toolBudget {
+	return &toolBudget{limits: limits, calls: make(map[string]int)}
+}
+
+// allow records a call to the named tool, returning an error if its cap is reached
+func (b *toolBudget) allow(name string) error {
+	limit := b.limits.callLimit(name)
+	if limit > 0 && b.calls[name] >= limit {
+		return fmt.Errorf("call limit reached for tool '%s' (%d per query); answer with the information you already have", name, limit)
+	}
+	b.calls[name]++
+	return nil
+}
+
+// recordError counts a failed tool call and reports whether the circuit breaker tripped
+func (b *toolBudget) recordError() bool {
+	b.consecutiveErrors++
+	return b.limits.MaxConsecutiveErrors > 0 && b.consecutiveErrors >= b.limits.MaxConsecutiveErrors
+}
+
+// recordSuccess resets the consecutive error count
+func (b *toolBudget) recordSuccess() {
+	b.consecutiveErrors = 0
+}
@@ -13,6 +13,8 @@ type OllamaAgent struct {
 	llm      llms.Model
 	registry *ToolRegistry
 	verbose  bool
+	limits   AgentLimits
+	system   string // Instructions placed before the tool descriptions
 }
 
 // NewOllamaAgent creates a new Ollama agent
@@ -21,26 +23,72 @@ func NewOllamaAgent(llm llms.Model, registry *ToolRegistry, verbose bool) *Ollam
 		llm:      llm,
 		registry: registry,
 		verbose:  verbose,
+		limits:   DefaultAgentLimits(),
 	}
 }
 
-// Execute processes a user query and executes tools as needed
+// SetLimits replaces the agent's per-query limits
+func (a *OllamaAgent) SetLimits(limits AgentLimits) {
+	a.limits = limits
+}
+
+// SetSystemPrompt sets instructions (tone, repository context, coding standards)
+// that are prepended to the agent's prompt
+func (a *OllamaAgent) SetSystemPrompt(prompt string) {
+	a.system = strings.TrimSpace(prompt)
+}
+
+// systemPrompt combines the configured instructions with the tool descriptions
+func (a *OllamaAgent) systemPrompt() string {
+	toolsPrompt := FormatToolsPrompt(a.registry.List())
+	if a.system == "" {
+		return toolsPrompt
+	}
+	return a.system + "\n\n" + toolsPrompt
+}
+
+// withBudget applies the configured wall-clock budget to ctx
+func (a *OllamaAgent) withBudget(ctx context.Context) (context.Context, context.CancelFunc) {
+	if a.limits.Timeout > 0 {
+		return context.WithTimeout(ctx, a.limits.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// budgetError explains why the agent stopped when its context is done
+func (a *OllamaAgent) budgetError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("agent stopped: time budget of %s exceeded", a.limits.Timeout)
+	}
+	return ctx.Err()
+}
+
+// Execute processes a user query and executes tools as needed, within the agent's limits
 func (a *OllamaAgent) Execute(ctx context.Context, query string) (string, error) {
+	ctx, cancel := a.withBudget(ctx)
+	defer cancel()
+
 	// Build the system prompt with tool descriptions
-	tools := a.registry.List()
-	systemPrompt := FormatToolsPrompt(tools)
+	systemPrompt := a.systemPrompt()
 
 	// Combine system prompt with user query
 	fullPrompt := systemPrompt + "\n\nUser Query: " + query + "\n\nThink about whether you need to use a tool to answer this query. If yes, respond with the appropriate tool JSON. If no, respond with the answer directly.\n\nAssistant:"
 
 	// Keep track of conversation for multi-turn interactions
 	conversation := []string{fullPrompt}
-	maxIterations := 5
+	budget := newToolBudget(a.limits)
+
+	for i := 0; i < a.limits.MaxIterations; i++ {
+		if ctx.Err() != nil {
+			return "", a.budgetError(ctx)
+		}
 
-	for i := 0; i < maxIterations; i++ {
 		// Get response from LLM
 		response, err := a.llm.Call(ctx, strings.Join(conversation, "\n"))
 		if err != nil {
+			if ctx.Err() != nil {
+				return "", a.budgetError(ctx)
+			}
 			return "", fmt.Errorf("LLM call failed: %v", err)
 		}
 
@@ -59,29 +107,21 @@ func (a *OllamaAgent) Execute(ctx context.Context, query string) (string, error)
 			return response, nil
 		}
 
-		// Execute the tool
-		tool, exists := a.registry.Get(toolCall.Name)
-		if !exists {
-			errMsg := fmt.Sprintf("Tool '%s' not found", toolCall.Name)
-			conversation = append(conversation, response)
-			conversation = append(conversation, "Tool Error: "+errMsg)
-			continue
-		}
-
-		if a.verbose {
-			fmt.Printf("Executing tool: %s with parameters: %v\n", toolCall.Name, toolCall.Parameters)
-		}
-
-		result, err := tool.Execute(ctx, toolCall.Parameters)
+		result, err := a.executeTool(ctx, toolCall, budget)
+		conversation = append(conversation, response)
 		if err != nil {
-			errMsg := fmt.Sprintf("Tool execution failed: %v", err)
-			conversation = append(conversation, response)
-			conversation = append(conversation, "Tool Error: "+errMsg)
+			if ctx.Err() != nil {
+				return "", a.budgetError(ctx)
+			}
+			if budget.recordError() {
+				return "", fmt.Errorf("agent stopped: %d tool calls failed in a row (last error: %v)", budget.consecutiveErrors, err)
+			}
+			conversation = append(conversation, "Tool Error: "+err.Error())
 			continue
 		}
+		budget.recordSuccess()
 
 		// Add tool result to conversation
-		conversation = append(conversation, response)
 		conversation = append(conversation, fmt.Sprintf("Tool Result: %s", result))
 		conversation = append(conversation, "Based on the tool result above, please provide a natural language answer to the user's original query. Be concise and direct.")
 	}
@@ -89,11 +129,35 @@ func (a *OllamaAgent) Execute(ctx context.Context, query string) (string, error)
 	return "Maximum iterations reached. Unable to complete the request.", nil
 }
 
+// executeTool runs a tool call if the tool exists and is within its call limit
+func (a *OllamaAgent) executeTool(ctx context.Context, toolCall *ToolCall, budget *toolBudget) (string, error) {
+	tool, exists := a.registry.Get(toolCall.Name)
+	if !exists {
+		return "", fmt.Errorf("Tool '%s' not found", toolCall.Name)
+	}
+
+	if err := budget.allow(toolCall.Name); err != nil {
+		return "", err
+	}
+
+	if a.verbose {
+		fmt.Printf("Executing tool: %s with parameters: %v\n", toolCall.Name, toolCall.Parameters)
+	}
+
+	result, err := tool.Execute(ctx, toolCall.Parameters)
+	if err != nil {
+		return "", fmt.Errorf("Tool execution failed: %v", err)
+	}
+	return result, nil
+}
+
 // ExecuteWithHistory processes a query with conversation history
 func (a *OllamaAgent) ExecuteWithHistory(ctx context.Context, query string, history []string) (string, error) {
+	ctx, cancel := a.withBudget(ctx)
+	defer cancel()
+
 	// Build the system prompt with tool descriptions
-	tools := a.registry.List()
-	systemPrompt := FormatToolsPrompt(tools)
+	systemPrompt := a.systemPrompt()
 
 	// Build conversation with history
 	conversation := []string{systemPrompt}
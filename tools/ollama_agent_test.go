@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// scriptedLLM always answers with the same response and counts calls
+type scriptedLLM struct {
+	response string
+	delay    time.Duration
+	calls    int
+	prompts  []string
+}
+
+func (m *scriptedLLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *scriptedLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	m.calls++
+	m.prompts = append(m.prompts, prompt)
+	if m.delay > 0 {
+		select {
+		case <-time.After(m.delay):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	return m.response, nil
+}
+
+// countingTool records invocations and optionally fails
+type countingTool struct {
+	calls int
+	fail  bool
+}
+
+func (t *countingTool) Name() string                       { return "counter" }
+func (t *countingTool) Description() string                { return "counts calls" }
+func (t *countingTool) Parameters() map[string]interface{} { return map[string]interface{}{} }
+func (t *countingTool) Execute(ctx context.Context, params map[string]interface{}) (string, error) {
+	t.calls++
+	if t.fail {
+		return "", errors.New("boom")
+	}
+	return "ok", nil
+}
+
+func TestOllamaAgentLimits(t *testing.T) {
+	toolCallJSON := `{"tool": "counter", "parameters": {}}`
+
+	t.Run("per-tool call cap", func(t *testing.T) {
+		tool := &countingTool{}
+		registry := NewToolRegistry()
+		registry.Register(tool)
+
+		limits := DefaultAgentLimits()
+		limits.MaxIterations = 10
+		limits.ToolCallLimits = map[string]int{"counter": 2}
+		limits.MaxConsecutiveErrors = 0
+
+		agent := NewOllamaAgent(&scriptedLLM{response: toolCallJSON}, registry, false)
+		agent.SetLimits(limits)
+
+		if _, err := agent.Execute(context.Background(), "loop forever"); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if tool.calls != 2 {
+			t.Errorf("Expected tool to be called 2 times, got %d", tool.calls)
+		}
+	})
+
+	t.Run("circuit breaker on repeated errors", func(t *testing.T) {
+		tool := &countingTool{fail: true}
+		registry := NewToolRegistry()
+		registry.Register(tool)
+
+		limits := DefaultAgentLimits()
+		limits.MaxIterations = 10
+		limits.MaxConsecutiveErrors = 3
+
+		agent := NewOllamaAgent(&scriptedLLM{response: toolCallJSON}, registry, false)
+		agent.SetLimits(limits)
+
+		_, err := agent.Execute(context.Background(), "keep failing")
+		if err == nil || !strings.Contains(err.Error(), "3 tool calls failed in a row") {
+			t.Fatalf("Expected circuit breaker error, got: %v", err)
+		}
+		if tool.calls != 3 {
+			t.Errorf("Expected 3 tool calls before aborting, got %d", tool.calls)
+		}
+	})
+
+	t.Run("wall-clock budget", func(t *testing.T) {
+		limits := DefaultAgentLimits()
+		limits.Timeout = 20 * time.Millisecond
+
+		agent := NewOllamaAgent(&scriptedLLM{response: "done", delay: time.Second}, NewToolRegistry(), false)
+		agent.SetLimits(limits)
+
+		_, err := agent.Execute(context.Background(), "slow")
+		if err == nil || !strings.Contains(err.Error(), "time budget") {
+			t.Fatalf("Expected time budget error, got: %v", err)
+		}
+	})
+}
+
+func TestOllamaAgentSystemPrompt(t *testing.T) {
+	llm := &scriptedLLM{response: "done"}
+	agent := NewOllamaAgent(llm, NewToolRegistry(), false)
+	agent.SetSystemPrompt("Answer like a pirate.\n")
+
+	if _, err := agent.Execute(context.Background(), "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if len(llm.prompts) != 1 || !strings.HasPrefix(llm.prompts[0], "Answer like a pirate.\n\n") {
+		t.Errorf("Expected the system prompt to start the prompt, got: %q", llm.prompts)
+	}
+}
@@ -0,0 +1,274 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DefaultMaxReplaceFiles is the default cap on the number of files a replacement may change
+const DefaultMaxReplaceFiles = 20
+
+// ReplaceTool performs regex find-and-replace across files. By default it only
+// previews the changes as a diff; they are written when the caller passes
+// apply: true and AllowApply is set.
+type ReplaceTool struct {
+	MaxFileSize int64  // Files larger than this many bytes are skipped (0 disables the limit)
+	MaxFiles    int    // Maximum number of files one replacement may change
+	Root        string // Sandbox root; empty means the repository root of the current directory
+	AllowApply  bool   // Whether apply: true may write files; otherwise only previews are produced
+}
+
+// NewReplaceTool creates a new replace tool that can apply changes
+func NewReplaceTool() *ReplaceTool {
+	return &ReplaceTool{
+		MaxFileSize: DefaultMaxFileSize,
+		MaxFiles:    DefaultMaxReplaceFiles,
+		AllowApply:  true,
+	}
+}
+
+// Name returns the name of the tool
+func (r *ReplaceTool) Name() string {
+	return "replace"
+}
+
+// Description returns what the tool does
+func (r *ReplaceTool) Description() string {
+	return "Find and replace a regular expression across files, previewing the changes as a diff before applying them"
+}
+
+// Parameters returns the JSON schema for the tool's parameters
+func (r *ReplaceTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"pattern": map[string]interface{}{
+				"type":        "string",
+				"description": "The regular expression to replace; it is matched against one line at a time",
+			},
+			"replacement": map[string]interface{}{
+				"type":        "string",
+				"description": "The replacement text; $1, ${name} etc. refer to capture groups",
+			},
+			"path": map[string]interface{}{
+				"type":        "string",
+				"description": "The directory or file to change, relative to the repository root (default: repository root)",
+			},
+			"file_pattern": map[string]interface{}{
+				"type":        "string",
+				"description": "File name pattern to filter files (e.g., '*.go', '*.js')",
+			},
+			"type": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "File types to change, like the grep tool's type parameter (e.g. ['go'])",
+			},
+			"apply": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Write the changes to disk; without it only a diff is shown (default: false)",
+			},
+		},
+		"required": []string{"pattern", "replacement"},
+	}
+}
+
+// fileReplacement holds the proposed new content for one file
+type fileReplacement struct {
+	path    string
+	relPath string
+	mode    os.FileMode
+	content string
+	diff    []string
+}
+
+// Execute previews or applies the replacement
+func (r *ReplaceTool) Execute(ctx context.Context, params map[string]interface{}) (string, error) {
+	pattern, ok := params["pattern"].(string)
+	if !ok || pattern == "" {
+		return "", fmt.Errorf("pattern parameter is required")
+	}
+	replacement, ok := params["replacement"].(string)
+	if !ok {
+		return "", fmt.Errorf("replacement parameter is required")
+	}
+
+	searchPath := "."
+	if path, ok := params["path"].(string); ok {
+		searchPath = path
+	}
+
+	filePattern := "*"
+	if fp, ok := params["file_pattern"].(string); ok {
+		filePattern = fp
+	}
+
+	typeGlobs, _, err := fileTypeGlobs(params["type"])
+	if err != nil {
+		return "", err
+	}
+
+	apply, _ := params["apply"].(bool)
+	if apply && !r.AllowApply {
+		return "", fmt.Errorf("applying replacements is disabled; show the diff to the user instead")
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid regex pattern: %v", err)
+	}
+
+	// Confine changes to the sandbox, checking containment after resolving symlinks
+	sandbox, err := NewSandbox(r.Root)
+	if err != nil {
+		return "", err
+	}
+	searchPath, _, err = sandbox.Resolve(searchPath)
+	if err != nil {
+		return "", err
+	}
+	baseDir := sandbox.Root
+
+	ignored, err := sandbox.loadIgnore()
+	if err != nil {
+		return "", err
+	}
+
+	maxFiles := r.MaxFiles
+	if maxFiles <= 0 {
+		maxFiles = DefaultMaxReplaceFiles
+	}
+
+	var changes []fileReplacement
+	tooMany := false
+
+	err = filepath.Walk(searchPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip files with errors
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		if ignored.Match(path, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Only regular files are changed; rewriting a symlink would replace the link itself
+		if !info.Mode().IsRegular() || isBinaryFile(path) || strings.Contains(path, "/.") {
+			return nil
+		}
+
+		matched, err := filepath.Match(filePattern, filepath.Base(path))
+		if err != nil || !matched || !matchesAny(typeGlobs, filepath.Base(path)) {
+			return nil
+		}
+		if r.MaxFileSize > 0 && info.Size() > r.MaxFileSize {
+			return nil
+		}
+
+		change, err := replaceInFile(path, re, replacement)
+		if err != nil || change == nil {
+			return nil // Skip unreadable and unchanged files
+		}
+		if len(changes) == maxFiles {
+			tooMany = true
+			return filepath.SkipAll
+		}
+
+		change.relPath, _ = filepath.Rel(baseDir, path)
+		change.mode = info.Mode().Perm()
+		changes = append(changes, *change)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error during replace: %v", err)
+	}
+
+	if len(changes) == 0 {
+		return "No matches found", nil
+	}
+
+	if tooMany && apply {
+		return "", fmt.Errorf("replacement would change more than %d files; narrow it with path, file_pattern or type", maxFiles)
+	}
+
+	var result []string
+	for _, change := range changes {
+		result = append(result, fmt.Sprintf("--- a/%s\n+++ b/%s", change.relPath, change.relPath))
+		result = append(result, change.diff...)
+	}
+	if tooMany {
+		result = append(result, fmt.Sprintf("\n... (preview limited to %d files; narrow it with path, file_pattern or type before applying)", maxFiles))
+	}
+
+	if !apply {
+		result = append(result, fmt.Sprintf("\nPreview only: %d files would change. Call again with apply: true to write the changes.", len(changes)))
+		return strings.Join(result, "\n"), nil
+	}
+
+	for i, change := range changes {
+		if err := writeFileAtomic(change.path, []byte(change.content), change.mode); err != nil {
+			return "", fmt.Errorf("failed to write %s after changing %d files: %v", change.relPath, i, err)
+		}
+	}
+	result = append(result, fmt.Sprintf("\nApplied: %d files changed.", len(changes)))
+	return strings.Join(result, "\n"), nil
+}
+
+// replaceInFile applies the replacement to each line of a file, returning nil if
+// nothing would change
+func replaceInFile(path string, re *regexp.Regexp, replacement string) (*fileReplacement, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	var diff []string
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		replaced := re.ReplaceAllString(line, replacement)
+		if replaced == line {
+			continue
+		}
+		diff = append(diff, fmt.Sprintf("@@ line %d @@\n-%s\n+%s", i+1, line, replaced))
+		lines[i] = replaced
+	}
+
+	if len(diff) == 0 {
+		return nil, nil
+	}
+	return &fileReplacement{path: path, content: strings.Join(lines, "\n"), diff: diff}, nil
+}
+
+// writeFileAtomic replaces path with data by writing a temporary file in the same
+// directory and renaming it over the original
+func writeFileAtomic(path string, data []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplaceTool(t *testing.T) {
+	setup := func(t *testing.T) string {
+		t.Helper()
+		root := t.TempDir()
+		files := map[string]string{
+			"a.go":          "func LoadConfig() {}\n// calls LoadConfig\n",
+			"b/b.go":        "x := LoadConfig()\n",
+			"c.txt":         "LoadConfig in prose\n",
+			"secret.go":     "LoadConfig()\n",
+			".workieignore": "secret.go\n",
+		}
+		for rel, content := range files {
+			path := filepath.Join(root, rel)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return root
+	}
+
+	read := func(t *testing.T, path string) string {
+		t.Helper()
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	t.Run("previews without writing", func(t *testing.T) {
+		root := setup(t)
+		tool := NewReplaceTool()
+		tool.Root = root
+
+		out, err := tool.Execute(context.Background(), map[string]interface{}{
+			"pattern":     `\bLoadConfig\b`,
+			"replacement": "ReadConfig",
+			"type":        "go",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		for _, want := range []string{"--- a/a.go", "-func LoadConfig() {}", "+func ReadConfig() {}", "+++ b/b/b.go", "2 files would change"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("Expected preview to contain %q, got:\n%s", want, out)
+			}
+		}
+		if strings.Contains(out, "secret.go") || strings.Contains(out, "c.txt") {
+			t.Errorf("Expected ignored and non-Go files to be left out, got:\n%s", out)
+		}
+		if got := read(t, filepath.Join(root, "a.go")); !strings.Contains(got, "LoadConfig") {
+			t.Errorf("Expected preview not to modify files, got %q", got)
+		}
+	})
+
+	t.Run("applies changes", func(t *testing.T) {
+		root := setup(t)
+		tool := NewReplaceTool()
+		tool.Root = root
+
+		_, err := tool.Execute(context.Background(), map[string]interface{}{
+			"pattern":     `(\w+) := LoadConfig\(\)`,
+			"replacement": "$1 := ReadConfig()",
+			"apply":       true,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if got := read(t, filepath.Join(root, "b", "b.go")); got != "x := ReadConfig()\n" {
+			t.Errorf("Expected replacement to be written, got %q", got)
+		}
+		if got := read(t, filepath.Join(root, "a.go")); got != "func LoadConfig() {}\n// calls LoadConfig\n" {
+			t.Errorf("Expected non-matching file to be unchanged, got %q", got)
+		}
+	})
+
+	t.Run("refuses to apply when disabled or over the file cap", func(t *testing.T) {
+		root := setup(t)
+		tool := NewReplaceTool()
+		tool.Root = root
+		params := map[string]interface{}{"pattern": "LoadConfig", "replacement": "ReadConfig", "apply": true}
+
+		tool.AllowApply = false
+		if _, err := tool.Execute(context.Background(), params); err == nil {
+			t.Error("Expected error when applying is disabled")
+		}
+
+		tool.AllowApply = true
+		tool.MaxFiles = 1
+		if _, err := tool.Execute(context.Background(), params); err == nil {
+			t.Error("Expected error when more files than the cap would change")
+		}
+		if got := read(t, filepath.Join(root, "a.go")); !strings.Contains(got, "LoadConfig") {
+			t.Errorf("Expected no files to be written, got %q", got)
+		}
+	})
+
+	t.Run("stays inside the sandbox", func(t *testing.T) {
+		root, outside := setupEscapeFixture(t)
+		tool := NewReplaceTool()
+		tool.Root = root
+
+		if _, err := tool.Execute(context.Background(), map[string]interface{}{"pattern": "secret", "replacement": "x", "apply": true}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if got := read(t, filepath.Join(outside, "secret.txt")); got != "top secret" {
+			t.Errorf("Expected file outside the sandbox to be untouched, got %q", got)
+		}
+		if _, err := tool.Execute(context.Background(), map[string]interface{}{"pattern": "secret", "replacement": "x", "path": "dir-escape"}); err == nil {
+			t.Error("Expected a path outside the sandbox to be denied")
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/agoodway/workie/ignore"
+)
+
+// Sandbox confines file access by tools to a single directory tree
+type Sandbox struct {
+	Root string // Absolute, symlink-free path of the sandbox root
+}
+
+// NewSandbox creates a sandbox rooted at root. An empty root uses the
+// repository root of the current directory (see DefaultSandboxRoot).
+func NewSandbox(root string) (*Sandbox, error) {
+	if root == "" {
+		var err error
+		root, err = DefaultSandboxRoot()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve sandbox root %s: %v", root, err)
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("sandbox directory does not exist: %s", root)
+		}
+		return nil, fmt.Errorf("failed to resolve sandbox root %s: %v", root, err)
+	}
+
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access sandbox directory %s: %v", root, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("sandbox path is not a directory: %s", root)
+	}
+
+	return &Sandbox{Root: resolved}, nil
+}
+
+// DefaultSandboxRoot returns the git repository root containing the current
+// directory, or the current directory itself when not inside a repository.
+// Using the repository root keeps the sandbox the same regardless of which
+// subdirectory workie is run from.
+func DefaultSandboxRoot() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	if output, err := cmd.Output(); err == nil {
+		if root := strings.TrimSpace(string(output)); root != "" {
+			return root, nil
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %v", err)
+	}
+	return cwd, nil
+}
+
+// Resolve turns a tool-supplied path into an absolute path inside the sandbox.
+// Relative paths are taken relative to the sandbox root. Symlinks are resolved
+// before the containment check, including for paths that do not exist yet, so a
+// link inside the sandbox cannot be used to reach files outside it.
+// It returns the resolved path and its path relative to the root.
+func (s *Sandbox) Resolve(path string) (string, string, error) {
+	path = filepath.Clean(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.Root, path)
+	}
+
+	resolved, err := resolveExisting(path)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve path: %v", err)
+	}
+
+	rel, ok := s.relative(resolved)
+	if !ok {
+		return "", "", fmt.Errorf("access denied: path is outside the sandbox (%s)", s.Root)
+	}
+	return resolved, rel, nil
+}
+
+// Contains reports whether path, after resolving symlinks, lies inside the sandbox
+func (s *Sandbox) Contains(path string) bool {
+	resolved, err := resolveExisting(path)
+	if err != nil {
+		return false
+	}
+	_, ok := s.relative(resolved)
+	return ok
+}
+
+// relative returns path relative to the root if it is inside the sandbox
+func (s *Sandbox) relative(path string) (string, bool) {
+	rel, err := filepath.Rel(s.Root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// InGitDir reports whether the absolute, symlink-free path is git metadata: inside
+// the repository's git directory or its common directory (shared by worktrees),
+// or a .git file or directory anywhere. Writing there, e.g. to .git/hooks or
+// core.hooksPath in .git/config, would run code on the next git command.
+func (s *Sandbox) InGitDir(path string) bool {
+	for _, elem := range strings.Split(filepath.ToSlash(path), "/") {
+		if strings.EqualFold(elem, ".git") {
+			return true
+		}
+	}
+
+	cmd := exec.Command("git", "rev-parse", "--git-dir", "--git-common-dir")
+	cmd.Dir = s.Root
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	for _, dir := range strings.Fields(string(output)) {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(s.Root, dir)
+		}
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			dir = resolved
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoreRules are the .workieignore patterns that apply inside a sandbox. They
+// are relative to the repository root, which is above the sandbox root when the
+// sandbox is a subdirectory of the repository.
+type ignoreRules struct {
+	matcher *ignore.Matcher
+	root    string // Directory the patterns are relative to
+}
+
+// loadIgnore loads the .workieignore of the repository containing the sandbox,
+// or of the sandbox root when it is not inside a repository
+func (s *Sandbox) loadIgnore() (*ignoreRules, error) {
+	root := s.Root
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = s.Root
+	if output, err := cmd.Output(); err == nil {
+		if top := strings.TrimSpace(string(output)); top != "" {
+			if resolved, err := filepath.EvalSymlinks(top); err == nil {
+				root = resolved
+			}
+		}
+	}
+
+	matcher, err := ignore.Load(root)
+	if err != nil {
+		return nil, err
+	}
+	return &ignoreRules{matcher: matcher, root: root}, nil
+}
+
+// Match reports whether the absolute, symlink-free path is excluded
+func (r *ignoreRules) Match(path string, isDir bool) bool {
+	rel, err := filepath.Rel(r.root, path)
+	if err != nil {
+		return false
+	}
+	return r.matcher.Match(rel, isDir)
+}
+
+// resolveExisting resolves symlinks in path. When path does not exist, the
+// longest existing prefix is resolved and the remaining elements are appended.
+func resolveExisting(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		return resolved, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	// A dangling symlink must be judged by where it points, not where it lives
+	if info, lerr := os.Lstat(path); lerr == nil && info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		return resolveExisting(target)
+	}
+
+	parent := filepath.Dir(path)
+	if parent == path {
+		return path, nil
+	}
+	resolvedParent, err := resolveExisting(parent)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolvedParent, filepath.Base(path)), nil
+}
@@ -0,0 +1,159 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupEscapeFixture creates a sandbox with symlinks that point outside of it
+func setupEscapeFixture(t *testing.T) (root, outside string) {
+	t.Helper()
+	root = t.TempDir()
+	outside = t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("top secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "inside.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	links := map[string]string{
+		"file-escape":     filepath.Join(outside, "secret.txt"),
+		"dir-escape":      outside,
+		"relative-escape": filepath.Join("..", filepath.Base(outside), "secret.txt"),
+		"dangling-escape": filepath.Join(outside, "new.txt"),
+		"inside-link":     "inside.txt",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, name)); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+	}
+	return root, outside
+}
+
+func TestSandboxResolve(t *testing.T) {
+	root, outside := setupEscapeFixture(t)
+	sandbox, err := NewSandbox(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allowed := []string{"inside.txt", "inside-link", "missing/new.txt", "."}
+	for _, path := range allowed {
+		if _, _, err := sandbox.Resolve(path); err != nil {
+			t.Errorf("Resolve(%q) returned error: %v", path, err)
+		}
+	}
+
+	denied := []string{
+		"file-escape",
+		"dir-escape/secret.txt",
+		"dir-escape/missing.txt",
+		"relative-escape",
+		"dangling-escape",
+		"../" + filepath.Base(outside) + "/secret.txt",
+		filepath.Join(outside, "secret.txt"),
+	}
+	for _, path := range denied {
+		if _, _, err := sandbox.Resolve(path); err == nil || !strings.Contains(err.Error(), "outside the sandbox") {
+			t.Errorf("Resolve(%q) = %v, want outside-sandbox error", path, err)
+		}
+	}
+}
+
+func TestFileSystemToolSymlinkEscape(t *testing.T) {
+	root, _ := setupEscapeFixture(t)
+	tool := NewFileSystemTool()
+	tool.Root = root
+
+	for _, path := range []string{"file-escape", "dir-escape/secret.txt", "relative-escape"} {
+		out, err := tool.Execute(context.Background(), map[string]interface{}{"operation": "read", "path": path})
+		if err == nil {
+			t.Errorf("Expected read of %q to be denied, got: %q", path, out)
+		}
+	}
+
+	out, err := tool.Execute(context.Background(), map[string]interface{}{"operation": "read", "path": "inside-link"})
+	if err != nil || out != "hello" {
+		t.Errorf("Expected symlink inside the sandbox to be readable, got %q, %v", out, err)
+	}
+}
+
+func TestGrepToolSymlinkEscape(t *testing.T) {
+	root, _ := setupEscapeFixture(t)
+	tool := NewGrepTool()
+	tool.Root = root
+
+	out, err := tool.Execute(context.Background(), map[string]interface{}{"pattern": "secret|hello"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if strings.Contains(out, "top secret") {
+		t.Errorf("Expected grep not to follow symlinks outside the sandbox, got:\n%s", out)
+	}
+	if !strings.Contains(out, "hello") {
+		t.Errorf("Expected grep to find matches inside the sandbox, got:\n%s", out)
+	}
+
+	if _, err := tool.Execute(context.Background(), map[string]interface{}{"pattern": "secret", "path": "dir-escape"}); err == nil {
+		t.Error("Expected searching a symlinked directory outside the sandbox to be denied")
+	}
+}
+
+func TestSandboxSubdirectoryHonorsRepositoryIgnore(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	for rel, content := range map[string]string{
+		".workieignore":     "svc/secret.txt\nsvc/private/\n",
+		"svc/ok.txt":        "TOKEN=visible\n",
+		"svc/secret.txt":    "TOKEN=secret\n",
+		"svc/private/k.txt": "TOKEN=private\n",
+	} {
+		path := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root := filepath.Join(repo, "svc")
+	ctx := context.Background()
+
+	fs := NewFileSystemTool()
+	fs.Root = root
+	for _, path := range []string{"secret.txt", "private/k.txt", "private"} {
+		if out, err := fs.Execute(ctx, map[string]interface{}{"operation": "read", "path": path}); err == nil {
+			t.Errorf("Expected %s to be excluded, got %q", path, out)
+		}
+	}
+	out, err := fs.Execute(ctx, map[string]interface{}{"operation": "list", "path": "."})
+	if err != nil || !strings.Contains(out, "ok.txt") || strings.Contains(out, "secret.txt") || strings.Contains(out, "private") {
+		t.Errorf("Expected only ok.txt to be listed, got %q, %v", out, err)
+	}
+
+	grep := NewGrepTool()
+	grep.Root = root
+	out, err = grep.Execute(ctx, map[string]interface{}{"pattern": "TOKEN"})
+	if err != nil || !strings.Contains(out, "visible") || strings.Contains(out, "secret") || strings.Contains(out, "private") {
+		t.Errorf("Expected grep to skip excluded files, got %q, %v", out, err)
+	}
+
+	replace := NewReplaceTool()
+	replace.Root = root
+	out, err = replace.Execute(ctx, map[string]interface{}{"pattern": "TOKEN", "replacement": "KEY"})
+	if err != nil || !strings.Contains(out, "ok.txt") || strings.Contains(out, "secret") || strings.Contains(out, "private") {
+		t.Errorf("Expected replace to skip excluded files, got %q, %v", out, err)
+	}
+}
@@ -2,7 +2,9 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/tmc/langchaingo/llms"
@@ -13,42 +15,60 @@ type SimpleAgent struct {
 	llm      llms.Model
 	registry *ToolRegistry
 	verbose  bool
+	limits   AgentLimits
+	system   string
 }
 
-// NewSimpleAgent creates a new simple agent
+// ErrNoLLM is returned for open-ended queries when no language model is configured
+var ErrNoLLM = errors.New("this question needs AI, which is not configured\n\nBuilt-in questions work without AI, for example:\n  • list files\n  • what is the current branch?\n  • what is the current directory?\n  • suggest a commit message\n\nTo enable AI for general questions:\n  • Add an ai section with model.provider and model.name to .workie.yaml\n  • Run 'workie init' to see an example configuration")
+
+// NewSimpleAgent creates a new simple agent. llm may be nil, in which case only
+// the built-in intents that map directly to tool calls are answered.
 func NewSimpleAgent(llm llms.Model, registry *ToolRegistry, verbose bool) *SimpleAgent {
 	return &SimpleAgent{
 		llm:      llm,
 		registry: registry,
 		verbose:  verbose,
+		limits:   DefaultAgentLimits(),
 	}
 }
 
+// SetLimits replaces the limits applied to open-ended queries
+func (s *SimpleAgent) SetLimits(limits AgentLimits) {
+	s.limits = limits
+}
+
+// SetSystemPrompt sets instructions prepended to the prompt of open-ended queries
+func (s *SimpleAgent) SetSystemPrompt(prompt string) {
+	s.system = prompt
+}
+
 // Execute processes a query with a simplified approach
 func (s *SimpleAgent) Execute(ctx context.Context, query string) (string, error) {
 	// Check for common queries and handle them directly
 	lowerQuery := strings.ToLower(query)
 
-	// Direct handling for file listing (check this first)
+	// Direct handling for file listing (check this first). The filesystem
+	// tool lists the sandbox root, leaving out .workieignore'd files.
 	if strings.Contains(lowerQuery, "list") && (strings.Contains(lowerQuery, "file") || strings.Contains(lowerQuery, "directory")) {
 		if s.verbose {
-			fmt.Println("Detected list files query, using shell tool directly")
+			fmt.Println("Detected list files query, using filesystem tool directly")
 		}
 
-		tool, exists := s.registry.Get("shell")
+		tool, exists := s.registry.Get("filesystem")
 		if !exists {
-			return "", fmt.Errorf("shell tool is not registered in the tool registry")
+			return "", fmt.Errorf("filesystem tool is not registered in the tool registry")
 		}
 		result, err := tool.Execute(ctx, map[string]interface{}{
-			"command": "ls",
-			"args":    []interface{}{"-la"},
+			"operation": "list",
+			"path":      ".",
 		})
 
 		if err != nil {
 			return "", err
 		}
 
-		return fmt.Sprintf("Files in current directory:\n%s", result), nil
+		return fmt.Sprintf("Files in the repository root:\n%s", result), nil
 	}
 
 	// Direct handling for branch queries
@@ -75,22 +95,15 @@ func (s *SimpleAgent) Execute(ctx context.Context, query string) (string, error)
 	// Direct handling for pwd/directory queries
 	if strings.Contains(lowerQuery, "current") && (strings.Contains(lowerQuery, "directory") || strings.Contains(lowerQuery, "folder")) {
 		if s.verbose {
-			fmt.Println("Detected pwd query, using shell tool directly")
+			fmt.Println("Detected pwd query, answering directly")
 		}
 
-		tool, exists := s.registry.Get("shell")
-		if !exists {
-			return "", fmt.Errorf("shell tool is not registered in the tool registry")
-		}
-		result, err := tool.Execute(ctx, map[string]interface{}{
-			"command": "pwd",
-		})
-
+		dir, err := os.Getwd()
 		if err != nil {
-			return "", err
+			return "", fmt.Errorf("failed to get the current directory: %v", err)
 		}
 
-		return fmt.Sprintf("The current directory is: %s", strings.TrimSpace(result)), nil
+		return fmt.Sprintf("The current directory is: %s", dir), nil
 	}
 
 	// Direct handling for commit message generation
@@ -111,10 +124,16 @@ func (s *SimpleAgent) Execute(ctx context.Context, query string) (string, error)
 			format = "detailed"
 		}
 
-		result, err := tool.Execute(ctx, map[string]interface{}{
+		params := map[string]interface{}{
 			"type":   "all",
 			"format": format,
-		})
+		}
+		// "... since origin/main" summarizes the branch's commits instead
+		if since := sinceRef(query); since != "" {
+			params["since"] = since
+		}
+
+		result, err := tool.Execute(ctx, params)
 
 		if err != nil {
 			return "", err
@@ -123,11 +142,28 @@ func (s *SimpleAgent) Execute(ctx context.Context, query string) (string, error)
 		return fmt.Sprintf("Suggested commit message:\n\n%s", result), nil
 	}
 
-	// For other queries, fall back to the OllamaAgent
+	// For other queries, fall back to the OllamaAgent if a model is available
+	if s.llm == nil {
+		return "", ErrNoLLM
+	}
 	agent := NewOllamaAgent(s.llm, s.registry, s.verbose)
+	agent.SetLimits(s.limits)
+	agent.SetSystemPrompt(s.system)
 	return agent.Execute(ctx, query)
 }
 
+// sinceRef returns the ref following "since" in a query, e.g. origin/main in
+// "commit message since origin/main", or "" if there is none
+func sinceRef(query string) string {
+	words := strings.Fields(query)
+	for i := 0; i+1 < len(words); i++ {
+		if strings.EqualFold(words[i], "since") {
+			return strings.TrimRight(words[i+1], "?.,!")
+		}
+	}
+	return ""
+}
+
 // generateCommitMessageWithGit uses git tools to analyze changes
 func (s *SimpleAgent) generateCommitMessageWithGit(ctx context.Context) (string, error) {
 	gitTool, exists := s.registry.Get("git")
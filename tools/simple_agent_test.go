@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSimpleAgentWithoutLLM(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	agent := NewSimpleAgent(nil, DefaultRegistry(root), false)
+
+	// Deterministic intents are answered by tools alone
+	result, err := agent.Execute(context.Background(), "what is the current directory?")
+	if err != nil {
+		t.Fatalf("Expected built-in query to work without an LLM, got: %v", err)
+	}
+	if !strings.HasPrefix(result, "The current directory is:") {
+		t.Errorf("Unexpected result: %q", result)
+	}
+
+	// Files are listed from the sandbox root, not the current directory
+	result, err = agent.Execute(context.Background(), "list files")
+	if err != nil || !strings.Contains(result, "main.go") {
+		t.Errorf("Expected the sandbox root to be listed, got %q, %v", result, err)
+	}
+
+	// Open-ended queries need a model
+	if _, err := agent.Execute(context.Background(), "explain the architecture"); !errors.Is(err, ErrNoLLM) {
+		t.Errorf("Expected ErrNoLLM, got: %v", err)
+	}
+}
+
+func TestSimpleAgentGitAllowlist(t *testing.T) {
+	registry := NewToolRegistry()
+	git := NewGitTool()
+	git.AllowedCommands = []string{"log"}
+	registry.Register(git)
+	agent := NewSimpleAgent(nil, registry, false)
+
+	// Built-in intents call the git tool, so they are held to its allowlist
+	_, err := agent.Execute(context.Background(), "what is the current branch?")
+	if err == nil || !strings.Contains(err.Error(), "git command 'branch' is not allowed") {
+		t.Errorf("Expected the branch query to be refused, got: %v", err)
+	}
+}
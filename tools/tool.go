@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"sort"
 )
 
 // Tool represents a function that can be called by the AI
@@ -17,6 +18,18 @@ type Tool interface {
 	Execute(ctx context.Context, params map[string]interface{}) (string, error)
 }
 
+// DefaultMaxFileSize is the size above which file-reading tools skip a file (10MB)
+const DefaultMaxFileSize int64 = 10 * 1024 * 1024
+
+// maxFileSizeParam returns the max_file_size parameter if present, otherwise fallback.
+// A value of 0 or less disables the limit.
+func maxFileSizeParam(params map[string]interface{}, fallback int64) int64 {
+	if v, ok := params["max_file_size"].(float64); ok {
+		return int64(v)
+	}
+	return fallback
+}
+
 // ToolRegistry manages available tools
 type ToolRegistry struct {
 	tools map[string]Tool
@@ -40,15 +53,53 @@ func (r *ToolRegistry) Get(name string) (Tool, bool) {
 	return tool, ok
 }
 
-// List returns all registered tools
+// List returns all registered tools, sorted by name
 func (r *ToolRegistry) List() []Tool {
 	tools := make([]Tool, 0, len(r.tools))
 	for _, tool := range r.tools {
 		tools = append(tools, tool)
 	}
+	sort.Slice(tools, func(i, j int) bool {
+		return tools[i].Name() < tools[j].Name()
+	})
 	return tools
 }
 
+// DefaultRegistry returns a registry with the tools available to the agent. File
+// and git tools are confined to root (empty means the repository root). The
+// replace tool only previews changes and the filesystem tool can't write; set
+// their AllowApply and AllowWrite fields to let them change files.
+func DefaultRegistry(root string) *ToolRegistry {
+	registry := NewToolRegistry()
+
+	fsTool := NewFileSystemTool()
+	fsTool.Root = root
+	fsTool.AllowWrite = false
+	registry.Register(fsTool)
+
+	grepTool := NewGrepTool()
+	grepTool.Root = root
+	registry.Register(grepTool)
+
+	replaceTool := NewReplaceTool()
+	replaceTool.Root = root
+	replaceTool.AllowApply = false
+	registry.Register(replaceTool)
+
+	gitTool := NewGitTool()
+	gitTool.Root = root
+	registry.Register(gitTool)
+
+	commitTool := NewCommitMessageTool()
+	commitTool.Root = root
+	registry.Register(commitTool)
+
+	// The shell tool is left out: its commands run in the current directory
+	// with any arguments, outside the sandbox and .workieignore
+
+	return registry
+}
+
 // ToolCall represents a request to execute a tool
 type ToolCall struct {
 	Name       string                 `json:"name"`
@@ -0,0 +1,35 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	registry := DefaultRegistry(t.TempDir())
+
+	var names []string
+	for _, tool := range registry.List() {
+		names = append(names, tool.Name())
+	}
+	want := "commit_message,filesystem,git,grep,replace"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("Expected tools %s in name order, got %s", want, got)
+	}
+
+	tool, ok := registry.Get("replace")
+	if !ok {
+		t.Fatal("Expected the replace tool to be registered")
+	}
+	if tool.(*ReplaceTool).AllowApply {
+		t.Error("Expected the default replace tool to only preview changes")
+	}
+
+	tool, ok = registry.Get("filesystem")
+	if !ok {
+		t.Fatal("Expected the filesystem tool to be registered")
+	}
+	if tool.(*FileSystemTool).AllowWrite {
+		t.Error("Expected the default filesystem tool not to write files")
+	}
+}